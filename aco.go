@@ -0,0 +1,124 @@
+package palettecalculator
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Photoshop color swatch color spaces
+const (
+	acoRGB       = 0
+	acoHSB       = 1
+	acoCMYK      = 2
+	acoLAB       = 7
+	acoGrayscale = 8
+)
+
+// Reads a Photoshop color swatch (.aco) file, version 1 or 2. RGB, HSB, CMYK, LAB and grayscale swatches are
+// converted to sRGB, swatch names are dropped
+func LoadACOPalette(r io.Reader) (*Palette, error) {
+	pc := new(PaletteCalculator)
+
+	var header struct {
+		Version uint16
+		Count   uint16
+	}
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return nil, fmt.Errorf("invalid aco file: %w", err)
+	}
+	if header.Version != 1 && header.Version != 2 {
+		return nil, fmt.Errorf("invalid aco file: unsupported version %d", header.Version)
+	}
+
+	// version 1 files are followed by the same colors as version 2 with names, the first section is enough
+	p := &Palette{}
+	for i := uint16(0); i < header.Count; i++ {
+		var swatch struct {
+			Space  uint16
+			Values [4]uint16
+		}
+		if err := binary.Read(r, binary.BigEndian, &swatch); err != nil {
+			return nil, fmt.Errorf("invalid aco color %d: %w", i+1, err)
+		}
+		if header.Version == 2 {
+			if err := skipACOName(r); err != nil {
+				return nil, fmt.Errorf("invalid aco color %d: %w", i+1, err)
+			}
+		}
+
+		c, err := pc.acoColor(swatch.Space, swatch.Values)
+		if err != nil {
+			return nil, fmt.Errorf("invalid aco color %d: %w", i+1, err)
+		}
+		p.Colors = append(p.Colors, *c)
+	}
+
+	return p, nil
+}
+
+// Skips a version 2 swatch name, a zero word, a length and that many UTF-16 code units including a null
+func skipACOName(r io.Reader) error {
+	var name struct {
+		Zero   uint16
+		Length uint16
+	}
+	if err := binary.Read(r, binary.BigEndian, &name); err != nil {
+		return err
+	}
+	if _, err := io.CopyN(io.Discard, r, 2*int64(name.Length)); err != nil {
+		return errors.New("truncated swatch name")
+	}
+
+	return nil
+}
+
+func (pc *PaletteCalculator) acoColor(space uint16, values [4]uint16) (*Color, error) {
+	w, x, y, z := float64(values[0]), float64(values[1]), float64(values[2]), float64(values[3])
+
+	switch space {
+	case acoRGB:
+		return pc.unitRGBToColor(w/65535, x/65535, y/65535), nil
+	case acoHSB:
+		return pc.hsbToRGB(w/65535*360, x/65535, y/65535), nil
+	case acoCMYK:
+		// 0 is full ink
+		return pc.cmykToRGB(1-w/65535, 1-x/65535, 1-y/65535, 1-z/65535), nil
+	case acoLAB:
+		return pc.ConvertLABToRGB(&LAB{L: w / 100, A: float64(int16(values[1])) / 100, B: float64(int16(values[2])) / 100}), nil
+	case acoGrayscale:
+		// gray is ink coverage, 10000 is black
+		gray := 1 - w/10000
+		return pc.unitRGBToColor(gray, gray, gray), nil
+	}
+
+	return nil, fmt.Errorf("unsupported color space %d", space)
+}
+
+// Converts hue in degrees, saturation and brightness in [0,1] to a Color
+func (pc *PaletteCalculator) hsbToRGB(hue float64, saturation float64, brightness float64) *Color {
+	chroma := brightness * saturation
+	sector := math.Mod(hue, 360) / 60
+	second := chroma * (1 - math.Abs(math.Mod(sector, 2)-1))
+
+	var r, g, b float64
+	switch int(sector) {
+	case 0:
+		r, g = chroma, second
+	case 1:
+		r, g = second, chroma
+	case 2:
+		g, b = chroma, second
+	case 3:
+		g, b = second, chroma
+	case 4:
+		r, b = second, chroma
+	default:
+		r, b = chroma, second
+	}
+	m := brightness - chroma
+
+	return pc.unitRGBToColor(r+m, g+m, b+m)
+}
@@ -0,0 +1,62 @@
+package palettecalculator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestLoadACOPalette(t *testing.T) {
+	swatches := [][5]uint16{
+		{acoRGB, 24 * 257, 98 * 257, 119 * 257, 0},
+		{acoHSB, 0, 65535, 65535, 0},
+		{acoCMYK, 65535, 65535, 65535, 65535},
+		{acoLAB, 10000, 0, 0, 0},
+		{acoGrayscale, 10000, 0, 0, 0},
+	}
+	expectedPalette := &Palette{Colors: []Color{{24, 98, 119, "186277"}, {255, 0, 0, "ff0000"}, {255, 255, 255, "ffffff"}, {255, 255, 255, "ffffff"}, {0, 0, 0, "000000"}}}
+
+	for _, version := range []uint16{1, 2} {
+		var file bytes.Buffer
+		binary.Write(&file, binary.BigEndian, []uint16{version, uint16(len(swatches))})
+		for _, swatch := range swatches {
+			binary.Write(&file, binary.BigEndian, swatch)
+			if version == 2 {
+				binary.Write(&file, binary.BigEndian, []uint16{0, 2, 'a', 0})
+			}
+		}
+
+		returnedPalette, err := LoadACOPalette(&file)
+
+		if err != nil {
+			t.Errorf("version %d expected error: %v returned error: %v", version, nil, err)
+		}
+		if !reflect.DeepEqual(expectedPalette, returnedPalette) {
+			t.Errorf("version %d expected: %v\n returned: %v\n", version, expectedPalette, returnedPalette)
+		}
+	}
+}
+
+func TestLoadACOPaletteWithInvalidFile(t *testing.T) {
+	tests := []struct {
+		file        []uint16
+		expectedErr error
+	}{
+		{[]uint16{3, 0}, errors.New("invalid aco file: unsupported version 3")},
+		{[]uint16{1, 1, 9, 0, 0, 0, 0}, fmt.Errorf("invalid aco color 1: %w", errors.New("unsupported color space 9"))},
+	}
+
+	for _, test := range tests {
+		var file bytes.Buffer
+		binary.Write(&file, binary.BigEndian, test.file)
+
+		_, err := LoadACOPalette(&file)
+
+		if !reflect.DeepEqual(test.expectedErr, err) {
+			t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package palettecalculator
+
+import "fmt"
+
+// Maximum passes over the pairs, adjusting one pair can break another that shares a color
+const maxAdjustPasses = 10
+
+// Foreground and background palette indices that must meet a contrast level together
+type ContrastPair struct {
+	Foreground int      `json:"foreground"`
+	Background int      `json:"background"`
+	Size       TextSize `json:"size"`
+}
+
+// Adjusts CIELAB lightness of palette colors by the smallest amount that makes every pair meet level, preserving
+// hue. Foregrounds are adjusted first, backgrounds only when the foreground alone cannot reach the level.
+// Returns the adjusted copy of palette, or an error if a pair cannot be satisfied
+func (pc *PaletteCalculator) AdjustForContrast(palette []Color, pairs []ContrastPair, level ContrastLevel) ([]Color, error) {
+	adjusted := append([]Color(nil), palette...)
+
+	for _, pair := range pairs {
+		if pair.Foreground < 0 || pair.Foreground >= len(adjusted) || pair.Background < 0 || pair.Background >= len(adjusted) {
+			return nil, fmt.Errorf("contrast pair %d/%d out of range for palette of %d colors", pair.Foreground, pair.Background, len(adjusted))
+		}
+	}
+
+	for pass := 0; pass < maxAdjustPasses; pass++ {
+		changed := false
+		for _, pair := range pairs {
+			fg, bg := &adjusted[pair.Foreground], &adjusted[pair.Background]
+			required := pc.requiredContrast(level, pair.Size)
+			if pc.ContrastRatio(fg, bg) >= required {
+				continue
+			}
+
+			changed = true
+			if c, ok := pc.adjustLightness(fg, bg, required); ok {
+				*fg = *c
+				continue
+			}
+
+			// foreground can't get there alone, move background away from the foreground's best extreme and retry
+			if c, ok := pc.adjustLightness(bg, pc.mostContrastingExtreme(fg, bg), required); ok {
+				*bg = *c
+				if c, ok := pc.adjustLightness(fg, bg, required); ok {
+					*fg = *c
+				}
+			}
+		}
+
+		if !changed {
+			return adjusted, nil
+		}
+	}
+
+	for _, pair := range pairs {
+		if pc.ContrastRatio(&adjusted[pair.Foreground], &adjusted[pair.Background]) < pc.requiredContrast(level, pair.Size) {
+			return nil, fmt.Errorf("unable to meet contrast for pair %d/%d", pair.Foreground, pair.Background)
+		}
+	}
+	return adjusted, nil
+}
+
+// Finds the color closest in lightness to c, keeping its hue, that reaches required contrast against other
+func (pc *PaletteCalculator) adjustLightness(c *Color, other *Color, required float64) (*Color, bool) {
+	if pc.ContrastRatio(c, other) >= required {
+		return c, true
+	}
+
+	lch := pc.ConvertLABToLCH(pc.ConvertRGBToLAB(c))
+	for delta := float64(1); delta <= 100; delta++ {
+		for _, l := range []float64{lch.L + delta, lch.L - delta} {
+			if l < 0 || l > 100 {
+				continue
+			}
+			candidate := pc.ConvertLCHToRGB(&LCH{L: l, C: lch.C, H: lch.H})
+			if pc.ContrastRatio(candidate, other) >= required {
+				return candidate, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// The lightness extreme (black or white, keeping c's hue) furthest in contrast from other
+func (pc *PaletteCalculator) mostContrastingExtreme(c *Color, other *Color) *Color {
+	lch := pc.ConvertLABToLCH(pc.ConvertRGBToLAB(c))
+	light := pc.ConvertLCHToRGB(&LCH{L: 100, C: lch.C, H: lch.H})
+	dark := pc.ConvertLCHToRGB(&LCH{L: 0, C: lch.C, H: lch.H})
+
+	if pc.ContrastRatio(dark, other) > pc.ContrastRatio(light, other) {
+		return dark
+	}
+	return light
+}
@@ -0,0 +1,61 @@
+package palettecalculator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestAdjustForContrast(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		pairs       []ContrastPair
+		level       ContrastLevel
+		expectedRGB []Color
+		expectedErr error
+	}{
+		{
+			name:        "should adjust failing foregrounds by smallest lightness change",
+			pairs:       []ContrastPair{{Foreground: 0, Background: 1}, {Foreground: 3, Background: 2}},
+			level:       AA,
+			expectedRGB: []Color{{123, 186, 210, "7bbad2"}, {119, 45, 24, "772d18"}, {240, 240, 240, "f0f0f0"}, {109, 109, 109, "6d6d6d"}},
+			expectedErr: nil,
+		},
+		{
+			name:        "should leave passing pairs unchanged",
+			pairs:       []ContrastPair{{Foreground: 1, Background: 2}},
+			level:       AAA,
+			expectedRGB: []Color{{Red, Green, Blue, Hex}, {119, 45, 24, "772d18"}, {240, 240, 240, "f0f0f0"}, {119, 119, 119, "777777"}},
+			expectedErr: nil,
+		},
+		{
+			name:        "error occurs when pair is out of range",
+			pairs:       []ContrastPair{{Foreground: 0, Background: 9}},
+			level:       AA,
+			expectedRGB: nil,
+			expectedErr: errors.New("contrast pair 0/9 out of range for palette of 4 colors"),
+		},
+		{
+			name:        "error occurs when pair cannot be satisfied",
+			pairs:       []ContrastPair{{Foreground: 0, Background: 0}},
+			level:       AA,
+			expectedRGB: nil,
+			expectedErr: errors.New("unable to meet contrast for pair 0/0"),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			palette := []Color{{Red, Green, Blue, Hex}, {119, 45, 24, "772d18"}, {240, 240, 240, "f0f0f0"}, {119, 119, 119, "777777"}}
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedRGB, err := paletteCalculator.AdjustForContrast(palette, test.pairs, test.level)
+
+			if !reflect.DeepEqual(test.expectedRGB, returnedRGB) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedRGB, returnedRGB)
+			}
+
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package palettecalculator
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+// Channel levels of the xterm 6x6x6 color cube
+var ansiCubeLevels = []float64{0, 95, 135, 175, 215, 255}
+
+// The xterm 256 color palette by index. 0-15 are the default xterm system colors, which terminal themes often
+// override, 16-231 the 6x6x6 color cube and 232-255 a gray ramp
+var ANSIPalette = ansiPalette()
+
+func ansiPalette() []Color {
+	var palette []Color
+	for _, hex := range []string{
+		"000000", "cd0000", "00cd00", "cdcd00", "0000ee", "cd00cd", "00cdcd", "e5e5e5",
+		"7f7f7f", "ff0000", "00ff00", "ffff00", "5c5cff", "ff00ff", "00ffff", "ffffff",
+	} {
+		c, _ := ParseHex(hex)
+		palette = append(palette, *c)
+	}
+	for _, r := range ansiCubeLevels {
+		for _, g := range ansiCubeLevels {
+			for _, b := range ansiCubeLevels {
+				palette = append(palette, Color{Red: r, Green: g, Blue: b, Hex: hexString(r, g, b)})
+			}
+		}
+	}
+	for i := 0; i < 24; i++ {
+		gray := float64(8 + 10*i)
+		palette = append(palette, Color{Red: gray, Green: gray, Blue: gray, Hex: hexString(gray, gray, gray)})
+	}
+
+	return palette
+}
+
+// Finds the xterm 256 color closest to c by CIEDE2000. Only the standardized indices 16-255 are considered,
+// system colors depend on the terminal theme
+func (pc *PaletteCalculator) ToANSI256(c *Color) int {
+	return 16 + pc.nearestANSI(c, ANSIPalette[16:])
+}
+
+// Finds the 16 color system index closest to c by CIEDE2000, assuming the xterm defaults
+func (pc *PaletteCalculator) ToANSI16(c *Color) int {
+	return pc.nearestANSI(c, ANSIPalette[:16])
+}
+
+// Returns the color of an xterm 256 color index
+func (pc *PaletteCalculator) FromANSI(index int) (*Color, error) {
+	if index < 0 || index >= len(ANSIPalette) {
+		return nil, fmt.Errorf("invalid ansi color index: %d", index)
+	}
+
+	c := ANSIPalette[index]
+	return &c, nil
+}
+
+func (pc *PaletteCalculator) nearestANSI(c *Color, palette []Color) int {
+	nearest, distance := 0, math.Inf(1)
+	for i := range palette {
+		if d := pc.DistanceDeltaE(c, &palette[i], CIEDE2000); d < distance {
+			nearest, distance = i, d
+		}
+	}
+
+	return nearest
+}
+
+// Prints each palette color as a colored block followed by its hex. Uses 24-bit escape codes when COLORTERM
+// advertises truecolor support and the nearest xterm 256 colors otherwise
+func (p *Palette) PrintANSI(w io.Writer) error {
+	colorTerm := os.Getenv("COLORTERM")
+	return p.printANSI(w, colorTerm == "truecolor" || colorTerm == "24bit")
+}
+
+func (p *Palette) printANSI(w io.Writer, truecolor bool) error {
+	pc := new(PaletteCalculator)
+
+	var sb strings.Builder
+	for i := range p.Colors {
+		c := p.Colors[i].Clamp()
+		if truecolor {
+			sb.WriteString(fmt.Sprintf("\x1b[48;2;%d;%d;%dm", int(c.Red), int(c.Green), int(c.Blue)))
+		} else {
+			sb.WriteString(fmt.Sprintf("\x1b[48;5;%dm", pc.ToANSI256(c)))
+		}
+		sb.WriteString(fmt.Sprintf("      \x1b[0m %s\n", cssHex(c)))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
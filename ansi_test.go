@@ -0,0 +1,90 @@
+package palettecalculator
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestToANSI(t *testing.T) {
+	for _, test := range []struct {
+		name            string
+		color           *Color
+		expectedANSI256 int
+		expectedANSI16  int
+	}{
+		{name: "should map dominant color", color: &Color{Red, Green, Blue, Hex}, expectedANSI256: 24, expectedANSI16: 12},
+		{name: "should map red", color: &Color{255, 0, 0, "ff0000"}, expectedANSI256: 196, expectedANSI16: 9},
+		{name: "should map gray to gray ramp", color: &Color{128, 128, 128, "808080"}, expectedANSI256: 244, expectedANSI16: 8},
+		{name: "should map black to color cube", color: &Color{0, 0, 0, "000000"}, expectedANSI256: 16, expectedANSI16: 0},
+		{name: "should map white to color cube", color: &Color{255, 255, 255, "ffffff"}, expectedANSI256: 231, expectedANSI16: 15},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedANSI256 := paletteCalculator.ToANSI256(test.color)
+			returnedANSI16 := paletteCalculator.ToANSI16(test.color)
+
+			if test.expectedANSI256 != returnedANSI256 {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedANSI256, returnedANSI256)
+			}
+
+			if test.expectedANSI16 != returnedANSI16 {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedANSI16, returnedANSI16)
+			}
+		})
+	}
+}
+
+func TestFromANSI(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		index         int
+		expectedColor *Color
+		expectedErr   error
+	}{
+		{name: "should return system color", index: 12, expectedColor: &Color{92, 92, 255, "5c5cff"}},
+		{name: "should return color cube color", index: 24, expectedColor: &Color{0, 95, 135, "005f87"}},
+		{name: "should return gray ramp color", index: 244, expectedColor: &Color{128, 128, 128, "808080"}},
+		{name: "error occurs for index out of range", index: 256, expectedErr: errors.New("invalid ansi color index: 256")},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedColor, err := paletteCalculator.FromANSI(test.index)
+
+			if !reflect.DeepEqual(test.expectedColor, returnedColor) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedColor, returnedColor)
+			}
+
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestPalettePrintANSI(t *testing.T) {
+	palette := &Palette{Colors: []Color{{24, 98, 119, "186277"}, {255, 255, 255, "ffffff"}}}
+	tests := []struct {
+		truecolor bool
+		expected  string
+	}{
+		{true, "\x1b[48;2;24;98;119m      \x1b[0m #186277\n\x1b[48;2;255;255;255m      \x1b[0m #ffffff\n"},
+		{false, "\x1b[48;5;24m      \x1b[0m #186277\n\x1b[48;5;231m      \x1b[0m #ffffff\n"},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+
+		err := palette.printANSI(&buf, test.truecolor)
+
+		if err != nil {
+			t.Errorf("expected error: %v returned error: %v", nil, err)
+		}
+		if test.expected != buf.String() {
+			t.Errorf("expected: %q\n returned: %q\n", test.expected, buf.String())
+		}
+	}
+}
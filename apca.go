@@ -0,0 +1,70 @@
+package palettecalculator
+
+import "math"
+
+// APCA-W3 0.0.98G-4g constants
+const apcaBlackThreshold = 0.022
+const apcaBlackClamp = 1.414
+const apcaDeltaYMin = 0.0005
+const apcaNormBG = 0.56
+const apcaNormText = 0.57
+const apcaRevBG = 0.65
+const apcaRevText = 0.62
+const apcaScale = 1.14
+const apcaLowOffset = 0.027
+const apcaLowClip = 0.1
+
+// Calculates APCA (WCAG 3 draft) lightness contrast Lc of text on background. Positive for dark text on light
+// backgrounds, negative for light text on dark backgrounds, roughly -108 to 106
+func (pc *PaletteCalculator) APCAContrast(text *Color, bg *Color) float64 {
+	yText := pc.apcaLuminance(text)
+	yBG := pc.apcaLuminance(bg)
+
+	if math.Abs(yBG-yText) < apcaDeltaYMin {
+		return 0
+	}
+
+	if yBG > yText {
+		sapc := (math.Pow(yBG, apcaNormBG) - math.Pow(yText, apcaNormText)) * apcaScale
+		if sapc < apcaLowClip {
+			return 0
+		}
+		return (sapc - apcaLowOffset) * 100
+	}
+
+	sapc := (math.Pow(yBG, apcaRevBG) - math.Pow(yText, apcaRevText)) * apcaScale
+	if sapc > -apcaLowClip {
+		return 0
+	}
+	return (sapc + apcaLowOffset) * 100
+}
+
+// Minimum absolute Lc for text of the given size (px) and weight (100-900), following APCA bronze simple mode
+func (pc *PaletteCalculator) RequiredAPCAContrast(fontSize float64, fontWeight int) float64 {
+	bold := fontWeight >= 700
+
+	switch {
+	case fontSize >= 36 || (fontSize >= 24 && bold):
+		return 45
+	case fontSize >= 24 || (fontSize >= 16 && bold):
+		return 60
+	case fontSize >= 18 || (fontSize >= 14 && bold):
+		return 75
+	}
+	return 90
+}
+
+// Reports whether text on background meets APCA bronze simple mode for the given size (px) and weight
+func (pc *PaletteCalculator) PassesAPCA(text *Color, bg *Color, fontSize float64, fontWeight int) bool {
+	return math.Abs(pc.APCAContrast(text, bg)) >= pc.RequiredAPCAContrast(fontSize, fontWeight)
+}
+
+// APCA screen luminance, a simple 2.4 exponent with a soft clamp near black
+func (pc *PaletteCalculator) apcaLuminance(c *Color) float64 {
+	y := 0.2126729*math.Pow(c.Red/RGBMax, 2.4) + 0.7151522*math.Pow(c.Green/RGBMax, 2.4) + 0.0721750*math.Pow(c.Blue/RGBMax, 2.4)
+	if y < apcaBlackThreshold {
+		y += math.Pow(apcaBlackThreshold-y, apcaBlackClamp)
+	}
+
+	return y
+}
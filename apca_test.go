@@ -0,0 +1,55 @@
+package palettecalculator
+
+import (
+	"testing"
+)
+
+func TestAPCAContrast(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		text       *Color
+		bg         *Color
+		expectedLc float64
+	}{
+		{name: "black on white", text: &Color{0, 0, 0, "000000"}, bg: &Color{255, 255, 255, "ffffff"}, expectedLc: 106.04},
+		{name: "white on black", text: &Color{255, 255, 255, "ffffff"}, bg: &Color{0, 0, 0, "000000"}, expectedLc: -107.88},
+		{name: "gray on white", text: &Color{136, 136, 136, "888888"}, bg: &Color{255, 255, 255, "ffffff"}, expectedLc: 63.06},
+		{name: "dominant color on white", text: &Color{Red, Green, Blue, Hex}, bg: &Color{255, 255, 255, "ffffff"}, expectedLc: 83.45},
+		{name: "identical colors", text: &Color{Red, Green, Blue, Hex}, bg: &Color{Red, Green, Blue, Hex}, expectedLc: 0},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedLc := roundFloat(paletteCalculator.APCAContrast(test.text, test.bg), 2)
+
+			if test.expectedLc != returnedLc {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedLc, returnedLc)
+			}
+		})
+	}
+}
+
+func TestPassesAPCA(t *testing.T) {
+	for _, test := range []struct {
+		name           string
+		text           *Color
+		fontSize       float64
+		fontWeight     int
+		expectedPasses bool
+	}{
+		{name: "gray fails body text", text: &Color{136, 136, 136, "888888"}, fontSize: 16, fontWeight: 400, expectedPasses: false},
+		{name: "gray passes large text", text: &Color{136, 136, 136, "888888"}, fontSize: 24, fontWeight: 400, expectedPasses: true},
+		{name: "dominant color passes bold body text", text: &Color{Red, Green, Blue, Hex}, fontSize: 14, fontWeight: 700, expectedPasses: true},
+		{name: "dominant color fails small body text", text: &Color{Red, Green, Blue, Hex}, fontSize: 14, fontWeight: 400, expectedPasses: false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedPasses := paletteCalculator.PassesAPCA(test.text, &Color{255, 255, 255, "ffffff"}, test.fontSize, test.fontWeight)
+
+			if test.expectedPasses != returnedPasses {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedPasses, returnedPasses)
+			}
+		})
+	}
+}
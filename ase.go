@@ -0,0 +1,136 @@
+package palettecalculator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf16"
+)
+
+// Adobe Swatch Exchange block types
+const (
+	aseColorBlock      = 0x0001
+	aseGroupStartBlock = 0xc001
+)
+
+// Reads an Adobe Swatch Exchange (.ase) file. RGB, CMYK, LAB and gray swatches are converted to sRGB, the
+// palette is named after the first group. Swatch names are dropped
+func LoadASEPalette(r io.Reader) (*Palette, error) {
+	pc := new(PaletteCalculator)
+
+	var header struct {
+		Signature [4]byte
+		Major     uint16
+		Minor     uint16
+		Blocks    uint32
+	}
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return nil, fmt.Errorf("invalid ase file: %w", err)
+	}
+	if string(header.Signature[:]) != "ASEF" {
+		return nil, errors.New("invalid ase file: missing ASEF signature")
+	}
+
+	p := &Palette{}
+	for i := uint32(0); i < header.Blocks; i++ {
+		var block struct {
+			Type   uint16
+			Length uint32
+		}
+		if err := binary.Read(r, binary.BigEndian, &block); err != nil {
+			return nil, fmt.Errorf("invalid ase block %d: %w", i+1, err)
+		}
+		data := make([]byte, block.Length)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, fmt.Errorf("invalid ase block %d: %w", i+1, err)
+		}
+
+		switch block.Type {
+		case aseGroupStartBlock:
+			if p.Name == "" {
+				name, err := readASEName(bytes.NewReader(data))
+				if err != nil {
+					return nil, fmt.Errorf("invalid ase block %d: %w", i+1, err)
+				}
+				p.Name = name
+			}
+		case aseColorBlock:
+			c, err := pc.readASEColor(data)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ase block %d: %w", i+1, err)
+			}
+			p.Colors = append(p.Colors, *c)
+		}
+	}
+
+	return p, nil
+}
+
+// Reads a length prefixed, null terminated UTF-16 name
+func readASEName(r io.Reader) (string, error) {
+	var length uint16
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return "", err
+	}
+	name := make([]uint16, length)
+	if err := binary.Read(r, binary.BigEndian, name); err != nil {
+		return "", err
+	}
+	if length > 0 && name[length-1] == 0 {
+		name = name[:length-1]
+	}
+
+	return string(utf16.Decode(name)), nil
+}
+
+func (pc *PaletteCalculator) readASEColor(data []byte) (*Color, error) {
+	r := bytes.NewReader(data)
+	if _, err := readASEName(r); err != nil {
+		return nil, err
+	}
+
+	var model [4]byte
+	if err := binary.Read(r, binary.BigEndian, &model); err != nil {
+		return nil, err
+	}
+
+	var values []float32
+	switch string(model[:]) {
+	case "RGB ", "LAB ":
+		values = make([]float32, 3)
+	case "CMYK":
+		values = make([]float32, 4)
+	case "Gray":
+		values = make([]float32, 1)
+	default:
+		return nil, fmt.Errorf("unsupported color model %q", string(model[:]))
+	}
+	if err := binary.Read(r, binary.BigEndian, values); err != nil {
+		return nil, err
+	}
+
+	v := func(i int) float64 { return float64(values[i]) }
+	switch string(model[:]) {
+	case "LAB ":
+		return pc.ConvertLABToRGB(&LAB{L: v(0) * 100, A: v(1), B: v(2)}), nil
+	case "CMYK":
+		return pc.cmykToRGB(v(0), v(1), v(2), v(3)), nil
+	case "Gray":
+		return pc.unitRGBToColor(v(0), v(0), v(0)), nil
+	}
+
+	return pc.unitRGBToColor(v(0), v(1), v(2)), nil
+}
+
+// Naive CMYK conversion without an ICC profile, components in [0,1]
+func (pc *PaletteCalculator) cmykToRGB(c float64, m float64, y float64, k float64) *Color {
+	return pc.unitRGBToColor((1-c)*(1-k), (1-m)*(1-k), (1-y)*(1-k))
+}
+
+// Color of sRGB channels in [0,1], rounded and clamped
+func (pc *PaletteCalculator) unitRGBToColor(r float64, g float64, b float64) *Color {
+	c := &Color{Red: r * RGBMax, Green: g * RGBMax, Blue: b * RGBMax}
+	return c.Clamp()
+}
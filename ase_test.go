@@ -0,0 +1,77 @@
+package palettecalculator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"unicode/utf16"
+)
+
+func aseBlock(blockType uint16, name string, body ...interface{}) []byte {
+	var data bytes.Buffer
+	encoded := append(utf16.Encode([]rune(name)), 0)
+	binary.Write(&data, binary.BigEndian, uint16(len(encoded)))
+	binary.Write(&data, binary.BigEndian, encoded)
+	for _, v := range body {
+		binary.Write(&data, binary.BigEndian, v)
+	}
+
+	var block bytes.Buffer
+	binary.Write(&block, binary.BigEndian, blockType)
+	binary.Write(&block, binary.BigEndian, uint32(data.Len()))
+	block.Write(data.Bytes())
+	return block.Bytes()
+}
+
+func aseFile(blocks ...[]byte) []byte {
+	var file bytes.Buffer
+	file.WriteString("ASEF")
+	binary.Write(&file, binary.BigEndian, []uint16{1, 0})
+	binary.Write(&file, binary.BigEndian, uint32(len(blocks)))
+	for _, block := range blocks {
+		file.Write(block)
+	}
+	return file.Bytes()
+}
+
+func TestLoadASEPalette(t *testing.T) {
+	file := aseFile(
+		aseBlock(aseGroupStartBlock, "photo"),
+		aseBlock(aseColorBlock, "navy", []byte("RGB "), []float32{24. / 255, 98. / 255, 119. / 255}, uint16(2)),
+		aseBlock(aseColorBlock, "paper", []byte("CMYK"), []float32{0, 0, 0, 0}, uint16(2)),
+		aseBlock(aseColorBlock, "mid", []byte("Gray"), []float32{.5}, uint16(2)),
+		aseBlock(aseColorBlock, "black", []byte("LAB "), []float32{0, 0, 0}, uint16(2)),
+		aseBlock(0xc002, ""),
+	)
+	expectedPalette := &Palette{Name: "photo", Colors: []Color{{24, 98, 119, "186277"}, {255, 255, 255, "ffffff"}, {128, 128, 128, "808080"}, {0, 0, 0, "000000"}}}
+
+	returnedPalette, err := LoadASEPalette(bytes.NewReader(file))
+
+	if err != nil {
+		t.Errorf("expected error: %v returned error: %v", nil, err)
+	}
+	if !reflect.DeepEqual(expectedPalette, returnedPalette) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedPalette, returnedPalette)
+	}
+}
+
+func TestLoadASEPaletteWithInvalidFile(t *testing.T) {
+	tests := []struct {
+		file        []byte
+		expectedErr error
+	}{
+		{[]byte("ACOF\x00\x01\x00\x00\x00\x00\x00\x00"), errors.New("invalid ase file: missing ASEF signature")},
+		{aseFile(aseBlock(aseColorBlock, "x", []byte("HSV "), []float32{0, 0, 0})), fmt.Errorf("invalid ase block 1: %w", errors.New(`unsupported color model "HSV "`))},
+	}
+
+	for _, test := range tests {
+		_, err := LoadASEPalette(bytes.NewReader(test.file))
+
+		if !reflect.DeepEqual(test.expectedErr, err) {
+			t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
+		}
+	}
+}
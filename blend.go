@@ -0,0 +1,73 @@
+package palettecalculator
+
+import (
+	"fmt"
+)
+
+// Separable blend mode used to composite one color on top of another
+type BlendMode string
+
+const (
+	Multiply BlendMode = "multiply"
+	Screen   BlendMode = "screen"
+	Overlay  BlendMode = "overlay"
+)
+
+// Calculates the mix of two colors in the given space with t parts of b, the same as Interpolate
+func (pc *PaletteCalculator) Mix(a *Color, b *Color, t float64, space InterpolationSpace) *Color {
+	return pc.Interpolate(a, b, t, space)
+}
+
+// Calculates the result of compositing source on top of backdrop with the given blend mode,
+// channels are blended in sRGB or linear RGB
+func (pc *PaletteCalculator) Blend(backdrop *Color, source *Color, mode BlendMode, space InterpolationSpace) (*Color, error) {
+	var blend func(cb float64, cs float64) float64
+	switch mode {
+	case Multiply:
+		blend = pc.multiply
+	case Screen:
+		blend = pc.screen
+	case Overlay:
+		blend = pc.overlay
+	default:
+		return nil, fmt.Errorf("unsupported blend mode: %s", mode)
+	}
+
+	backdropRGB := []float64{backdrop.Red / RGBMax, backdrop.Green / RGBMax, backdrop.Blue / RGBMax}
+	sourceRGB := []float64{source.Red / RGBMax, source.Green / RGBMax, source.Blue / RGBMax}
+
+	switch space {
+	case InterpolateSRGB:
+		var channels []float64
+		for i := range backdropRGB {
+			channels = append(channels, clampChannel(pc.round(blend(backdropRGB[i], sourceRGB[i])*RGBMax, 0)))
+		}
+		r, g, b := channels[RED], channels[GREEN], channels[BLUE]
+		return &Color{Red: r, Green: g, Blue: b, Hex: pc.generateHex(r, g, b)}, nil
+	case InterpolateLinearRGB:
+		var channels []float64
+		for i := range backdropRGB {
+			channels = append(channels, blend(linearize(backdropRGB[i]), linearize(sourceRGB[i])))
+		}
+		return pc.linearRGBToColor(channels[RED], channels[GREEN], channels[BLUE]), nil
+	default:
+		return nil, fmt.Errorf("unsupported blend space: %d", space)
+	}
+}
+
+func (pc *PaletteCalculator) multiply(cb float64, cs float64) float64 {
+	return cb * cs
+}
+
+func (pc *PaletteCalculator) screen(cb float64, cs float64) float64 {
+	return cb + cs - cb*cs
+}
+
+// Overlay is hard light with the layers swapped, it keeps the backdrop's highlights and shadows
+func (pc *PaletteCalculator) overlay(cb float64, cs float64) float64 {
+	if cb <= .5 {
+		return pc.multiply(cs, 2*cb)
+	}
+
+	return pc.screen(cs, 2*cb-1)
+}
@@ -0,0 +1,77 @@
+package palettecalculator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMix(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		amount        float64
+		space         InterpolationSpace
+		expectedColor *Color
+	}{
+		{name: "should mix evenly in sRGB", amount: .5, space: InterpolateSRGB, expectedColor: &Color{72, 72, 72, "484848"}},
+		{name: "should mix in linear RGB", amount: .25, space: InterpolateLinearRGB, expectedColor: &Color{65, 88, 105, "415869"}},
+		{name: "should clamp amount above 1 to the second color", amount: 2, space: InterpolateOKLCH, expectedColor: &Color{119, 45, 24, "772d18"}},
+		{name: "should clamp amount below 0 to the first color", amount: -1, space: InterpolateSRGB, expectedColor: &Color{Red, Green, Blue, Hex}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			pc := &PaletteCalculator{}
+			a := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+			b := &Color{Red: 119, Green: 45, Blue: 24, Hex: "772d18"}
+
+			returnedColor := pc.Mix(a, b, test.amount, test.space)
+
+			if !reflect.DeepEqual(test.expectedColor, returnedColor) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedColor, returnedColor)
+			}
+		})
+	}
+}
+
+func TestBlend(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		mode          BlendMode
+		space         InterpolationSpace
+		expectedColor *Color
+		expectedErr   error
+	}{
+		{name: "should multiply in sRGB", mode: Multiply, space: InterpolateSRGB, expectedColor: &Color{11, 17, 11, "0b110b"}},
+		{name: "should screen in sRGB", mode: Screen, space: InterpolateSRGB, expectedColor: &Color{132, 126, 132, "847e84"}},
+		{name: "should overlay in sRGB", mode: Overlay, space: InterpolateSRGB, expectedColor: &Color{22, 35, 22, "162316"}},
+		{name: "should multiply in linear RGB", mode: Multiply, space: InterpolateLinearRGB, expectedColor: &Color{6, 11, 6, "060b06"}},
+		{name: "should screen in linear RGB", mode: Screen, space: InterpolateLinearRGB, expectedColor: &Color{121, 106, 121, "796a79"}},
+		{
+			name:        "error occurs for unsupported blend mode",
+			mode:        "dodge",
+			space:       InterpolateSRGB,
+			expectedErr: errors.New("unsupported blend mode: dodge"),
+		},
+		{
+			name:        "error occurs for unsupported blend space",
+			mode:        Multiply,
+			space:       InterpolateOKLCH,
+			expectedErr: errors.New("unsupported blend space: 4"),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			pc := &PaletteCalculator{}
+			backdrop := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+			source := &Color{Red: 119, Green: 45, Blue: 24, Hex: "772d18"}
+
+			returnedColor, err := pc.Blend(backdrop, source, test.mode, test.space)
+
+			if !reflect.DeepEqual(test.expectedColor, returnedColor) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedColor, returnedColor)
+			}
+
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,355 @@
+package palettecalculator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+)
+
+// Delta E under which a palette color is taken for the background by ExcludeBackground
+const backgroundDeltaE = 10
+
+// Extraction request built up one option at a time and started with Run, e.g.
+//
+//	result, err := pc.Extract().FromFile("photo.jpg").TopColors(5).ExcludeBackground().Scheme(Triadic).Run(ctx)
+//
+// Option errors, e.g. TopColors(0), are returned by Run. Run does not change the extraction, so one built from a file,
+// uri or image can be Run from several goroutines; a reader is consumed by the first Run
+type Extraction struct {
+	pc                *PaletteCalculator
+	file              string
+	uri               string
+	reader            io.Reader
+	img               image.Image
+	local             bool
+	top               int
+	excludeBackground bool
+	schemes           []extractionScheme
+	err               error
+}
+
+type extractionScheme struct {
+	scheme SchemeType
+	opts   []SchemeOption
+}
+
+// Outcome of an Extraction
+type ExtractionResult struct {
+	// Image's palette, by weight
+	Palette *Palette
+	// Most dominant color of the palette, nil when it has none
+	Predominant *Color
+	// Color of the image's border left out of the palette by ExcludeBackground, nil when none was found
+	Background *Color
+	// Schemes of the predominant color by type
+	Schemes map[SchemeType][]Color
+	// Backend the palette was extracted with, local or vision
+	Backend string
+}
+
+// Starts an extraction request. It uses the Vision API when pc has a Calculator and extracts locally otherwise
+func (pc *PaletteCalculator) Extract() *Extraction {
+	return &Extraction{pc: pc, local: pc.Calculator == nil, top: 5}
+}
+
+// Extracts the image file at path
+func (e *Extraction) FromFile(path string) *Extraction {
+	e.setSource()
+	e.file = path
+	return e
+}
+
+// Extracts the image at uri, e.g. gs://bucket/photo.jpg, with the Vision API
+func (e *Extraction) FromURI(uri string) *Extraction {
+	e.setSource()
+	e.uri = uri
+	return e
+}
+
+// Extracts the image read from r
+func (e *Extraction) FromReader(r io.Reader) *Extraction {
+	e.setSource()
+	e.reader = r
+	return e
+}
+
+// Extracts a decoded image
+func (e *Extraction) FromImage(img image.Image) *Extraction {
+	e.setSource()
+	e.img = img
+	return e
+}
+
+// Extracts locally with ExtractPalette instead of the Vision API
+func (e *Extraction) Local() *Extraction {
+	e.local = true
+	return e
+}
+
+// Extracts with the Vision API, an error when pc has no Calculator
+func (e *Extraction) Vision() *Extraction {
+	if e.pc.Calculator == nil {
+		e.fail(errors.New("vision extraction needs a calculator, use NewPaletteCalculator"))
+	}
+	e.local = false
+	return e
+}
+
+// Keeps the n most dominant colors, 5 unless set. Local extraction quantizes to n colors
+func (e *Extraction) TopColors(n int) *Extraction {
+	if n < 1 || n > 256 {
+		e.fail(fmt.Errorf("invalid top colors %d: expected 1 to 256", n))
+	}
+	e.top = n
+	return e
+}
+
+// Leaves the color of the image's border out of the palette, e.g. the white behind a product photo
+func (e *Extraction) ExcludeBackground() *Extraction {
+	e.excludeBackground = true
+	return e
+}
+
+// Calculates scheme of the predominant color, call it once per scheme wanted
+func (e *Extraction) Scheme(scheme SchemeType, opts ...SchemeOption) *Extraction {
+	e.schemes = append(e.schemes, extractionScheme{scheme: scheme, opts: opts})
+	return e
+}
+
+// Runs the extraction, calling the Vision API with ctx
+func (e *Extraction) Run(ctx context.Context) (result *ExtractionResult, err error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	pc, span := e.pc.WithContext(ctx).startSpan("palettecalculator.Extract")
+	defer func() { span.End(err) }()
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+	data, img, err := e.read(pc, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	result = &ExtractionResult{Schemes: map[SchemeType][]Color{}, Backend: "vision"}
+	if e.excludeBackground {
+		if img == nil {
+			return nil, errors.New("excluding the background needs the image, not a uri")
+		}
+		result.Background = pc.borderColor(img)
+	}
+
+	colors := e.top
+	if result.Background != nil {
+		// one more color to make up for the background when it is left out
+		colors++
+	}
+	var p *Palette
+	switch {
+	case e.local:
+		result.Backend = "local"
+		p, err = pc.ExtractPalette(img, colors)
+	case e.uri != "":
+		p, err = pc.visionExtract(nil, e.uri)
+	default:
+		p, err = pc.visionExtract(bytes.NewReader(data), "")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Background != nil {
+		p = pc.withoutColor(p, result.Background)
+	}
+	result.Palette = pc.topColors(p, e.top)
+	if len(result.Palette.Colors) > 0 {
+		result.Predominant = &result.Palette.Colors[0]
+	}
+
+	for _, s := range e.schemes {
+		if result.Predominant == nil {
+			return nil, errors.New("palette has no colors to calculate a scheme from")
+		}
+		scheme, err := pc.CalculateScheme(result.Predominant, s.scheme, s.opts...)
+		if err != nil {
+			return nil, err
+		}
+		result.Schemes[s.scheme] = scheme
+	}
+
+	return result, nil
+}
+
+// Reads the source into buf, returning the bytes sent to the Vision API and, when needed, the decoded image
+func (e *Extraction) read(pc *PaletteCalculator, buf *bytes.Buffer) ([]byte, image.Image, error) {
+	if e.uri != "" {
+		if e.local {
+			return nil, nil, errors.New("local extraction needs a file, reader or image, not a uri")
+		}
+		return nil, nil, nil
+	}
+	if e.img != nil {
+		if e.local {
+			return nil, e.img, nil
+		}
+		// the Vision API is sent the image as a png
+		if err := png.Encode(buf, e.img); err != nil {
+			return nil, nil, err
+		}
+		return buf.Bytes(), e.img, nil
+	}
+
+	r := e.reader
+	if e.file != "" {
+		f, err := pc.open(e.file)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	if r == nil {
+		return nil, nil, errors.New("extraction has no image, set one with FromFile, FromURI, FromReader or FromImage")
+	}
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, nil, err
+	}
+	data := buf.Bytes()
+	if !e.local && !e.excludeBackground {
+		return data, nil, nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, &DecodeError{Err: err}
+	}
+
+	return data, img, nil
+}
+
+// Opens file with pc's Opener, or os.Open when it has none
+func (pc *PaletteCalculator) open(file string) (*os.File, error) {
+	var (
+		f   *os.File
+		err error
+	)
+	if pc.Opener != nil {
+		f, err = pc.Opener.Open(file)
+	} else {
+		f, err = os.Open(file)
+	}
+	if err != nil {
+		return nil, &FileError{File: file, Err: err}
+	}
+
+	return f, nil
+}
+
+func (e *Extraction) setSource() {
+	if e.file != "" || e.uri != "" || e.reader != nil || e.img != nil {
+		e.fail(errors.New("extraction already has an image"))
+	}
+}
+
+func (e *Extraction) fail(err error) {
+	if e.err == nil {
+		e.err = err
+	}
+}
+
+// Most common color of the border of img, nil when no color covers at least half of it
+func (pc *PaletteCalculator) borderColor(img image.Image) *Color {
+	bounds := img.Bounds()
+	// colors are counted in buckets of 8 levels a channel so noise and compression artifacts count together
+	type bucket struct {
+		count   int
+		r, g, b int
+	}
+	buckets := map[[3]uint8]*bucket{}
+	total := 0
+	add := func(x int, y int) {
+		px := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+		if px.A == 0 {
+			return
+		}
+		key := [3]uint8{px.R >> 3, px.G >> 3, px.B >> 3}
+		b, ok := buckets[key]
+		if !ok {
+			b = new(bucket)
+			buckets[key] = b
+		}
+		b.count++
+		b.r, b.g, b.b = b.r+int(px.R), b.g+int(px.G), b.b+int(px.B)
+		total++
+	}
+	for x := bounds.Min.X; x < bounds.Max.X; x++ {
+		add(x, bounds.Min.Y)
+		if bounds.Dy() > 1 {
+			add(x, bounds.Max.Y-1)
+		}
+	}
+	for y := bounds.Min.Y + 1; y < bounds.Max.Y-1; y++ {
+		add(bounds.Min.X, y)
+		if bounds.Dx() > 1 {
+			add(bounds.Max.X-1, y)
+		}
+	}
+
+	var most *bucket
+	for _, b := range buckets {
+		if most == nil || b.count > most.count {
+			most = b
+		}
+	}
+	if most == nil || most.count*2 < total {
+		return nil
+	}
+
+	r := float64(most.r / most.count)
+	g := float64(most.g / most.count)
+	b := float64(most.b / most.count)
+	return &Color{Red: r, Green: g, Blue: b, Hex: hexString(r, g, b)}
+}
+
+// Copy of p without the colors within backgroundDeltaE of c, the weights of the rest scaled to sum to what they did
+func (pc *PaletteCalculator) withoutColor(p *Palette, c *Color) *Palette {
+	kept := &Palette{Name: p.Name}
+	total, keptTotal := 0.0, 0.0
+	for i := range p.Colors {
+		total += p.weight(i)
+		if pc.DistanceDeltaE(&p.Colors[i], c, CIEDE2000) < backgroundDeltaE {
+			continue
+		}
+		kept.Colors = append(kept.Colors, p.Colors[i])
+		if len(p.Weights) > 0 {
+			kept.Weights = append(kept.Weights, p.weight(i))
+			keptTotal += p.weight(i)
+		}
+	}
+	if keptTotal > 0 {
+		for i := range kept.Weights {
+			kept.Weights[i] *= total / keptTotal
+		}
+	}
+
+	return kept
+}
+
+// First n colors of p, which is ordered by weight
+func (pc *PaletteCalculator) topColors(p *Palette, n int) *Palette {
+	if len(p.Colors) <= n {
+		return p
+	}
+	top := &Palette{Name: p.Name, Colors: p.Colors[:n]}
+	if len(p.Weights) >= n {
+		top.Weights = p.Weights[:n]
+	}
+
+	return top
+}
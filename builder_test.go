@@ -0,0 +1,163 @@
+package palettecalculator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// 10x10 white image with a red block and a blue stripe inside its border
+func backgroundTestImage() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			img.Set(x, y, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+			if x > 0 && x < 9 && y > 0 && y < 9 {
+				img.Set(x, y, color.RGBA{R: 255, A: 255})
+			}
+			if x == 8 && y > 0 && y < 9 {
+				img.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+	return img
+}
+
+func TestExtraction(t *testing.T) {
+	pc := new(PaletteCalculator)
+	img := backgroundTestImage()
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, img); err != nil {
+		t.Fatal(err)
+	}
+	red := Color{Red: 255, Hex: hexString(255, 0, 0)}
+	blue := Color{Blue: 255, Hex: hexString(0, 0, 255)}
+	white := Color{Red: 255, Green: 255, Blue: 255, Hex: hexString(255, 255, 255)}
+
+	for _, test := range []struct {
+		name           string
+		extraction     *Extraction
+		wantColors     []Color
+		wantBackground *Color
+	}{
+		{
+			name:       "top colors of an image",
+			extraction: pc.Extract().FromImage(img).TopColors(3),
+			wantColors: []Color{red, white, blue},
+		},
+		{
+			name:           "background excluded",
+			extraction:     pc.Extract().FromImage(img).TopColors(2).ExcludeBackground(),
+			wantColors:     []Color{red, blue},
+			wantBackground: &white,
+		},
+		{
+			name:           "from a reader",
+			extraction:     pc.Extract().FromReader(bytes.NewReader(encoded.Bytes())).ExcludeBackground(),
+			wantColors:     []Color{red, blue},
+			wantBackground: &white,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := test.extraction.Run(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result.Palette.Colors, test.wantColors) {
+				t.Errorf("expected: %v\n returned: %v\n", test.wantColors, result.Palette.Colors)
+			}
+			if !reflect.DeepEqual(result.Background, test.wantBackground) {
+				t.Errorf("expected: %v\n returned: %v\n", test.wantBackground, result.Background)
+			}
+			if result.Backend != "local" || !reflect.DeepEqual(result.Predominant, &result.Palette.Colors[0]) {
+				t.Errorf("expected: %v\n returned: %v %v\n", "local and the first color", result.Backend, result.Predominant)
+			}
+		})
+	}
+}
+
+func TestExtractionWeights(t *testing.T) {
+	pc := new(PaletteCalculator)
+	result, err := pc.Extract().FromImage(backgroundTestImage()).ExcludeBackground().Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the background's weight is shared out so the rest still sum to 1
+	expected := []float64{.875, .125}
+	for i, weight := range result.Palette.Weights {
+		if math.Abs(weight-expected[i]) > 1e-9 {
+			t.Errorf("expected: %v\n returned: %v\n", expected, result.Palette.Weights)
+		}
+	}
+}
+
+func TestExtractionScheme(t *testing.T) {
+	pc := new(PaletteCalculator)
+	result, err := pc.Extract().FromImage(backgroundTestImage()).ExcludeBackground().Scheme(Triadic).Scheme(Complimentary, OKLCHRotation).Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	triadic, _ := pc.CalculateScheme(result.Predominant, Triadic)
+	complimentary, _ := pc.CalculateScheme(result.Predominant, Complimentary, OKLCHRotation)
+	expected := map[SchemeType][]Color{Triadic: triadic, Complimentary: complimentary}
+	if !reflect.DeepEqual(result.Schemes, expected) {
+		t.Errorf("expected: %v\n returned: %v\n", expected, result.Schemes)
+	}
+}
+
+func TestExtractionErrors(t *testing.T) {
+	pc := new(PaletteCalculator)
+	img := backgroundTestImage()
+
+	for _, test := range []struct {
+		name       string
+		extraction *Extraction
+		wantErr    error
+	}{
+		{
+			name:       "invalid top colors",
+			extraction: pc.Extract().FromImage(img).TopColors(0),
+			wantErr:    errors.New("invalid top colors 0: expected 1 to 256"),
+		},
+		{
+			name:       "no image",
+			extraction: pc.Extract().TopColors(3),
+			wantErr:    errors.New("extraction has no image, set one with FromFile, FromURI, FromReader or FromImage"),
+		},
+		{
+			name:       "two images",
+			extraction: pc.Extract().FromImage(img).FromFile("photo.png"),
+			wantErr:    errors.New("extraction already has an image"),
+		},
+		{
+			name:       "local uri",
+			extraction: pc.Extract().FromURI("gs://bucket/photo.png"),
+			wantErr:    errors.New("local extraction needs a file, reader or image, not a uri"),
+		},
+		{
+			name:       "vision without a calculator",
+			extraction: pc.Extract().FromImage(img).Vision(),
+			wantErr:    errors.New("vision extraction needs a calculator, use NewPaletteCalculator"),
+		},
+		{
+			name:       "undecodable reader",
+			extraction: pc.Extract().FromReader(bytes.NewReader([]byte("not an image"))),
+			wantErr:    &DecodeError{Err: image.ErrFormat},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.extraction.Run(context.Background())
+			if !reflect.DeepEqual(err, test.wantErr) {
+				t.Errorf("expected: %v\n returned: %v\n", test.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Package cache keeps extracted palettes so repeated extractions of the same image skip the work, in memory for a
+// long running process or on disk across CLI runs.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+)
+
+// Palettes by key, e.g. a hash of the image and the extraction options
+type Cache interface {
+	// Returns the palette cached for key, false when it is missing or expired
+	Get(key string) (*palettecalculator.Palette, bool)
+	// Caches p for key, evicting the least recently used palettes over the size of the cache
+	Set(key string, p *palettecalculator.Palette) error
+}
+
+// Cache held in memory, safe for concurrent use
+type Memory struct {
+	// Most palettes kept, zero keeps every palette
+	MaxEntries int
+	// How long a palette is kept after it is set, zero keeps it until it is evicted
+	TTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// Most recently used first
+	order *list.List
+	now   func() time.Time
+}
+
+type memoryEntry struct {
+	key      string
+	palette  *palettecalculator.Palette
+	storedAt time.Time
+}
+
+// Creates a cache of up to maxEntries palettes kept for ttl
+func NewMemory(maxEntries int, ttl time.Duration) *Memory {
+	return &Memory{MaxEntries: maxEntries, TTL: ttl}
+}
+
+func (m *Memory) Get(key string) (*palettecalculator.Palette, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*memoryEntry)
+	if expired(entry.storedAt, m.TTL, m.clock()) {
+		m.order.Remove(element)
+		delete(m.entries, key)
+		return nil, false
+	}
+	m.order.MoveToFront(element)
+
+	return copyPalette(entry.palette), true
+}
+
+func (m *Memory) Set(key string, p *palettecalculator.Palette) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.entries == nil {
+		m.entries = make(map[string]*list.Element)
+		m.order = list.New()
+	}
+	entry := &memoryEntry{key: key, palette: copyPalette(p), storedAt: m.clock()}
+	if element, ok := m.entries[key]; ok {
+		element.Value = entry
+		m.order.MoveToFront(element)
+	} else {
+		m.entries[key] = m.order.PushFront(entry)
+	}
+
+	for m.MaxEntries > 0 && m.order.Len() > m.MaxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryEntry).key)
+	}
+
+	return nil
+}
+
+func (m *Memory) clock() time.Time {
+	if m.now != nil {
+		return m.now()
+	}
+	return time.Now()
+}
+
+// Whether a palette stored at storedAt has outlived ttl, a zero ttl never expires
+func expired(storedAt time.Time, ttl time.Duration, now time.Time) bool {
+	return ttl > 0 && now.Sub(storedAt) >= ttl
+}
+
+// Copies p so callers can't change a cached palette
+func copyPalette(p *palettecalculator.Palette) *palettecalculator.Palette {
+	c := &palettecalculator.Palette{Name: p.Name}
+	c.Colors = append(c.Colors, p.Colors...)
+	c.Weights = append(c.Weights, p.Weights...)
+
+	return c
+}
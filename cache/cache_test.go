@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+)
+
+var (
+	redPalette  = &palettecalculator.Palette{Colors: []palettecalculator.Color{{Red: 255, Hex: "ff0000"}}, Weights: []float64{1}}
+	bluePalette = &palettecalculator.Palette{Colors: []palettecalculator.Color{{Blue: 255, Hex: "0000ff"}}, Weights: []float64{1}}
+)
+
+// Clock advanced by tests
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+// Runs the behavior every Cache shares against c, which keeps palettes for an hour
+func testCache(t *testing.T, c Cache, clock *fakeClock) {
+	if _, ok := c.Get("missing"); ok {
+		t.Errorf("expected a miss for a key never set")
+	}
+	if err := c.Set("red", redPalette); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Set("blue", redPalette); err != nil {
+		t.Fatal(err)
+	}
+	// replaces the palette set for blue
+	if err := c.Set("blue", bluePalette); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		key      string
+		advance  time.Duration
+		expected *palettecalculator.Palette
+	}{
+		{"red", 0, redPalette},
+		{"blue", 0, bluePalette},
+		{"red", 59 * time.Minute, redPalette},
+		{"red", time.Minute, nil},
+		{"blue", 0, nil},
+	}
+
+	for _, test := range tests {
+		clock.t = clock.t.Add(test.advance)
+		returned, ok := c.Get(test.key)
+		if ok != (test.expected != nil) || !reflect.DeepEqual(returned, test.expected) {
+			t.Errorf("expected: %v\n returned: %v\n", test.expected, returned)
+		}
+	}
+}
+
+func TestMemory(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	m := NewMemory(0, time.Hour)
+	m.now = clock.now
+
+	testCache(t, m, clock)
+}
+
+func TestMemoryEviction(t *testing.T) {
+	m := NewMemory(2, 0)
+	m.Set("a", redPalette)
+	m.Set("b", redPalette)
+	// using a makes b the least recently used
+	m.Get("a")
+	m.Set("c", redPalette)
+
+	expected := map[string]bool{"a": true, "b": false, "c": true}
+	for key, cached := range expected {
+		if _, ok := m.Get(key); ok != cached {
+			t.Errorf("expected: %v\n returned: %v\n", cached, ok)
+		}
+	}
+}
+
+func TestMemoryCopies(t *testing.T) {
+	m := NewMemory(0, 0)
+	p := &palettecalculator.Palette{Colors: []palettecalculator.Color{{Red: 255}}}
+	m.Set("p", p)
+	p.Colors[0].Red = 0
+
+	returned, _ := m.Get("p")
+	returned.Colors = nil
+	returned, _ = m.Get("p")
+	if len(returned.Colors) != 1 || returned.Colors[0].Red != 255 {
+		t.Errorf("expected: %v\n returned: %v\n", 255, returned.Colors)
+	}
+}
@@ -0,0 +1,181 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+)
+
+// Size of a disk cache when MaxSize is not set, 64 MiB
+const DefaultMaxSize = 64 << 20
+
+// Cache in a directory, one JSON file per key. Files are shared by every process using the directory, the last
+// write of a key wins.
+type Disk struct {
+	// Directory of the cache files, created by the first Set
+	Dir string
+	// How long a palette is kept after it is set, zero keeps it until it is evicted
+	TTL time.Duration
+	// Largest total size of the cache files in bytes, zero uses DefaultMaxSize
+	MaxSize int64
+
+	now func() time.Time
+}
+
+// Contents of a cache file, the file's modification time is when it was last used
+type diskEntry struct {
+	Key      string                     `json:"key"`
+	StoredAt time.Time                  `json:"stored-at"`
+	Palette  *palettecalculator.Palette `json:"palette"`
+}
+
+// Creates a cache in dir keeping palettes for ttl, up to maxSize bytes
+func NewDisk(dir string, ttl time.Duration, maxSize int64) *Disk {
+	return &Disk{Dir: dir, TTL: ttl, MaxSize: maxSize}
+}
+
+// Directory of the palettecalc cache under the user's cache directory
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "palettecalc"), nil
+}
+
+func (d *Disk) Get(key string) (*palettecalculator.Palette, bool) {
+	path := d.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var entry diskEntry
+	// a file of another key with the same hash, however unlikely, is a miss
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key || entry.Palette == nil {
+		return nil, false
+	}
+	now := d.clock()
+	if expired(entry.StoredAt, d.TTL, now) {
+		os.Remove(path)
+		return nil, false
+	}
+	// mark the file used so eviction keeps it
+	os.Chtimes(path, now, now)
+
+	return entry.Palette, true
+}
+
+func (d *Disk) Set(key string, p *palettecalculator.Palette) error {
+	if err := os.MkdirAll(d.Dir, 0o755); err != nil {
+		return err
+	}
+	now := d.clock()
+	data, err := json.Marshal(&diskEntry{Key: key, StoredAt: now, Palette: p})
+	if err != nil {
+		return err
+	}
+
+	// write then rename so readers never see a partial file
+	tmp, err := os.CreateTemp(d.Dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), d.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	os.Chtimes(d.path(key), now, now)
+
+	return d.evict()
+}
+
+// Removes expired files, then the least recently used files until the cache fits in MaxSize
+func (d *Disk) evict() error {
+	entries, err := os.ReadDir(d.Dir)
+	if err != nil {
+		return err
+	}
+
+	type file struct {
+		path   string
+		size   int64
+		usedAt time.Time
+	}
+	var (
+		files []file
+		total int64
+	)
+	now := d.clock()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			// removed by another process since it was listed
+			continue
+		}
+		path := filepath.Join(d.Dir, entry.Name())
+		// a file unused for longer than the ttl was stored before then too
+		if expired(info.ModTime(), d.TTL, now) {
+			os.Remove(path)
+			continue
+		}
+		files = append(files, file{path: path, size: info.Size(), usedAt: info.ModTime()})
+		total += info.Size()
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].usedAt.Before(files[j].usedAt) })
+	var evictErr error
+	for i := 0; total > d.maxSize() && i < len(files); i++ {
+		if err := os.Remove(files[i].path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			if evictErr == nil {
+				evictErr = fmt.Errorf("evicting from %s: %w", d.Dir, err)
+			}
+			continue
+		}
+		total -= files[i].size
+	}
+
+	return evictErr
+}
+
+// File of key, named by its hash so any key is a valid file name
+func (d *Disk) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (d *Disk) maxSize() int64 {
+	if d.MaxSize > 0 {
+		return d.MaxSize
+	}
+	return DefaultMaxSize
+}
+
+func (d *Disk) clock() time.Time {
+	if d.now != nil {
+		return d.now()
+	}
+	return time.Now()
+}
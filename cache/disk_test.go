@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDisk(t *testing.T) {
+	clock := &fakeClock{t: time.Now()}
+	d := NewDisk(filepath.Join(t.TempDir(), "palettes"), time.Hour, 0)
+	d.now = clock.now
+
+	testCache(t, d, clock)
+
+	// expired files are removed when a palette is set
+	if err := d.Set("green", redPalette); err != nil {
+		t.Fatal(err)
+	}
+	files, err := filepath.Glob(filepath.Join(d.Dir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("expected: %v\n returned: %v\n", 1, files)
+	}
+}
+
+func TestDiskEviction(t *testing.T) {
+	clock := &fakeClock{t: time.Now()}
+	d := NewDisk(t.TempDir(), 0, 0)
+	d.now = clock.now
+	d.Set("a", redPalette)
+	info, err := os.Stat(d.path("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// room for two files, give or take the length of their times
+	d.MaxSize = 2*info.Size() + info.Size()/2
+
+	clock.t = clock.t.Add(time.Second)
+	d.Set("b", redPalette)
+	clock.t = clock.t.Add(time.Second)
+	// using a makes b the least recently used
+	d.Get("a")
+	clock.t = clock.t.Add(time.Second)
+	d.Set("c", redPalette)
+
+	expected := map[string]bool{"a": true, "b": false, "c": true}
+	for key, cached := range expected {
+		if _, ok := d.Get(key); ok != cached {
+			t.Errorf("expected: %v\n returned: %v\n", cached, ok)
+		}
+	}
+}
+
+func TestDiskCorruptFile(t *testing.T) {
+	d := NewDisk(t.TempDir(), 0, 0)
+	if err := os.WriteFile(d.path("a"), []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := d.Get("a"); ok {
+		t.Errorf("expected a miss for a corrupt file")
+	}
+	if err := d.Set("a", redPalette); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := d.Get("a"); !ok {
+		t.Errorf("expected a hit after replacing a corrupt file")
+	}
+}
@@ -1,13 +1,9 @@
 package palettecalculator
 
 import (
-	vision "cloud.google.com/go/vision/apiv1"
 	"context"
-	gax2 "github.com/googleapis/gax-go/v2"
-	"gonum.org/v1/gonum/floats"
-	pb "google.golang.org/genproto/googleapis/cloud/vision/v1"
-	col "google.golang.org/genproto/googleapis/type/color"
-	"io"
+	"fmt"
+	"log/slog"
 	"math"
 	"os"
 	"strconv"
@@ -29,16 +25,38 @@ type Color struct {
 
 // Representation of HSL (hue, saturation, luminosity) color
 type HSL struct {
-	hue        float64
-	saturation float64
-	luminosity float64
+	Hue        float64 `json:"hue"`
+	Saturation float64 `json:"saturation"`
+	Luminosity float64 `json:"luminosity"`
 }
 
-// Third party wrapper of the vision.NewImageAnnotatorClient method being used by DI
-type Calculator interface {
-	DetectImageProperties(ctx context.Context, img *pb.Image, ictx *pb.ImageContext, opts ...gax2.CallOption) (*pb.ImageProperties, error)
+// Creates the color of red, green and blue channels from 0 to 255 with its hex. Channels out of range or NaN are
+// returned as a *ColorError
+func NewColor(r float64, g float64, b float64) (*Color, error) {
+	for i, channel := range []float64{r, g, b} {
+		if math.IsNaN(channel) || channel < 0 || channel > RGBMax {
+			return nil, &ColorError{
+				Syntax: "rgb",
+				Input:  fmt.Sprintf("rgb(%v, %v, %v)", r, g, b),
+				Err:    fmt.Errorf("%s %v is out of range 0 to 255", channelNames[i], channel),
+			}
+		}
+	}
+
+	return &Color{Red: r, Green: g, Blue: b, Hex: hexString(r, g, b)}, nil
+}
+
+// Creates a color like NewColor, panicking when a channel is out of range. For colors known to be valid, e.g. constants
+func MustColor(r float64, g float64, b float64) Color {
+	c, err := NewColor(r, g, b)
+	if err != nil {
+		panic(err)
+	}
+	return *c
 }
 
+var channelNames = []string{RED: "red", GREEN: "green", BLUE: "blue"}
+
 // Dependency wrapper for os.Open DI
 type Opener interface {
 	Open(name string) (*os.File, error)
@@ -55,180 +73,155 @@ func (fo *FileOpener) Open(name string) (*os.File, error) {
 	return file, nil
 }
 
-// Third Party wrapper interface for vision.NewImageFromReader
-type Reader interface {
-	NewImageFromReader(r io.Reader) (*pb.Image, error)
-	NewImageFromURI(uri string) *pb.Image
-}
-
-type VisionReader struct{}
-
-func (vr *VisionReader) NewImageFromReader(r io.Reader) (*pb.Image, error) {
-	image, err := vision.NewImageFromReader(r)
-	if err != nil {
-		return nil, err
-	}
-
-	return image, nil
-
-}
-
-func (vr *VisionReader) NewImageFromURI(uri string) *pb.Image {
-	image := vision.NewImageFromURI(uri)
-
-	return image
-}
-
-// Calculator for all palette combinations
+// Calculator for all palette combinations. Its methods keep no state between calls, so once its fields are set a
+// PaletteCalculator is safe for concurrent use. Don't change its fields while it is in use; WithContext and WithLogger
+// return copies for per call contexts and loggers instead
 type PaletteCalculator struct {
 	Calculator
 	Reader
 	Opener
 	context.Context
+	// Traces calculations when set
+	Tracer Tracer
+	// Logs calculations when set, see WithLogger
+	Logger *slog.Logger
+	// Rounds conversions and schemes, see RoundingPolicy
+	Rounding RoundingPolicy
+	// Downscales images whose longest side is larger than this many pixels before uploading them to the Vision
+	// API, zero uploads images as they are
+	MaxUploadDimension int
 }
 
-func NewPaletteCalculator() (*PaletteCalculator, error) {
-	ctx := context.Background()
-	client, err := vision.NewImageAnnotatorClient(ctx)
-	if err != nil {
-		return nil, err
-	}
+// Calculates complimentary colors based on dominant color. Returns array of two Color{}
+func (pc *PaletteCalculator) CalculateComplimentaryColorScheme(dc *Color, opts ...SchemeOption) []Color {
+
+	complimentaryColors, hsl := pc.generateInitialRGBAndHSLForColor(dc)
 
-	return &PaletteCalculator{Calculator: client, Reader: new(VisionReader), Opener: new(FileOpener), Context: ctx}, nil
+	// Calculate complimentary color
+	transformedColor := pc.rotateHue(dc, hsl, 180, opts...)
+
+	// Append complimentary color
+	return pc.constrainScheme(append(complimentaryColors, *transformedColor), opts...)
 
 }
 
-// Calculates predominant color in image given file path to image
-func (pc *PaletteCalculator) CalculatePredominantColorFromFile(file string) (*Color, error) {
-	dc := new(Color)
+// Calculates split complimentary colors based on dominant color. Returns array of three Color{}
+func (pc *PaletteCalculator) CalculateSplitComplimentaryColorScheme(dc *Color, opts ...SchemeOption) []Color {
 
-	// Open file
-	f, err := pc.Opener.Open(file)
-	if err != nil {
-		return nil, err
-	}
-	defer f.Close()
+	splitComplimentaryColors, hsl := pc.generateInitialRGBAndHSLForColor(dc)
 
-	// generate image from file
-	image, err := pc.Reader.NewImageFromReader(f)
-	if err != nil {
-		return nil, err
-	}
+	// Calculate split complimentary colors
+	transformedCompliment1 := pc.rotateHue(dc, hsl, 150, opts...)
 
-	// calculate properties of generated image with
-	properties, err := pc.Calculator.DetectImageProperties(pc.Context, image, nil)
-	if err != nil {
-		return nil, err
-	}
+	transformedCompliment2 := pc.rotateHue(dc, hsl, 210, opts...)
 
-	// iterate through resulting colors, get most dominant and add to dc's attributes
-	var c *col.Color
-	max := float32(0)
-	for _, quantized := range properties.DominantColors.Colors {
-		color := quantized.Color
-		score := quantized.Score
-		if score > max {
-			max = score
-			c = color
-		}
-	}
+	// Append split complimentary colors
+	return pc.constrainScheme(append(splitComplimentaryColors, *transformedCompliment1, *transformedCompliment2), opts...)
 
-	dc.Red = float64(c.GetRed())
-	dc.Green = float64(c.GetGreen())
-	dc.Blue = float64(c.GetBlue())
-	dc.Hex = pc.generateHex(dc.Red, dc.Green, dc.Blue)
-	return dc, nil
 }
-func (pc *PaletteCalculator) CalculatePredominantColorFromURI(uri string) (*Color, error) {
-	dc := new(Color)
 
-	// generate image from file
-	image := pc.Reader.NewImageFromURI(uri)
+// Calculates Triadic colors based on dominant color. Returns array of three Color{}
+func (pc *PaletteCalculator) CalculateTriadicColorScheme(dc *Color, opts ...SchemeOption) []Color {
 
-	println("poop 2")
+	triadicColors, hsl := pc.generateInitialRGBAndHSLForColor(dc)
 
-	// calculate properties of generated image with
-	properties, err := pc.Calculator.DetectImageProperties(pc.Context, image, nil)
-	if err != nil {
-		return nil, err
-	}
+	// Calculate triadic colors
+	transformedTriadicColor1 := pc.rotateHue(dc, hsl, 120, opts...)
 
-	// iterate through resulting colors, get most dominant and add to dc's attributes
-	var c *col.Color
-	max := float32(0)
-	for _, quantized := range properties.DominantColors.Colors {
-		color := quantized.Color
-		score := quantized.Score
-		if score > max {
-			max = score
-			c = color
-		}
-	}
+	transformedTriadicColor2 := pc.rotateHue(dc, hsl, 240, opts...)
+
+	// Append triadic colors
+	return pc.constrainScheme(append(triadicColors, *transformedTriadicColor1, *transformedTriadicColor2), opts...)
 
-	dc.Red = float64(c.GetRed())
-	dc.Green = float64(c.GetGreen())
-	dc.Blue = float64(c.GetBlue())
-	dc.Hex = pc.generateHex(dc.Red, dc.Green, dc.Blue)
-	return dc, nil
 }
 
-// Calculates complimentary colors based on dominant color. Returns array of two Color{}
-func (pc *PaletteCalculator) CalculateComplimentaryColorScheme(dc *Color) []Color {
+// Calculates Tetradic colors based on dominant color. Returns array of four Color{}
+func (pc *PaletteCalculator) CalculateTetradicColorScheme(dc *Color, opts ...SchemeOption) []Color {
 
-	complimentaryColors, hsl := pc.generateInitialRGBAndHSLForColor(dc)
+	tetradicColors, hsl := pc.generateInitialRGBAndHSLForColor(dc)
 
-	// Calculate complimentary color
-	transformedHSL := pc.transformHue(hsl, 180)
+	// Calculate tetradic colors
+	transformedTetradicColor1 := pc.rotateHue(dc, hsl, 60, opts...)
+
+	transformedTetradicColor2 := pc.rotateHue(dc, hsl, 180, opts...)
 
-	// Convert complimentary HSL to Color and append
-	return append(complimentaryColors, *pc.ConvertHSLToRGB(transformedHSL))
+	transformedTetradicColor3 := pc.rotateHue(dc, hsl, 240, opts...)
+
+	// Append tetradic colors
+	return pc.constrainScheme(append(tetradicColors, *transformedTetradicColor1, *transformedTetradicColor2, *transformedTetradicColor3), opts...)
 
 }
 
-// Calculates split complimentary colors based on dominant color. Returns array of three Color{}
-func (pc *PaletteCalculator) CalculateSplitComplimentaryColorScheme(dc *Color) []Color {
+// Calculates double split complimentary (compound) colors based on dominant color. Returns array of five Color{}
+func (pc *PaletteCalculator) CalculateDoubleSplitComplimentaryColorScheme(dc *Color, opts ...SchemeOption) []Color {
 
-	splitComplimentaryColors, hsl := pc.generateInitialRGBAndHSLForColor(dc)
+	doubleSplitComplimentaryColors, hsl := pc.generateInitialRGBAndHSLForColor(dc)
 
-	// Calculate split complimentary colors
-	transformedHSLCompliment1 := pc.transformHue(hsl, 150)
+	// Calculate colors either side of the dominant color
+	transformedAnalogousColor1 := pc.rotateHue(dc, hsl, 30, opts...)
+
+	transformedAnalogousColor2 := pc.rotateHue(dc, hsl, 330, opts...)
+
+	// Calculate colors either side of the complimentary color
+	transformedCompliment1 := pc.rotateHue(dc, hsl, 150, opts...)
 
-	transformedHSLCompliment2 := pc.transformHue(hsl, 210)
+	transformedCompliment2 := pc.rotateHue(dc, hsl, 210, opts...)
 
-	// Convert split complimentary color HSL to Color and append
-	return append(splitComplimentaryColors, *pc.ConvertHSLToRGB(transformedHSLCompliment1), *pc.ConvertHSLToRGB(transformedHSLCompliment2))
+	// Append double split complimentary colors
+	return pc.constrainScheme(append(doubleSplitComplimentaryColors, *transformedAnalogousColor1, *transformedAnalogousColor2, *transformedCompliment1, *transformedCompliment2), opts...)
 
 }
 
-// Calculates Triadic colors based on dominant color. Returns array of three Color{}
-func (pc *PaletteCalculator) CalculateTriadicColorScheme(dc *Color) []Color {
+// Calculates analogous colors based on dominant color. Returns array of three Color{}
+func (pc *PaletteCalculator) CalculateAnalogousColorScheme(dc *Color, opts ...SchemeOption) []Color {
 
-	triadicColors, hsl := pc.generateInitialRGBAndHSLForColor(dc)
+	analogousColors, hsl := pc.generateInitialRGBAndHSLForColor(dc)
 
-	// Calculate triadic colors
-	transformedTriadicColor1 := pc.transformHue(hsl, 120)
+	// Calculate colors either side of the dominant color
+	transformedAnalogousColor1 := pc.rotateHue(dc, hsl, 330, opts...)
 
-	transformedTriadicColor2 := pc.transformHue(hsl, 240)
+	transformedAnalogousColor2 := pc.rotateHue(dc, hsl, 30, opts...)
 
-	// Convert triadic HSL to Color and append
-	return append(triadicColors, *pc.ConvertHSLToRGB(transformedTriadicColor1), *pc.ConvertHSLToRGB(transformedTriadicColor2))
+	// Append analogous colors
+	return pc.constrainScheme(append(analogousColors, *transformedAnalogousColor1, *transformedAnalogousColor2), opts...)
 
 }
 
-// Calculates Tetradic colors based on dominant color. Returns array of four Color{}
-func (pc *PaletteCalculator) CalculateTetradicColorScheme(dc *Color) []Color {
+// Calculates monochromatic colors based on dominant color, two lighter and two darker. Returns array of five Color{}.
+// Hue rotation options have no effect
+func (pc *PaletteCalculator) CalculateMonochromaticColorScheme(dc *Color, opts ...SchemeOption) []Color {
 
-	tetradicColors, hsl := pc.generateInitialRGBAndHSLForColor(dc)
+	monochromaticColors, hsl := pc.generateInitialRGBAndHSLForColor(dc)
+	cfg := newSchemeConfig(opts)
 
-	// Calculate tetradic colors
-	transformedTetradicColor1 := pc.transformHue(hsl, 60)
+	// Calculate colors of the same hue a half and a quarter of the way to white, then to black
+	lighter := []float64{hsl.Luminosity + (1-hsl.Luminosity)*.5, hsl.Luminosity + (1-hsl.Luminosity)*.25}
+	darker := []float64{hsl.Luminosity * .75, hsl.Luminosity * .5}
+	for _, luminosity := range append(lighter, darker...) {
+		shiftedHSL := &HSL{Hue: hsl.Hue, Saturation: hsl.Saturation, Luminosity: pc.round(luminosity, 2)}
+		monochromaticColors = append(monochromaticColors, *pc.ConvertHSLToRGB(pc.transformHue(shiftedHSL, 0, cfg.transforms...)))
+	}
+
+	return pc.constrainScheme(monochromaticColors, opts...)
+
+}
+
+// Calculates k colors evenly spaced in hue starting from dominant color. Returns array of k Color{}
+func (pc *PaletteCalculator) CalculateEvenlySpacedColorScheme(dc *Color, k int, opts ...SchemeOption) []Color {
+	if k < 1 {
+		return nil
+	}
 
-	transformedTetradicColor2 := pc.transformHue(hsl, 180)
+	evenlySpacedColors, hsl := pc.generateInitialRGBAndHSLForColor(dc)
 
-	transformedTetradicColor3 := pc.transformHue(hsl, 240)
+	// Calculate and append each hue step around the wheel
+	step := float64(360) / float64(k)
+	for i := 1; i < k; i++ {
+		transformedColor := pc.rotateHue(dc, hsl, step*float64(i), opts...)
+		evenlySpacedColors = append(evenlySpacedColors, *transformedColor)
+	}
 
-	// Convert tertradic HSL to Color and append
-	return append(tetradicColors, *pc.ConvertHSLToRGB(transformedTetradicColor1), *pc.ConvertHSLToRGB(transformedTetradicColor2), *pc.ConvertHSLToRGB(transformedTetradicColor3))
+	return pc.constrainScheme(evenlySpacedColors, opts...)
 
 }
 
@@ -244,49 +237,86 @@ func (pc *PaletteCalculator) generateInitialRGBAndHSLForColor(c *Color) ([]Color
 	return colors, hsl
 }
 
-func (pc *PaletteCalculator) transformHue(hsl *HSL, off float64) *HSL {
-	return &HSL{
-		hue:        math.Mod(hsl.hue+off, 360),
-		saturation: hsl.saturation,
-		luminosity: hsl.luminosity,
+// Rotates dominant color's hue by off in the space selected by opts, applying any Transform options after
+func (pc *PaletteCalculator) rotateHue(dc *Color, hsl *HSL, off float64, opts ...SchemeOption) *Color {
+	cfg := newSchemeConfig(opts)
+
+	if cfg.rotation == OKLCHRotation {
+		oklch := pc.ConvertOKLABToOKLCH(pc.ConvertRGBToOKLAB(dc))
+		rotated := pc.ConvertOKLCHToRGB(&OKLCH{L: oklch.L, C: oklch.C, H: math.Mod(oklch.H+off, 360)})
+		if len(cfg.transforms) == 0 {
+			return rotated
+		}
+		return pc.ConvertHSLToRGB(pc.transformHue(pc.ConvertRGBToHSL(rotated), 0, cfg.transforms...))
 	}
+
+	return pc.ConvertHSLToRGB(pc.transformHue(hsl, off, cfg.transforms...))
+}
+
+func (pc *PaletteCalculator) transformHue(hsl *HSL, off float64, transforms ...Transform) *HSL {
+	transformed := &HSL{
+		Hue:        math.Mod(hsl.Hue+off, 360),
+		Saturation: hsl.Saturation,
+		Luminosity: hsl.Luminosity,
+	}
+
+	for _, t := range transforms {
+		transformed = t.apply(pc, transformed)
+	}
+	return transformed
 }
 
 func (pc *PaletteCalculator) generateHex(r float64, g float64, b float64) string {
-	hex := []string{strconv.FormatInt(int64(r), 16), strconv.FormatInt(int64(g), 16), strconv.FormatInt(int64(b), 16)}
+	return hexString(r, g, b)
+}
 
-	return strings.Join(hex[:], "")
+// Six zero padded hex digits of the channels, e.g. "0a0a0a", clamped to 0 to 255
+func hexString(r float64, g float64, b float64) string {
+	var hex strings.Builder
+	for _, channel := range []float64{r, g, b} {
+		n := int64(channel)
+		if n < 0 || math.IsNaN(channel) {
+			n = 0
+		}
+		if n > 255 {
+			n = 255
+		}
+		hex.WriteString(strconv.FormatInt(n>>4, 16))
+		hex.WriteString(strconv.FormatInt(n&15, 16))
+	}
+
+	return hex.String()
 }
 
 // Converting method for Color to HSL
 func (pc *PaletteCalculator) ConvertRGBToHSL(rgb *Color) *HSL {
 	rgbArr := []float64{rgb.Red, rgb.Green, rgb.Blue}
 
-	min := floats.Min(rgbArr) / RGBMax
-	max := floats.Max(rgbArr) / RGBMax
+	min := minFloat(rgbArr) / RGBMax
+	max := maxFloat(rgbArr) / RGBMax
 	delta := max - min
-	luminosity := floats.Round((max+min)/float64(2), 2)
+	luminosity := pc.round((max+min)/float64(2), 2)
 
 	if delta > 0 {
 		return pc.CalculateHSL(rgbArr, luminosity, delta)
 	}
-	return &HSL{hue: 0, saturation: 0, luminosity: luminosity}
+	return &HSL{Hue: 0, Saturation: 0, Luminosity: luminosity}
 }
 
 // Color to HSL helper method
 func (pc *PaletteCalculator) CalculateHSL(rgb []float64, luminosity float64, delta float64) *HSL {
 	var saturation float64
 	var hue float64
-	min := floats.Round(floats.Min(rgb)/RGBMax, 3)
-	max := floats.Round(floats.Max(rgb)/RGBMax, 3)
-	red := floats.Round(rgb[RED]/RGBMax, 3)
-	green := floats.Round(rgb[GREEN]/RGBMax, 3)
-	blue := floats.Round(rgb[BLUE]/RGBMax, 3)
+	min := pc.round(minFloat(rgb)/RGBMax, 3)
+	max := pc.round(maxFloat(rgb)/RGBMax, 3)
+	red := pc.round(rgb[RED]/RGBMax, 3)
+	green := pc.round(rgb[GREEN]/RGBMax, 3)
+	blue := pc.round(rgb[BLUE]/RGBMax, 3)
 
 	if luminosity < .5 {
-		saturation = floats.Round(delta/(max+min), 3)
+		saturation = pc.round(delta/(max+min), 3)
 	} else {
-		saturation = floats.Round(delta/(2-max-min), 3)
+		saturation = pc.round(delta/(2-max-min), 3)
 	}
 
 	if red == max {
@@ -299,38 +329,44 @@ func (pc *PaletteCalculator) CalculateHSL(rgb []float64, luminosity float64, del
 		hue = 4 + (red-green)/(max-min)
 	}
 
+	// hues left of red come out negative, wrap them back onto the wheel
+	hue = math.Mod(pc.round(hue*60, 0)+360, 360)
+
 	return &HSL{
-		hue:        floats.Round(hue*60, 0),
-		saturation: floats.Round(saturation, 2),
-		luminosity: floats.Round(luminosity, 2),
+		Hue:        hue,
+		Saturation: pc.round(saturation, 2),
+		Luminosity: pc.round(luminosity, 2),
 	}
 
 }
 
-// Converting method for HSL to Color
+// Converting method for HSL to Color. Hue is wrapped to [0,360) and saturation and luminosity clamped to [0,1]
 func (pc *PaletteCalculator) ConvertHSLToRGB(hsl *HSL) *Color {
 	var temp1 float64
 	var temp2 float64
 
-	if hsl.hue > 0 && hsl.saturation > 0 {
-		if hsl.luminosity < .5 {
-			temp1 = hsl.luminosity * (1 + hsl.saturation)
+	hsl = &HSL{Hue: math.Mod(math.Mod(hsl.Hue, 360)+360, 360), Saturation: clampUnit(hsl.Saturation), Luminosity: clampUnit(hsl.Luminosity)}
+
+	if hsl.Saturation > 0 {
+		if hsl.Luminosity < .5 {
+			temp1 = hsl.Luminosity * (1 + hsl.Saturation)
 		} else {
-			temp1 = (hsl.luminosity + hsl.saturation) - (hsl.luminosity * hsl.saturation)
+			temp1 = (hsl.Luminosity + hsl.Saturation) - (hsl.Luminosity * hsl.Saturation)
 		}
 
-		temp2 = 2*hsl.luminosity - temp1
+		temp2 = 2*hsl.Luminosity - temp1
 
-		tempRed := floats.Round(hsl.hue/360+float64(1)/float64(3), 2)
-		tempGreen := floats.Round(hsl.hue/360, 3)
-		tempBlue := floats.Round(hsl.hue/360-float64(1)/float64(3), 2)
+		tempRed := pc.round(hsl.Hue/360+float64(1)/float64(3), 2)
+		tempGreen := pc.round(hsl.Hue/360, 3)
+		tempBlue := pc.round(hsl.Hue/360-float64(1)/float64(3), 2)
 		return pc.calculateRGB([]float64{tempRed, tempGreen, tempBlue}, []float64{temp1, temp2})
 	}
+	gray := pc.round(hsl.Luminosity*255, 0)
 	return &Color{
-		Red:   hsl.luminosity * 255,
-		Green: hsl.luminosity * 255,
-		Blue:  hsl.luminosity * 255,
-		Hex:   pc.generateHex(hsl.luminosity*255, hsl.luminosity*255, hsl.luminosity*255),
+		Red:   gray,
+		Green: gray,
+		Blue:  gray,
+		Hex:   pc.generateHex(gray, gray, gray),
 	}
 
 }
@@ -346,11 +382,11 @@ func (pc *PaletteCalculator) calculateRGB(tempRGB []float64, tempVar []float64)
 		}
 	}
 
-	red := floats.Round(pc.calculateRGBByColor(tempRGB[RED], tempVar)*255, 0)
+	red := pc.round(pc.calculateRGBByColor(tempRGB[RED], tempVar)*255, 0)
 
-	green := floats.Round(pc.calculateRGBByColor(tempRGB[GREEN], tempVar)*255, 0)
+	green := pc.round(pc.calculateRGBByColor(tempRGB[GREEN], tempVar)*255, 0)
 
-	blue := floats.Round(pc.calculateRGBByColor(tempRGB[BLUE], tempVar)*255, 0)
+	blue := pc.round(pc.calculateRGBByColor(tempRGB[BLUE], tempVar)*255, 0)
 
 	hex := pc.generateHex(red, green, blue)
 
@@ -361,14 +397,14 @@ func (pc *PaletteCalculator) calculateRGB(tempRGB []float64, tempVar []float64)
 // HSL to Color helper method
 func (pc *PaletteCalculator) calculateRGBByColor(tempColor float64, tempVar []float64) float64 {
 	if tempColor*6 < 1 {
-		return floats.Round(tempVar[1]+(tempVar[0]-tempVar[1])*6*tempColor, 3)
+		return pc.round(tempVar[1]+(tempVar[0]-tempVar[1])*6*tempColor, 3)
 	}
 	if tempColor*2 < 1 {
-		return floats.Round(tempVar[0], 3)
+		return pc.round(tempVar[0], 3)
 	}
 	if tempColor*3 < 2 {
-		return floats.Round(tempVar[1]+(tempVar[0]-tempVar[1])*(float64(2)/float64(3)-tempColor)*6, 3)
+		return pc.round(tempVar[1]+(tempVar[0]-tempVar[1])*(float64(2)/float64(3)-tempColor)*6, 3)
 	}
 
-	return floats.Round(tempVar[1], 3)
+	return pc.round(tempVar[1], 3)
 }
@@ -1,13 +1,8 @@
 package palettecalculator
 
 import (
-	"context"
 	"errors"
-	"fmt"
-	"github.com/googleapis/gax-go/v2"
-	pb "google.golang.org/genproto/googleapis/cloud/vision/v1"
-	"google.golang.org/genproto/googleapis/type/color"
-	"io"
+	"math"
 	"os"
 	"reflect"
 	"testing"
@@ -21,131 +16,61 @@ const saturation = .66
 const luminosity = .28
 const Hex = "186277"
 
-func TestCalculatePredominantColorFromFile(t *testing.T) {
+func TestNewColor(t *testing.T) {
 	for _, test := range []struct {
-		name                  string
-		file                  os.File
-		filePath              string
-		data                  []*pb.ColorInfo
-		visionData            []byte
-		expectedDominantColor *Color
-		calculatorErr         error
-		openerErr             error
-		readerErr             error
-		expectedErr           error
+		name          string
+		r, g, b       float64
+		expectedColor *Color
+		expectedErr   error
 	}{
+		{name: "should set hex", r: Red, g: Green, b: Blue, expectedColor: &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}},
+		{name: "should allow range ends", r: 0, g: 255, b: 255, expectedColor: &Color{Red: 0, Green: 255, Blue: 255, Hex: hexString(0, 255, 255)}},
 		{
-			name:                  "should return dominant color with no error",
-			file:                  *new(os.File),
-			filePath:              "test/file.path",
-			data:                  []*pb.ColorInfo{&pb.ColorInfo{Color: &color.Color{Red: Red, Green: Green, Blue: Blue}, Score: .01}},
-			visionData:            []byte{},
-			expectedDominantColor: &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
-			calculatorErr:         nil,
-			openerErr:             nil,
-			readerErr:             nil,
-			expectedErr:           nil,
+			name:        "error occurs for channel over 255",
+			r:           256,
+			g:           Green,
+			b:           Blue,
+			expectedErr: &ColorError{Syntax: "rgb", Input: "rgb(256, 98, 119)", Err: errors.New("red 256 is out of range 0 to 255")},
 		},
 		{
-			name:                  "error occurs when file is opened",
-			file:                  *new(os.File),
-			filePath:              "test/file.path",
-			data:                  nil,
-			visionData:            nil,
-			expectedDominantColor: nil,
-			calculatorErr:         nil,
-			openerErr:             errors.New("os error has occurRed. file not found"),
-			readerErr:             nil,
-			expectedErr:           errors.New("os error has occurRed. file not found"),
+			name:        "error occurs for negative channel",
+			r:           Red,
+			g:           Green,
+			b:           -1,
+			expectedErr: &ColorError{Syntax: "rgb", Input: "rgb(24, 98, -1)", Err: errors.New("blue -1 is out of range 0 to 255")},
 		},
 		{
-			name:                  "error occurs when file is read as image",
-			file:                  *new(os.File),
-			filePath:              "test/file.path",
-			data:                  nil,
-			visionData:            nil,
-			expectedDominantColor: nil,
-			calculatorErr:         nil,
-			openerErr:             nil,
-			readerErr:             errors.New("unable to read from file"),
-			expectedErr:           errors.New("unable to read from file"),
-		}, {
-			name:                  "error occurs when image properties are calculated",
-			file:                  *new(os.File),
-			filePath:              "test/file.path",
-			data:                  nil,
-			visionData:            nil,
-			expectedDominantColor: nil,
-			calculatorErr:         errors.New("unable to calculate image properties"),
-			openerErr:             nil,
-			readerErr:             nil,
-			expectedErr:           errors.New("unable to calculate image properties"),
+			name:        "error occurs for NaN",
+			r:           Red,
+			g:           math.NaN(),
+			b:           Blue,
+			expectedErr: &ColorError{Syntax: "rgb", Input: "rgb(24, NaN, 119)", Err: errors.New("green NaN is out of range 0 to 255")},
 		},
 	} {
-		t.Run(fmt.Sprintf("%s", test.name), func(t *testing.T) {
-			paletteCalculator := new(PaletteCalculator)
-
-			paletteCalculator.Calculator = &MockCalculator{data: test.data, err: test.calculatorErr}
-			paletteCalculator.Opener = &MockFileOpener{data: &test.file, err: test.openerErr}
-			paletteCalculator.Reader = &MockVisionReader{data: test.visionData, err: test.readerErr}
+		t.Run(test.name, func(t *testing.T) {
+			returnedColor, err := NewColor(test.r, test.g, test.b)
 
-			returnedDominantColor, err := paletteCalculator.CalculatePredominantColorFromFile(test.filePath)
-
-			if !reflect.DeepEqual(test.expectedDominantColor, returnedDominantColor) {
-				t.Errorf("expected: %+v\n returned: %+v\n ", test.expectedDominantColor, returnedDominantColor)
+			if !reflect.DeepEqual(test.expectedColor, returnedColor) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedColor, returnedColor)
 			}
-
 			if !reflect.DeepEqual(test.expectedErr, err) {
-				t.Errorf("expected error: %s returned error: %s", test.expectedErr.Error(), err.Error())
+				t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
 			}
 		})
 	}
 }
-func TestCalculatePredominantColorFromURI(t *testing.T) {
-	for _, test := range []struct {
-		name                  string
-		uri                   string
-		data                  []*pb.ColorInfo
-		visionData            []byte
-		expectedDominantColor *Color
-		calculatorErr         error
-		expectedErr           error
-	}{
-		{
-			name:                  "should return dominant color with no error",
-			uri:                   "test.uri",
-			data:                  []*pb.ColorInfo{&pb.ColorInfo{Color: &color.Color{Red: Red, Green: Green, Blue: Blue}, Score: .01}},
-			visionData:            []byte{},
-			expectedDominantColor: &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
-			calculatorErr:         nil,
-			expectedErr:           nil,
-		}, {
-			name:                  "error occurs when image properties are calculated",
-			uri:                   "test.uri",
-			data:                  nil,
-			visionData:            nil,
-			expectedDominantColor: nil,
-			calculatorErr:         errors.New("unable to calculate image properties"),
-			expectedErr:           errors.New("unable to calculate image properties"),
-		},
-	} {
-		t.Run(fmt.Sprintf("%s", test.name), func(t *testing.T) {
-			paletteCalculator := new(PaletteCalculator)
-
-			paletteCalculator.Calculator = &MockCalculator{data: test.data, err: test.calculatorErr}
-			paletteCalculator.Reader = &MockVisionReader{data: test.visionData}
-
-			returnedDominantColor, err := paletteCalculator.CalculatePredominantColorFromURI(test.uri)
-
-			if !reflect.DeepEqual(test.expectedDominantColor, returnedDominantColor) {
-				t.Errorf("expected: %+v\n returned: %+v\n ", test.expectedDominantColor, returnedDominantColor)
-			}
 
-			if !reflect.DeepEqual(test.expectedErr, err) {
-				t.Errorf("expected error: %s returned error: %s", test.expectedErr.Error(), err.Error())
-			}
-		})
+func TestMustColor(t *testing.T) {
+	if c := MustColor(Red, Green, Blue); c.Hex != Hex {
+		t.Errorf("expected: %v\n returned: %v\n", Hex, c.Hex)
 	}
+
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, ErrInvalidColor) {
+			t.Errorf("expected: %v\n returned: %v\n", ErrInvalidColor, err)
+		}
+	}()
+	MustColor(300, 0, 0)
 }
 
 func TestCalculateComplimentaryColorScheme(t *testing.T) {
@@ -200,10 +125,97 @@ func TestCalculateTetradicColorScheme(t *testing.T) {
 
 }
 
+func TestCalculateDoubleSplitComplimentaryColorScheme(t *testing.T) {
+	dominantColors := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+	expectedRGB := []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {24, 51, 119, "183377"}, {24, 119, 92, "18775c"}, {119, 24, 51, "771833"}, {119, 92, 24, "775c18"}}
+	paletteCalculator := new(PaletteCalculator)
+
+	returnedRGB := paletteCalculator.CalculateDoubleSplitComplimentaryColorScheme(dominantColors)
+
+	if !reflect.DeepEqual(expectedRGB, returnedRGB) {
+		t.Errorf("expected: %v\n returned %v\n", expectedRGB, returnedRGB)
+	}
+
+}
+
+func TestCalculateAnalogousColorScheme(t *testing.T) {
+	dominantColors := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+	expectedRGB := []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {24, 119, 92, "18775c"}, {24, 51, 119, "183377"}}
+	paletteCalculator := new(PaletteCalculator)
+
+	returnedRGB := paletteCalculator.CalculateAnalogousColorScheme(dominantColors)
+
+	if !reflect.DeepEqual(expectedRGB, returnedRGB) {
+		t.Errorf("expected: %v\n returned %v\n", expectedRGB, returnedRGB)
+	}
+
+}
+
+func TestCalculateMonochromaticColorScheme(t *testing.T) {
+	dominantColors := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+	expectedRGB := []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {103, 198, 224, "67c6e0"}, {40, 161, 195, "28a1c3"}, {18, 74, 89, "124a59"}, {12, 49, 59, "0c313b"}}
+	paletteCalculator := new(PaletteCalculator)
+
+	returnedRGB := paletteCalculator.CalculateMonochromaticColorScheme(dominantColors)
+
+	if !reflect.DeepEqual(expectedRGB, returnedRGB) {
+		t.Errorf("expected: %v\n returned %v\n", expectedRGB, returnedRGB)
+	}
+
+}
+
+func TestCalculateEvenlySpacedColorScheme(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		k           int
+		expectedRGB []Color
+	}{
+		{
+			name:        "should return six colors spaced 60 degrees apart",
+			k:           6,
+			expectedRGB: []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {47, 24, 119, "2f1877"}, {119, 24, 96, "771860"}, {119, 45, 24, "772d18"}, {96, 119, 24, "607718"}, {24, 119, 47, "18772f"}},
+		},
+		{
+			name:        "should return only the dominant color when k is one",
+			k:           1,
+			expectedRGB: []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}},
+		},
+		{
+			name:        "should return nil when k is less than one",
+			k:           0,
+			expectedRGB: nil,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dominantColors := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedRGB := paletteCalculator.CalculateEvenlySpacedColorScheme(dominantColors, test.k)
+
+			if !reflect.DeepEqual(test.expectedRGB, returnedRGB) {
+				t.Errorf("expected: %v\n returned %v\n", test.expectedRGB, returnedRGB)
+			}
+		})
+	}
+}
+
 func TestConvertRGBToHSL(t *testing.T) {
 	testRGB := &Color{Red: Red, Green: Green, Blue: Blue}
 	paletteCalculator := new(PaletteCalculator)
-	expectedHSL := &HSL{hue: hue, saturation: saturation, luminosity: luminosity}
+	expectedHSL := &HSL{Hue: hue, Saturation: saturation, Luminosity: luminosity}
+
+	returnedHSL := paletteCalculator.ConvertRGBToHSL(testRGB)
+
+	if !reflect.DeepEqual(expectedHSL, returnedHSL) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedHSL, returnedHSL)
+	}
+
+}
+
+func TestConvertRGBToHSLWrapsNegativeHue(t *testing.T) {
+	testRGB := &Color{Red: 119, Green: 24, Blue: 96}
+	paletteCalculator := new(PaletteCalculator)
+	expectedHSL := &HSL{Hue: 315, Saturation: .66, Luminosity: .28}
 
 	returnedHSL := paletteCalculator.ConvertRGBToHSL(testRGB)
 
@@ -214,7 +226,7 @@ func TestConvertRGBToHSL(t *testing.T) {
 }
 
 func TestConvertHSLToRGB(t *testing.T) {
-	testHSL := &HSL{hue: hue, saturation: saturation, luminosity: luminosity}
+	testHSL := &HSL{Hue: hue, Saturation: saturation, Luminosity: luminosity}
 	paletteCalculator := new(PaletteCalculator)
 	expectedRGB := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
 
@@ -225,13 +237,63 @@ func TestConvertHSLToRGB(t *testing.T) {
 
 }
 
-type MockCalculator struct {
-	data []*pb.ColorInfo
-	err  error
+func TestConvertHSLToRGBWithZeroHue(t *testing.T) {
+	testHSL := &HSL{Hue: 0, Saturation: .5, Luminosity: .5}
+	paletteCalculator := new(PaletteCalculator)
+	expectedRGB := &Color{Red: 191, Green: 64, Blue: 64, Hex: "bf4040"}
+
+	returnedRGB := paletteCalculator.ConvertHSLToRGB(testHSL)
+	if !reflect.DeepEqual(expectedRGB, returnedRGB) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedRGB, returnedRGB)
+	}
+
 }
 
-func (m *MockCalculator) DetectImageProperties(ctx context.Context, img *pb.Image, ictx *pb.ImageContext, opts ...gax.CallOption) (*pb.ImageProperties, error) {
-	return &pb.ImageProperties{DominantColors: &pb.DominantColorsAnnotation{Colors: m.data}}, m.err
+func TestConvertHSLToRGBWithOutOfRangeHSL(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		hsl         *HSL
+		expectedRGB *Color
+	}{
+		{name: "should clamp saturation and luminosity", hsl: &HSL{Hue: -150, Saturation: 1.5, Luminosity: 1.2}, expectedRGB: &Color{255, 255, 255, "ffffff"}},
+		{name: "should wrap hue past a full turn", hsl: &HSL{Hue: 570, Saturation: .66, Luminosity: .28}, expectedRGB: &Color{24, 72, 119, "184877"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedRGB := paletteCalculator.ConvertHSLToRGB(test.hsl)
+			if !reflect.DeepEqual(test.expectedRGB, returnedRGB) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedRGB, returnedRGB)
+			}
+		})
+	}
+}
+
+func TestHSLRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		rgb         *Color
+		expectedHSL *HSL
+	}{
+		{name: "should round trip pure red at hue 0", rgb: &Color{255, 0, 0, "ff0000"}, expectedHSL: &HSL{Hue: 0, Saturation: 1, Luminosity: .5}},
+		{name: "should round trip mid gray", rgb: &Color{128, 128, 128, "808080"}, expectedHSL: &HSL{Hue: 0, Saturation: 0, Luminosity: .5}},
+		{name: "should round trip dark gray to whole channels", rgb: &Color{51, 51, 51, "333333"}, expectedHSL: &HSL{Hue: 0, Saturation: 0, Luminosity: .2}},
+		{name: "should round trip white", rgb: &Color{255, 255, 255, "ffffff"}, expectedHSL: &HSL{Hue: 0, Saturation: 0, Luminosity: 1}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedHSL := paletteCalculator.ConvertRGBToHSL(test.rgb)
+			if !reflect.DeepEqual(test.expectedHSL, returnedHSL) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedHSL, returnedHSL)
+			}
+
+			returnedRGB := paletteCalculator.ConvertHSLToRGB(returnedHSL)
+			if !reflect.DeepEqual(test.rgb, returnedRGB) {
+				t.Errorf("expected: %v\n returned: %v\n", test.rgb, returnedRGB)
+			}
+		})
+	}
 }
 
 type MockFileOpener struct {
@@ -242,16 +304,3 @@ type MockFileOpener struct {
 func (m *MockFileOpener) Open(name string) (*os.File, error) {
 	return m.data, m.err
 }
-
-type MockVisionReader struct {
-	data []byte
-	err  error
-}
-
-func (m *MockVisionReader) NewImageFromReader(r io.Reader) (*pb.Image, error) {
-	return &pb.Image{Content: m.data}, m.err
-}
-
-func (m *MockVisionReader) NewImageFromURI(uri string) *pb.Image {
-	return &pb.Image{Content: m.data}
-}
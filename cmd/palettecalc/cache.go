@@ -0,0 +1,61 @@
+package main
+
+import (
+	"crypto/sha256"
+	"flag"
+	"fmt"
+	"time"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	"github.com/evancaplan/palettecalculator/cache"
+)
+
+// Flags of the disk cache of extracted palettes
+type cacheFlags struct {
+	dir     string
+	ttl     time.Duration
+	maxSize int64
+}
+
+func (c *cacheFlags) register(fs *flag.FlagSet) {
+	// without a user cache directory, e.g. $HOME unset, caching is off unless -cache is set
+	dir, _ := cache.DefaultDir()
+	fs.StringVar(&c.dir, "cache", dir, "`directory` of cached palettes, empty disables the cache")
+	fs.DurationVar(&c.ttl, "cache-ttl", 30*24*time.Hour, "how long cached palettes are kept")
+	fs.Int64Var(&c.maxSize, "cache-size", cache.DefaultMaxSize, "largest size of the cache in `bytes`")
+}
+
+func (c *cacheFlags) cache() (cache.Cache, error) {
+	if c.dir == "" {
+		return noCache{}, nil
+	}
+	if c.maxSize < 0 || c.ttl < 0 {
+		return nil, fmt.Errorf("invalid cache size %d or ttl %v: expected zero or more", c.maxSize, c.ttl)
+	}
+
+	return cache.NewDisk(c.dir, c.ttl, c.maxSize), nil
+}
+
+// Cache key of an extraction, by the image contents or, for a URL read by the vision API, the URL
+func extractKey(backend string, k int, source string, data []byte) string {
+	if data == nil {
+		return backend + ":" + source
+	}
+	sum := sha256.Sum256(data)
+	if backend == "vision" {
+		return fmt.Sprintf("%s:sha256:%x", backend, sum)
+	}
+
+	return fmt.Sprintf("%s:%d:sha256:%x", backend, k, sum)
+}
+
+// Cache of -cache "", nothing is kept
+type noCache struct{}
+
+func (noCache) Get(key string) (*palettecalculator.Palette, bool) {
+	return nil, false
+}
+
+func (noCache) Set(key string, p *palettecalculator.Palette) error {
+	return nil
+}
@@ -0,0 +1,513 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	"github.com/evancaplan/palettecalculator/cache"
+	"github.com/evancaplan/palettecalculator/metrics"
+	"github.com/evancaplan/palettecalculator/palettegrpc"
+	"github.com/evancaplan/palettecalculator/pipeline"
+	"github.com/evancaplan/palettecalculator/server"
+	"github.com/evancaplan/palettecalculator/serverless"
+	"github.com/evancaplan/palettecalculator/store"
+	"github.com/evancaplan/palettecalculator/watch"
+)
+
+// Flags shared by every command
+type outputFlags struct {
+	format string
+}
+
+func (o *outputFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.format, "format", "text", "output `format`, text or json")
+}
+
+// Writes v as indented JSON when -format json is set, otherwise calls text
+func (o *outputFlags) write(w io.Writer, v interface{}, text func() error) error {
+	switch o.format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	case "text":
+		return text()
+	}
+
+	return fmt.Errorf("unknown format %q, expected text or json", o.format)
+}
+
+// Flags choosing the extraction backend
+type backendFlags struct {
+	name        string
+	credentials string
+}
+
+func (b *backendFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&b.name, "backend", "local", "extraction `backend`, local quantization or the Google Cloud vision API")
+	fs.StringVar(&b.credentials, "credentials", "", "Google Cloud service account key `file` of the vision backend, empty uses the application default credentials")
+}
+
+// Checks the backend and points the vision client at the credentials file
+func (b *backendFlags) check() error {
+	if b.name != "local" && b.name != "vision" {
+		return fmt.Errorf("unknown backend %q, expected local or vision", b.name)
+	}
+	if b.credentials != "" {
+		return os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", b.credentials)
+	}
+
+	return nil
+}
+
+func newFlagSet(name string, s *streams, arguments string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(s.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(s.stderr, "usage: palettecalc %s [flags] %s\n\nflags:\n", name, arguments)
+		fs.PrintDefaults()
+	}
+
+	return fs
+}
+
+func extract(args []string, s *streams) error {
+	fs := newFlagSet("extract", s, "<file | url | ->")
+	var out outputFlags
+	out.register(fs)
+	var backend backendFlags
+	backend.register(fs)
+	k := fs.Int("k", 5, "number of colors extracted by the local backend")
+	var cacheFlags cacheFlags
+	cacheFlags.register(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("expected one image")
+	}
+	source := fs.Arg(0)
+	if err := backend.check(); err != nil {
+		return err
+	}
+
+	var data []byte
+	// the vision API fetches URLs itself, everything else is read to key the cache by its contents
+	if backend.name == "local" || !isURL(source) {
+		r, err := openInput(source, s.stdin)
+		if err != nil {
+			return err
+		}
+		data, err = io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	c, err := cacheFlags.cache()
+	if err != nil {
+		return err
+	}
+	key := extractKey(backend.name, *k, source, data)
+	p, cached := c.Get(key)
+	if !cached {
+		if p, err = extractPalette(backend.name, *k, source, data); err != nil {
+			return err
+		}
+		if err := c.Set(key, p); err != nil {
+			fmt.Fprintf(s.stderr, "palettecalc extract: caching palette: %v\n", err)
+		}
+	}
+
+	return out.write(s.stdout, p, func() error {
+		pc := new(palettecalculator.PaletteCalculator)
+		for i := range p.Colors {
+			named, _ := pc.NearestNamedColor(&p.Colors[i])
+			weight := ""
+			if i < len(p.Weights) {
+				weight = fmt.Sprintf("%5.1f%%", p.Weights[i]*100)
+			}
+			fmt.Fprintf(s.stdout, "%s  %s  %s\n", hex(&p.Colors[i]), weight, named.Name)
+		}
+		return nil
+	})
+}
+
+// Extracts the palette of data, or of the URL source with the vision backend when data is nil
+func extractPalette(backend string, k int, source string, data []byte) (*palettecalculator.Palette, error) {
+	if backend == "vision" {
+		pc, err := palettecalculator.NewPaletteCalculator()
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			return pc.CalculatePaletteFromURI(source)
+		}
+		return pc.CalculatePaletteFromReader(bytes.NewReader(data))
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	pc := new(palettecalculator.PaletteCalculator)
+
+	return pc.ExtractPalette(img, k)
+}
+
+func scheme(args []string, s *streams) error {
+	fs := newFlagSet("scheme", s, "<color>")
+	var out outputFlags
+	out.register(fs)
+	schemeType := fs.String("type", string(palettecalculator.Complimentary), "scheme `type`, e.g. complimentary, triadic or analogous")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	c, err := parseColorArgs(fs, 1)
+	if err != nil {
+		return err
+	}
+
+	pc := new(palettecalculator.PaletteCalculator)
+	colors, err := pc.CalculateScheme(&c[0], palettecalculator.SchemeType(*schemeType))
+	if err != nil {
+		return err
+	}
+
+	return out.write(s.stdout, colors, func() error {
+		for i := range colors {
+			fmt.Fprintln(s.stdout, hex(&colors[i]))
+		}
+		return nil
+	})
+}
+
+// Representations printed by convert
+type conversion struct {
+	Hex     string `json:"hex"`
+	RGB     string `json:"rgb"`
+	HSL     string `json:"hsl"`
+	Name    string `json:"name"`
+	ANSI256 int    `json:"ansi256"`
+}
+
+func convert(args []string, s *streams) error {
+	fs := newFlagSet("convert", s, "<color>")
+	var out outputFlags
+	out.register(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	c, err := parseColorArgs(fs, 1)
+	if err != nil {
+		return err
+	}
+
+	pc := new(palettecalculator.PaletteCalculator)
+	hsl := pc.ConvertRGBToHSL(&c[0])
+	named, _ := pc.NearestNamedColor(&c[0])
+	result := conversion{
+		Hex:     hex(&c[0]),
+		RGB:     fmt.Sprintf("rgb(%g, %g, %g)", c[0].Red, c[0].Green, c[0].Blue),
+		HSL:     fmt.Sprintf("hsl(%g, %g%%, %g%%)", hsl.Hue, hsl.Saturation*100, hsl.Luminosity*100),
+		Name:    named.Name,
+		ANSI256: pc.ToANSI256(&c[0]),
+	}
+
+	return out.write(s.stdout, result, func() error {
+		_, err := fmt.Fprintf(s.stdout, "hex   %s\nrgb   %s\nhsl   %s\nname  %s\nansi  %d\n", result.Hex, result.RGB, result.HSL, result.Name, result.ANSI256)
+		return err
+	})
+}
+
+// WCAG results printed by contrast
+type contrastResult struct {
+	Ratio    float64 `json:"ratio"`
+	AA       bool    `json:"aa"`
+	AALarge  bool    `json:"aa-large"`
+	AAA      bool    `json:"aaa"`
+	AAALarge bool    `json:"aaa-large"`
+}
+
+func contrast(args []string, s *streams) error {
+	fs := newFlagSet("contrast", s, "<foreground> <background>")
+	var out outputFlags
+	out.register(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	c, err := parseColorArgs(fs, 2)
+	if err != nil {
+		return err
+	}
+
+	pc := new(palettecalculator.PaletteCalculator)
+	fg, bg := &c[0], &c[1]
+	result := contrastResult{
+		Ratio:    float64(int(pc.ContrastRatio(fg, bg)*100+.5)) / 100,
+		AA:       pc.PassesAA(fg, bg, palettecalculator.NormalText),
+		AALarge:  pc.PassesAA(fg, bg, palettecalculator.LargeText),
+		AAA:      pc.PassesAAA(fg, bg, palettecalculator.NormalText),
+		AAALarge: pc.PassesAAA(fg, bg, palettecalculator.LargeText),
+	}
+
+	return out.write(s.stdout, result, func() error {
+		_, err := fmt.Fprintf(s.stdout, "ratio      %g:1\nAA         %s\nAA large   %s\nAAA        %s\nAAA large  %s\n",
+			result.Ratio, pass(result.AA), pass(result.AALarge), pass(result.AAA), pass(result.AAALarge))
+		return err
+	})
+}
+
+func export(args []string, s *streams) error {
+	fs := newFlagSet("export", s, "[<color> ...]")
+	as := fs.String("as", "scss", "export `format`: scss, less, gpl, tailwind, tokens, w3c-tokens, markdown, sketch, svg, png, json, yaml or toml")
+	in := fs.String("in", "", "palette `file` to export instead of colors, .json, .yaml, .toml, .gpl, .ase or .aco, - for json on stdin")
+	name := fs.String("name", "", "palette name, used by gpl, tailwind and tokens")
+	width := fs.Int("width", 600, "svg and png width")
+	height := fs.Int("height", 120, "svg and png height")
+	columns := fs.Int("columns", 0, "svg and png swatches per row, 0 for a single strip")
+	labels := fs.Bool("labels", false, "draw hex labels on svg and png swatches")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	var p *palettecalculator.Palette
+	if *in != "" {
+		loaded, err := loadPalette(*in, s.stdin)
+		if err != nil {
+			return err
+		}
+		p = loaded
+	} else {
+		colors, err := parseColorArgs(fs, -1)
+		if err != nil {
+			return err
+		}
+		p = &palettecalculator.Palette{Colors: colors}
+	}
+	if *name != "" {
+		p.Name = *name
+	}
+
+	pc := new(palettecalculator.PaletteCalculator)
+	layout := palettecalculator.SwatchLayout{Columns: *columns, Labels: *labels}
+	switch *as {
+	case "scss":
+		_, err := io.WriteString(s.stdout, pc.ExportSCSS(p, nil))
+		return err
+	case "less":
+		_, err := io.WriteString(s.stdout, pc.ExportLESS(p, nil))
+		return err
+	case "markdown":
+		_, err := io.WriteString(s.stdout, pc.ExportMarkdown(p))
+		return err
+	case "tailwind":
+		tailwindName := p.Name
+		if tailwindName == "" {
+			tailwindName = "brand"
+		}
+		_, err := io.WriteString(s.stdout, p.ToTailwindConfig(tailwindName))
+		return err
+	case "gpl":
+		return pc.WriteGPL(s.stdout, p, nil)
+	case "tokens":
+		return pc.WriteDesignTokens(s.stdout, p, nil, palettecalculator.TokensStudio)
+	case "w3c-tokens":
+		return pc.WriteDesignTokens(s.stdout, p, nil, palettecalculator.W3CDesignTokens)
+	case "sketch":
+		return pc.WriteSketchPalette(s.stdout, p, nil)
+	case "svg":
+		return p.RenderSVG(s.stdout, *width, *height, layout)
+	case "png":
+		return p.RenderPNG(s.stdout, *width, *height, layout)
+	case "json":
+		encoder := json.NewEncoder(s.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(p)
+	case "yaml":
+		return pc.WriteYAML(s.stdout, p)
+	case "toml":
+		return pc.WriteTOML(s.stdout, p)
+	}
+
+	return fmt.Errorf("unknown export format %q", *as)
+}
+
+func serve(args []string, s *streams) error {
+	fs := newFlagSet("serve", s, "")
+	addr := fs.String("addr", ":8080", "`address` the REST API listens on")
+	grpcAddr := fs.String("grpc", "", "also serve the gRPC PaletteService on `address`")
+	var backend backendFlags
+	backend.register(fs)
+	maxSize := fs.Int64("max-size", server.DefaultMaxImageSize, "largest image accepted in bytes")
+	cacheEntries := fs.Int("cache-entries", 0, "palettes kept in memory for repeated images, 0 disables the cache")
+	serveMetrics := fs.Bool("metrics", false, "serve Prometheus metrics at /metrics")
+	rateLimit := fs.Float64("rate-limit", 0, "REST `requests` a second allowed per client IP, 0 does not limit requests")
+	rateBurst := fs.Int("rate-burst", 10, "REST requests a client may make at once before -rate-limit applies")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return errors.New("serve takes no arguments")
+	}
+
+	if err := backend.check(); err != nil {
+		return err
+	}
+	var calculator *palettecalculator.PaletteCalculator
+	if backend.name == "vision" {
+		pc, err := palettecalculator.NewPaletteCalculator()
+		if err != nil {
+			return err
+		}
+		calculator = pc
+	}
+
+	rest := server.New(calculator)
+	rest.MaxImageSize = *maxSize
+	if *rateLimit < 0 || *rateBurst < 0 {
+		return fmt.Errorf("invalid rate limit %v or burst %d: expected zero or more", *rateLimit, *rateBurst)
+	}
+	rest.RateLimit, rest.RateBurst = *rateLimit, *rateBurst
+	if *cacheEntries < 0 {
+		return fmt.Errorf("invalid cache entries %d: expected zero or more", *cacheEntries)
+	}
+	if *cacheEntries > 0 {
+		rest.Cache = cache.NewMemory(*cacheEntries, time.Hour)
+	}
+	var handler http.Handler = rest
+	if *serveMetrics {
+		collector := metrics.NewCollector()
+		rest.Metrics = collector
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", collector)
+		mux.Handle("/", rest)
+		handler = mux
+	}
+
+	errs := make(chan error, 2)
+	if *grpcAddr != "" {
+		grpcServer := palettegrpc.NewServer(calculator)
+		grpcServer.MaxMessageSize = int(*maxSize)
+		fmt.Fprintf(s.stderr, "palettecalc serving gRPC on %s\n", *grpcAddr)
+		go func() { errs <- palettegrpc.ListenAndServe(*grpcAddr, grpcServer) }()
+	}
+	fmt.Fprintf(s.stderr, "palettecalc serving on %s\n", *addr)
+	go func() { errs <- http.ListenAndServe(*addr, handler) }()
+
+	return <-errs
+}
+
+func watchDirs(args []string, s *streams) error {
+	fs := newFlagSet("watch", s, "<directory>...")
+	var out outputFlags
+	out.register(fs)
+	var backend backendFlags
+	backend.register(fs)
+	k := fs.Int("k", 5, "number of colors extracted by the local backend")
+	storePath := fs.String("store", "", "save palettes to the BoltDB `file`")
+	existing := fs.Bool("existing", false, "also extract the images already in the directories")
+	settle := fs.Duration("settle", watch.DefaultSettle, "how long a file must go unchanged before it is extracted")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("expected a directory")
+	}
+	if err := backend.check(); err != nil {
+		return err
+	}
+	if out.format != "text" && out.format != "json" {
+		return fmt.Errorf("unknown format %q, expected text or json", out.format)
+	}
+
+	w := &watch.Watcher{Dirs: fs.Args(), Settle: *settle, Existing: *existing}
+	w.Pipeline = &pipeline.Pipeline{Backend: serverless.Backend{Local: backend.name == "local", K: *k}}
+	if *storePath != "" {
+		bolt, err := store.OpenBoltStore(*storePath)
+		if err != nil {
+			return err
+		}
+		defer bolt.Close()
+		w.Store = bolt
+	}
+	w.OnResult = func(r pipeline.Result) {
+		if r.Err != nil {
+			fmt.Fprintf(s.stderr, "palettecalc watch: %s: %v\n", r.Input.Source, r.Err)
+			return
+		}
+		if out.format == "json" {
+			json.NewEncoder(s.stdout).Encode(map[string]interface{}{"file": r.Input.Source, "palette": r.Palette})
+			return
+		}
+		hexes := make([]string, len(r.Palette.Colors))
+		for i := range r.Palette.Colors {
+			hexes[i] = hex(&r.Palette.Colors[i])
+		}
+		fmt.Fprintf(s.stdout, "%s  %s\n", r.Input.Source, strings.Join(hexes, " "))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	fmt.Fprintf(s.stderr, "palettecalc watching %s\n", strings.Join(fs.Args(), ", "))
+
+	return w.Run(ctx)
+}
+
+// Parses the positional arguments as CSS colors, n of them or at least one when n is negative
+func parseColorArgs(fs *flag.FlagSet, n int) ([]palettecalculator.Color, error) {
+	if (n < 0 && fs.NArg() == 0) || (n >= 0 && fs.NArg() != n) {
+		fs.Usage()
+		if n == 1 {
+			return nil, errors.New("expected one color")
+		}
+		if n < 0 {
+			return nil, errors.New("expected colors or -in")
+		}
+		return nil, fmt.Errorf("expected %d colors", n)
+	}
+
+	var colors []palettecalculator.Color
+	for _, arg := range fs.Args() {
+		c, err := palettecalculator.ParseCSS(arg)
+		if err != nil {
+			return nil, err
+		}
+		colors = append(colors, *c)
+	}
+
+	return colors, nil
+}
+
+// Six digit #rrggbb of a color
+func hex(c *palettecalculator.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
+
+func pass(ok bool) string {
+	if ok {
+		return "pass"
+	}
+	return "fail"
+}
+
+func isURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "gs://")
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Environment variable naming the config file, overridden by -config
+const configEnv = "PALETTECALC_CONFIG"
+
+// Prefix of the environment variables setting flags, e.g. PALETTECALC_CACHE_TTL sets -cache-ttl
+const envPrefix = "PALETTECALC_"
+
+// Flag values of a config file, top level keys apply to every command that has the flag and the keys of a section
+// named after a command to that command only, e.g.
+//
+//	backend: vision
+//	credentials: /etc/palettecalc/key.json
+//
+//	serve:
+//	  addr: :9000
+//	  rate-limit: 5
+type config struct {
+	shared   map[string]string
+	sections map[string]map[string]string
+}
+
+// Parses args into fs after setting its flags from the config file and environment. Flags on the command line win
+// over environment variables, which win over the command's section of the config file, which wins over its top level
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	fs.String("config", "", "config `file`, defaults to $"+configEnv+" or palettecalc/config.yaml in the user config directory")
+
+	c, err := findConfig(fs, args)
+	if err != nil {
+		return err
+	}
+	for _, values := range []map[string]string{c.shared, c.sections[fs.Name()], envFlags(fs)} {
+		for name, value := range values {
+			if name == "config" || fs.Lookup(name) == nil {
+				continue
+			}
+			if err := fs.Set(name, value); err != nil {
+				return fmt.Errorf("invalid %s %q: %w", name, value, err)
+			}
+		}
+	}
+	for name := range c.sections[fs.Name()] {
+		if fs.Lookup(name) == nil {
+			return fmt.Errorf("config: %s has no flag %q", fs.Name(), name)
+		}
+	}
+
+	return fs.Parse(args)
+}
+
+// Loads the config file named by -config in args or $PALETTECALC_CONFIG, or the default one when it exists
+func findConfig(fs *flag.FlagSet, args []string) (*config, error) {
+	path, explicit := configFlag(fs, args)
+	if !explicit {
+		path, explicit = os.LookupEnv(configEnv)
+	}
+	if !explicit {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return &config{}, nil
+		}
+		path = filepath.Join(dir, "palettecalc", "config.yaml")
+	}
+	if path == "" {
+		return &config{}, nil
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		return &config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	c, err := loadConfig(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return c, nil
+}
+
+// Value of -config in args, read before the flags are parsed so that they can be set from the file. args are parsed
+// with the flags of fs, so that flag values aren't mistaken for positional arguments, but without setting them
+func configFlag(fs *flag.FlagSet, args []string) (string, bool) {
+	probe := flag.NewFlagSet(fs.Name(), flag.ContinueOnError)
+	probe.SetOutput(io.Discard)
+	fs.VisitAll(func(f *flag.Flag) {
+		if f.Name != "config" {
+			probe.Var(discardValue{boolFlag: isBoolFlag(f.Value)}, f.Name, "")
+		}
+	})
+	path := probe.String("config", "", "")
+	// a bad flag is reported by the real parse
+	probe.Parse(args)
+
+	explicit := false
+	probe.Visit(func(f *flag.Flag) {
+		if f.Name == "config" {
+			explicit = true
+		}
+	})
+
+	return *path, explicit
+}
+
+// Flag value ignoring what it is set to, standing in for a flag of the FlagSet configFlag probes
+type discardValue struct {
+	boolFlag bool
+}
+
+func (v discardValue) String() string   { return "" }
+func (v discardValue) Set(string) error { return nil }
+func (v discardValue) IsBoolFlag() bool { return v.boolFlag }
+
+func isBoolFlag(v flag.Value) bool {
+	b, ok := v.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// Reads a YAML config file of keys and sections of keys. Values are scalars, written as they would be on the
+// command line
+func loadConfig(r io.Reader) (*config, error) {
+	var values map[string]interface{}
+	if err := yaml.NewDecoder(r).Decode(&values); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	c := &config{shared: map[string]string{}, sections: map[string]map[string]string{}}
+	for key, value := range values {
+		keys, ok := value.(map[string]interface{})
+		if !ok {
+			flagValue, err := configValue(key, value)
+			if err != nil {
+				return nil, err
+			}
+			c.shared[key] = flagValue
+			continue
+		}
+
+		section := map[string]string{}
+		for name, value := range keys {
+			flagValue, err := configValue(key+"."+name, value)
+			if err != nil {
+				return nil, err
+			}
+			section[name] = flagValue
+		}
+		c.sections[key] = section
+	}
+
+	return c, nil
+}
+
+// Flag value of a scalar, an empty one for null
+func configValue(key string, value interface{}) (string, error) {
+	switch value.(type) {
+	case nil:
+		return "", nil
+	case map[string]interface{}, []interface{}:
+		return "", fmt.Errorf("config: %s must be a value, not a list or map", key)
+	}
+	return fmt.Sprint(value), nil
+}
+
+// Flag values set by PALETTECALC_ environment variables
+func envFlags(fs *flag.FlagSet) map[string]string {
+	values := map[string]string{}
+	fs.VisitAll(func(f *flag.Flag) {
+		name := envPrefix + strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
+		if value, ok := os.LookupEnv(name); ok {
+			values[f.Name] = value
+		}
+	})
+
+	return values
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    *config
+		wantErr string
+	}{
+		{
+			name: "shared keys and sections",
+			input: `# palettecalc config
+backend: vision
+cache: "" # no cache
+
+serve:
+  addr: ':9000'
+  rate-limit: 5
+extract:
+  k: 8
+format: json
+`,
+			want: &config{
+				shared: map[string]string{"backend": "vision", "cache": "", "format": "json"},
+				sections: map[string]map[string]string{
+					"serve":   {"addr": ":9000", "rate-limit": "5"},
+					"extract": {"k": "8"},
+				},
+			},
+		},
+		{
+			name:  "empty file",
+			input: "# nothing set\n",
+			want:  &config{shared: map[string]string{}, sections: map[string]map[string]string{}},
+		},
+		{
+			name:    "list value",
+			input:   "serve:\n  addr:\n    - :9000\n",
+			wantErr: "config: serve.addr must be a value, not a list or map",
+		},
+		{
+			name:    "not yaml",
+			input:   "backend: vision\n- local\n",
+			wantErr: "yaml: line 1: did not find expected key",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := loadConfig(strings.NewReader(test.input))
+			if test.wantErr != "" {
+				if err == nil || err.Error() != test.wantErr {
+					t.Errorf("expected: %v\n returned: %v\n", test.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(c, test.want) {
+				t.Errorf("expected: %v\n returned: %v\n", test.want, c)
+			}
+		})
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	err := os.WriteFile(path, []byte("backend: vision\nformat: json\naddr: :7000\n\nserve:\n  addr: :9000\n  format: text\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AppData", dir)
+	t.Setenv(configEnv, "")
+
+	tests := []struct {
+		name    string
+		command string
+		env     map[string]string
+		args    []string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:    "defaults without a config file",
+			command: "serve",
+			want:    map[string]string{"backend": "local", "addr": ":8080", "format": "text"},
+		},
+		{
+			name:    "section wins over shared keys",
+			command: "serve",
+			args:    []string{"-config", path},
+			want:    map[string]string{"backend": "vision", "addr": ":9000", "format": "text"},
+		},
+		{
+			name:    "shared keys",
+			command: "extract",
+			env:     map[string]string{configEnv: path},
+			want:    map[string]string{"backend": "vision", "addr": ":8080", "format": "json"},
+		},
+		{
+			name:    "environment wins over the config file",
+			command: "serve",
+			env:     map[string]string{configEnv: path, "PALETTECALC_ADDR": ":9500"},
+			want:    map[string]string{"backend": "vision", "addr": ":9500", "format": "text"},
+		},
+		{
+			name:    "flags win over the environment",
+			command: "serve",
+			env:     map[string]string{"PALETTECALC_ADDR": ":9500", "PALETTECALC_BACKEND": "vision"},
+			args:    []string{"-config=" + path, "-addr", ":9600"},
+			want:    map[string]string{"backend": "vision", "addr": ":9600", "format": "text"},
+		},
+		{
+			name:    "config after a flag taking a value",
+			command: "serve",
+			args:    []string{"-addr", ":9600", "-config", path},
+			want:    map[string]string{"backend": "vision", "addr": ":9600", "format": "text"},
+		},
+		{
+			name:    "missing config file",
+			command: "serve",
+			args:    []string{"-config", filepath.Join(dir, "missing.yaml")},
+			wantErr: "no such file or directory",
+		},
+		{
+			name:    "invalid environment value",
+			command: "serve",
+			env:     map[string]string{"PALETTECALC_RATE_LIMIT": "fast"},
+			wantErr: `invalid rate-limit "fast"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for name, value := range test.env {
+				t.Setenv(name, value)
+			}
+			fs := flag.NewFlagSet(test.command, flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			var backend backendFlags
+			backend.register(fs)
+			var out outputFlags
+			out.register(fs)
+			// addr is a flag of serve only, extract leaves the top level addr of the config file alone
+			if test.command == "serve" {
+				fs.String("addr", ":8080", "")
+				fs.Float64("rate-limit", 0, "")
+			}
+
+			err := parseFlags(fs, test.args)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Errorf("expected: %v\n returned: %v\n", test.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			returned := map[string]string{"backend": backend.name, "addr": ":8080", "format": out.format}
+			if f := fs.Lookup("addr"); f != nil {
+				returned["addr"] = f.Value.String()
+			}
+			if !reflect.DeepEqual(returned, test.want) {
+				t.Errorf("expected: %v\n returned: %v\n", test.want, returned)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+)
+
+// Opens source as stdin when it is -, an http or https URL, or a file
+func openInput(source string, stdin io.Reader) (io.ReadCloser, error) {
+	if source == "-" {
+		return io.NopCloser(stdin), nil
+	}
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		resp, err := http.Get(source)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("fetching %s: %s", source, resp.Status)
+		}
+		return resp.Body, nil
+	}
+
+	return os.Open(source)
+}
+
+// Loads a palette file by its extension, - reads a JSON palette from stdin
+func loadPalette(source string, stdin io.Reader) (*palettecalculator.Palette, error) {
+	r, err := openInput(source, stdin)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	ext := strings.ToLower(filepath.Ext(source))
+	if source == "-" {
+		ext = ".json"
+	}
+	switch ext {
+	case ".json":
+		p := new(palettecalculator.Palette)
+		if err := json.NewDecoder(r).Decode(p); err != nil {
+			return nil, err
+		}
+		return p, nil
+	case ".yaml", ".yml":
+		return palettecalculator.LoadYAMLPalette(r)
+	case ".toml":
+		return palettecalculator.LoadTOMLPalette(r)
+	case ".gpl":
+		return palettecalculator.LoadGPLPalette(r)
+	case ".ase":
+		return palettecalculator.LoadASEPalette(r)
+	case ".aco":
+		return palettecalculator.LoadACOPalette(r)
+	}
+
+	return nil, fmt.Errorf("unknown palette file type %q, expected .json, .yaml, .toml, .gpl, .ase or .aco", ext)
+}
@@ -0,0 +1,76 @@
+// Command palettecalc extracts palettes from images and calculates, converts, checks and exports colors from the
+// command line.
+//
+// Usage:
+//
+//	palettecalc <command> [flags] [arguments]
+//
+// Run palettecalc <command> -h for the flags of a command.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `usage: palettecalc <command> [flags] [arguments]
+
+commands:
+  extract   extract the palette of an image file, URL or - for stdin
+  scheme    calculate a color scheme from a color
+  convert   show a color as hex, rgb, hsl, its nearest name and ansi index
+  contrast  check the WCAG contrast of a foreground and background color
+  export    export a palette as scss, less, gpl, tailwind, tokens, markdown, svg, png and more
+  serve     serve extract, scheme and swatch previews as a JSON REST API
+  watch     extract the palettes of images as they arrive in directories
+
+Run palettecalc <command> -h for the flags of a command.
+`
+
+// Standard streams of a command run
+type streams struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// Subcommands by name, each parses its own flags from args
+var commands = map[string]func(args []string, s *streams) error{
+	"extract":  extract,
+	"scheme":   scheme,
+	"convert":  convert,
+	"contrast": contrast,
+	"export":   export,
+	"serve":    serve,
+	"watch":    watchDirs,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// Runs the command named by args[0] and returns the process exit code
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	command, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "palettecalc: unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+	err := command(args[1:], &streams{stdin: stdin, stdout: stdout, stderr: stderr})
+	if err == flag.ErrHelp {
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "palettecalc %s: %v\n", args[0], err)
+		return 1
+	}
+
+	return 0
+}
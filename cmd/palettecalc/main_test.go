@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	// keep the extract cache out of the user's cache directory
+	cacheDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheDir)
+	t.Setenv("HOME", cacheDir)
+	t.Setenv("LocalAppData", cacheDir)
+	t.Setenv("XDG_CONFIG_HOME", cacheDir)
+	t.Setenv("AppData", cacheDir)
+
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 4; y++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+			if x == 0 {
+				img.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, img); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "no command prints usage",
+			args:       nil,
+			wantCode:   2,
+			wantStderr: "usage: palettecalc <command>",
+		},
+		{
+			name:       "unknown command",
+			args:       []string{"paint"},
+			wantCode:   2,
+			wantStderr: `palettecalc: unknown command "paint"`,
+		},
+		{
+			name:       "command help",
+			args:       []string{"convert", "-h"},
+			wantCode:   2,
+			wantStderr: "usage: palettecalc convert [flags] <color>",
+		},
+		{
+			name:       "extract from stdin",
+			args:       []string{"extract", "-k", "2", "-"},
+			stdin:      encoded.String(),
+			wantCode:   0,
+			wantStdout: "#ff0000   75.0%  red\n#0000ff   25.0%  blue\n",
+		},
+		{
+			name:       "extract cached",
+			args:       []string{"extract", "-k", "2", "-"},
+			stdin:      encoded.String(),
+			wantCode:   0,
+			wantStdout: "#ff0000   75.0%  red\n#0000ff   25.0%  blue\n",
+		},
+		{
+			name:       "extract without cache",
+			args:       []string{"extract", "-k", "2", "-cache", "", "-"},
+			stdin:      encoded.String(),
+			wantCode:   0,
+			wantStdout: "#ff0000   75.0%  red\n#0000ff   25.0%  blue\n",
+		},
+		{
+			name:       "extract json",
+			args:       []string{"extract", "-k", "1", "-format", "json", "-"},
+			stdin:      encoded.String(),
+			wantCode:   0,
+			wantStdout: "\"weights\": [\n    1\n  ]",
+		},
+		{
+			name:       "extract unknown backend",
+			args:       []string{"extract", "-backend", "crayon", "-"},
+			wantCode:   1,
+			wantStderr: `palettecalc extract: unknown backend "crayon", expected local or vision`,
+		},
+		{
+			name:       "scheme",
+			args:       []string{"scheme", "-type", "complimentary", "#ff0000"},
+			wantCode:   0,
+			wantStdout: "#ff0000\n#00ffff\n",
+		},
+		{
+			name:       "convert",
+			args:       []string{"convert", "red"},
+			wantCode:   0,
+			wantStdout: "hex   #ff0000\nrgb   rgb(255, 0, 0)\nhsl   hsl(0, 100%, 50%)\nname  red\nansi  196\n",
+		},
+		{
+			name:       "convert json",
+			args:       []string{"convert", "-format", "json", "#000"},
+			wantCode:   0,
+			wantStdout: "\"hex\": \"#000000\"",
+		},
+		{
+			name:       "convert invalid color",
+			args:       []string{"convert", "notacolor"},
+			wantCode:   1,
+			wantStderr: `palettecalc convert: invalid css color "notacolor"`,
+		},
+		{
+			name:       "contrast",
+			args:       []string{"contrast", "#000", "#fff"},
+			wantCode:   0,
+			wantStdout: "ratio      21:1\nAA         pass\nAA large   pass\nAAA        pass\nAAA large  pass\n",
+		},
+		{
+			name:       "contrast needs two colors",
+			args:       []string{"contrast", "#000"},
+			wantCode:   1,
+			wantStderr: "palettecalc contrast: expected 2 colors",
+		},
+		{
+			name:       "export scss",
+			args:       []string{"export", "-as", "scss", "#ff0000", "#00ff00"},
+			wantCode:   0,
+			wantStdout: "$color-1: #ff0000;\n$color-2: #00ff00;\n",
+		},
+		{
+			name:       "export gpl from json on stdin",
+			args:       []string{"export", "-as", "gpl", "-name", "Brand", "-in", "-"},
+			stdin:      `{"colors": ["#ff0000"]}`,
+			wantCode:   0,
+			wantStdout: "GIMP Palette\nName: Brand\n",
+		},
+		{
+			name:       "serve unknown backend",
+			args:       []string{"serve", "-backend", "crayon"},
+			wantCode:   1,
+			wantStderr: `palettecalc serve: unknown backend "crayon", expected local or vision`,
+		},
+		{
+			name:       "watch needs a directory",
+			args:       []string{"watch"},
+			wantCode:   1,
+			wantStderr: "palettecalc watch: expected a directory",
+		},
+		{
+			name:       "export unknown format",
+			args:       []string{"export", "-as", "bmp", "#fff"},
+			wantCode:   1,
+			wantStderr: `palettecalc export: unknown export format "bmp"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Errorf("expected: %v\n returned: %v\n", tt.wantCode, code)
+			}
+			if !strings.Contains(stdout.String(), tt.wantStdout) {
+				t.Errorf("expected: %v\n returned: %v\n", tt.wantStdout, stdout.String())
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("expected: %v\n returned: %v\n", tt.wantStderr, stderr.String())
+			}
+		})
+	}
+}
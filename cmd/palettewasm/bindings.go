@@ -0,0 +1,218 @@
+// Command palettewasm exposes the color conversion, scheme and contrast math to JavaScript. Build it with
+//
+//	GOOS=js GOARCH=wasm go build -o palettecalc.wasm ./cmd/palettewasm
+//
+// and load it with wasm_exec.js from the Go distribution. The functions in bindings are set on a global
+// palettecalc object. Colors are CSS strings, results are plain JS values, and a failed call returns an Error
+// instead of throwing:
+//
+//	palettecalc.scheme("#e3c49a", "triadic") // [{red: 227, green: 196, blue: 154, hex: "e3c49a"}, ...]
+package main
+
+import (
+	"fmt"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+)
+
+// Functions set on the palettecalc global by name. Arguments are the call's strings, numbers and booleans, results
+// are marshaled to JSON so Colors keep their JSON form
+var bindings = map[string]func(args []interface{}) (interface{}, error){
+	"parse":       parse,
+	"convert":     convert,
+	"scheme":      scheme,
+	"contrast":    contrast,
+	"mix":         mix,
+	"gradient":    gradient,
+	"nearestName": nearestName,
+}
+
+// Interpolation spaces of mix and gradient by name
+var interpolationSpaces = map[string]palettecalculator.InterpolationSpace{
+	"srgb":       palettecalculator.InterpolateSRGB,
+	"linear-rgb": palettecalculator.InterpolateLinearRGB,
+	"hsl":        palettecalculator.InterpolateHSLShortest,
+	"hsl-longer": palettecalculator.InterpolateHSLLongest,
+	"oklch":      palettecalculator.InterpolateOKLCH,
+}
+
+// Representations returned by convert
+type conversion struct {
+	Hex     string                  `json:"hex"`
+	RGB     palettecalculator.Color `json:"rgb"`
+	HSL     *palettecalculator.HSL  `json:"hsl"`
+	Name    string                  `json:"name"`
+	ANSI256 int                     `json:"ansi256"`
+}
+
+// WCAG results returned by contrast
+type contrastResult struct {
+	Ratio    float64 `json:"ratio"`
+	AA       bool    `json:"aa"`
+	AALarge  bool    `json:"aaLarge"`
+	AAA      bool    `json:"aaa"`
+	AAALarge bool    `json:"aaaLarge"`
+}
+
+// parse(color) returns the color's channels and hex
+func parse(args []interface{}) (interface{}, error) {
+	return colorArg(args, 0)
+}
+
+// convert(color) returns the color as hex, rgb, hsl, its nearest CSS name and ANSI 256 index
+func convert(args []interface{}) (interface{}, error) {
+	c, err := colorArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	pc := new(palettecalculator.PaletteCalculator)
+	named, _ := pc.NearestNamedColor(c)
+	r, g, b, _ := c.RGBA()
+	return &conversion{
+		Hex:     fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8),
+		RGB:     *c,
+		HSL:     pc.ConvertRGBToHSL(c),
+		Name:    named.Name,
+		ANSI256: pc.ToANSI256(c),
+	}, nil
+}
+
+// scheme(color, type) returns the scheme's colors, type defaults to complimentary
+func scheme(args []interface{}) (interface{}, error) {
+	c, err := colorArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	schemeType := palettecalculator.Complimentary
+	if len(args) > 1 && args[1] != nil {
+		t, err := stringArg(args, 1, "type")
+		if err != nil {
+			return nil, err
+		}
+		schemeType = palettecalculator.SchemeType(t)
+	}
+
+	return new(palettecalculator.PaletteCalculator).CalculateScheme(c, schemeType)
+}
+
+// contrast(foreground, background) returns the WCAG ratio and which levels it passes
+func contrast(args []interface{}) (interface{}, error) {
+	fg, err := colorArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	bg, err := colorArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	pc := new(palettecalculator.PaletteCalculator)
+	return &contrastResult{
+		Ratio:    pc.ContrastRatio(fg, bg),
+		AA:       pc.PassesAA(fg, bg, palettecalculator.NormalText),
+		AALarge:  pc.PassesAA(fg, bg, palettecalculator.LargeText),
+		AAA:      pc.PassesAAA(fg, bg, palettecalculator.NormalText),
+		AAALarge: pc.PassesAAA(fg, bg, palettecalculator.LargeText),
+	}, nil
+}
+
+// mix(a, b, t, space) returns the color t of the way from a to b, space defaults to oklch
+func mix(args []interface{}) (interface{}, error) {
+	a, err := colorArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	b, err := colorArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	t, err := numberArg(args, 2, "t")
+	if err != nil {
+		return nil, err
+	}
+	space, err := spaceArg(args, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(palettecalculator.PaletteCalculator).Mix(a, b, t, space), nil
+}
+
+// gradient(from, to, steps, space) returns steps colors from from to to, space defaults to oklch
+func gradient(args []interface{}) (interface{}, error) {
+	from, err := colorArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	to, err := colorArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	steps, err := numberArg(args, 2, "steps")
+	if err != nil {
+		return nil, err
+	}
+	space, err := spaceArg(args, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(palettecalculator.PaletteCalculator).Gradient(from, to, int(steps), space), nil
+}
+
+// nearestName(color) returns the name of the nearest CSS named color
+func nearestName(args []interface{}) (interface{}, error) {
+	c, err := colorArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	named, _ := new(palettecalculator.PaletteCalculator).NearestNamedColor(c)
+	return named.Name, nil
+}
+
+func colorArg(args []interface{}, i int) (*palettecalculator.Color, error) {
+	s, err := stringArg(args, i, "color")
+	if err != nil {
+		return nil, err
+	}
+	return palettecalculator.ParseCSS(s)
+}
+
+func stringArg(args []interface{}, i int, name string) (string, error) {
+	if i >= len(args) {
+		return "", fmt.Errorf("missing argument %d: expected %s", i+1, name)
+	}
+	s, ok := args[i].(string)
+	if !ok {
+		return "", fmt.Errorf("invalid argument %d: expected %s as a string", i+1, name)
+	}
+	return s, nil
+}
+
+func numberArg(args []interface{}, i int, name string) (float64, error) {
+	if i >= len(args) {
+		return 0, fmt.Errorf("missing argument %d: expected %s", i+1, name)
+	}
+	n, ok := args[i].(float64)
+	if !ok {
+		return 0, fmt.Errorf("invalid argument %d: expected %s as a number", i+1, name)
+	}
+	return n, nil
+}
+
+func spaceArg(args []interface{}, i int) (palettecalculator.InterpolationSpace, error) {
+	if i >= len(args) || args[i] == nil {
+		return palettecalculator.InterpolateOKLCH, nil
+	}
+	name, err := stringArg(args, i, "space")
+	if err != nil {
+		return 0, err
+	}
+	space, ok := interpolationSpaces[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown interpolation space %q, expected srgb, linear-rgb, hsl, hsl-longer or oklch", name)
+	}
+	return space, nil
+}
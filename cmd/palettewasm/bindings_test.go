@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBindings(t *testing.T) {
+	tests := []struct {
+		name        string
+		binding     string
+		args        []interface{}
+		expected    string
+		expectedErr string
+	}{
+		{
+			name:     "parse",
+			binding:  "parse",
+			args:     []interface{}{"rgb(24 98 119)"},
+			expected: `{"red":24,"green":98,"blue":119,"hex":"186277"}`,
+		},
+		{
+			name:     "convert",
+			binding:  "convert",
+			args:     []interface{}{"red"},
+			expected: `{"hex":"#ff0000","rgb":{"red":255,"green":0,"blue":0,"hex":"ff0000"},"hsl":{"hue":0,"saturation":1,"luminosity":0.5},"name":"red","ansi256":196}`,
+		},
+		{
+			name:     "scheme defaults to complimentary",
+			binding:  "scheme",
+			args:     []interface{}{"#ff0000"},
+			expected: `[{"red":255,"green":0,"blue":0,"hex":"ff0000"},{"red":0,"green":255,"blue":255,"hex":"00ffff"}]`,
+		},
+		{
+			name:        "scheme with unknown type",
+			binding:     "scheme",
+			args:        []interface{}{"#ff0000", "plaid"},
+			expectedErr: "unsupported scheme type: plaid",
+		},
+		{
+			name:     "contrast",
+			binding:  "contrast",
+			args:     []interface{}{"black", "white"},
+			expected: `{"ratio":21,"aa":true,"aaLarge":true,"aaa":true,"aaaLarge":true}`,
+		},
+		{
+			name:     "mix in srgb",
+			binding:  "mix",
+			args:     []interface{}{"black", "white", .5, "srgb"},
+			expected: `{"red":128,"green":128,"blue":128,"hex":"808080"}`,
+		},
+		{
+			name:        "mix with unknown space",
+			binding:     "mix",
+			args:        []interface{}{"black", "white", .5, "cmyk"},
+			expectedErr: `unknown interpolation space "cmyk", expected srgb, linear-rgb, hsl, hsl-longer or oklch`,
+		},
+		{
+			name:     "gradient",
+			binding:  "gradient",
+			args:     []interface{}{"black", "white", float64(2), "srgb"},
+			expected: `[{"red":0,"green":0,"blue":0,"hex":"000000"},{"red":255,"green":255,"blue":255,"hex":"ffffff"}]`,
+		},
+		{
+			name:     "nearest name",
+			binding:  "nearestName",
+			args:     []interface{}{"#fe0101"},
+			expected: `"red"`,
+		},
+		{
+			name:        "missing color",
+			binding:     "nearestName",
+			args:        nil,
+			expectedErr: "missing argument 1: expected color",
+		},
+		{
+			name:        "number instead of color",
+			binding:     "parse",
+			args:        []interface{}{float64(12)},
+			expectedErr: "invalid argument 1: expected color as a string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := bindings[tt.binding](tt.args)
+			if tt.expectedErr != "" {
+				if err == nil || err.Error() != tt.expectedErr {
+					t.Errorf("expected: %v\n returned: %v\n", tt.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			returned, err := json.Marshal(result)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(returned) != tt.expected {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expected, string(returned))
+			}
+		})
+	}
+}
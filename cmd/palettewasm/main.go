@@ -0,0 +1,50 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+)
+
+func main() {
+	api := js.Global().Get("Object").New()
+	for name, binding := range bindings {
+		binding := binding
+		api.Set(name, js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			return call(binding, args)
+		}))
+	}
+	js.Global().Set("palettecalc", api)
+
+	// keep the runtime alive to serve calls
+	select {}
+}
+
+// Calls binding with the JS arguments and converts its result to a JS value through JSON
+func call(binding func(args []interface{}) (interface{}, error), args []js.Value) interface{} {
+	var goArgs []interface{}
+	for _, arg := range args {
+		switch arg.Type() {
+		case js.TypeString:
+			goArgs = append(goArgs, arg.String())
+		case js.TypeNumber:
+			goArgs = append(goArgs, arg.Float())
+		case js.TypeBoolean:
+			goArgs = append(goArgs, arg.Bool())
+		default:
+			goArgs = append(goArgs, nil)
+		}
+	}
+
+	result, err := binding(goArgs)
+	if err != nil {
+		return js.Global().Get("Error").New(err.Error())
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return js.Global().Get("Error").New(err.Error())
+	}
+
+	return js.Global().Get("JSON").Call("parse", string(data))
+}
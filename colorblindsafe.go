@@ -0,0 +1,87 @@
+package palettecalculator
+
+import "math"
+
+// Default minimum CIE76 Delta E between simulated colors of a color blind safe scheme
+const DefaultColorBlindSafeDeltaE = float64(10)
+
+// Deficiencies a color blind safe scheme must stay distinguishable under
+var commonDeficiencies = []ColorVisionDeficiency{Protanopia, Deuteranopia, Tritanopia}
+
+// Scheme option keeping every pair of generated colors at least MinDeltaE apart when simulated for protanopia,
+// deuteranopia and tritanopia. Colors are nudged in lightness and then hue, the dominant color is never changed.
+// CalculateScheme returns a *ColorBlindSafeError when no nudge reaches MinDeltaE, the scheme methods returning
+// []Color keep the largest separation found and can be checked with CheckColorBlindSafe.
+// A zero MinDeltaE uses DefaultColorBlindSafeDeltaE
+type ColorBlindSafe struct {
+	MinDeltaE float64
+}
+
+func (pc *PaletteCalculator) makeColorBlindSafe(colors []Color, minDeltaE float64) []Color {
+	if minDeltaE == 0 {
+		minDeltaE = DefaultColorBlindSafeDeltaE
+	}
+
+	safe := append([]Color(nil), colors...)
+	for i := 1; i < len(safe); i++ {
+		if pc.minSimulatedDistance(&safe[i], safe[:i]) >= minDeltaE {
+			continue
+		}
+
+		// search smallest lightness (then hue) nudge that separates this color from every earlier one,
+		// keeping the best separation found should none reach minDeltaE
+		lch := pc.ConvertLABToLCH(pc.ConvertRGBToLAB(&safe[i]))
+		best, bestDistance, bestCost := safe[i], float64(-1), math.Inf(1)
+		for _, dh := range []float64{0, 10, -10, 20, -20} {
+			for dl := float64(-50); dl <= 50; dl += 2 {
+				l := lch.L + dl
+				if l < 0 || l > 100 {
+					continue
+				}
+
+				candidate := pc.ConvertLCHToRGB(&LCH{L: l, C: lch.C, H: math.Mod(lch.H+dh+360, 360)})
+				distance := pc.minSimulatedDistance(candidate, safe[:i])
+				cost := math.Abs(dl) + math.Abs(dh)/2
+
+				if (distance >= minDeltaE && (bestDistance < minDeltaE || cost < bestCost)) ||
+					(bestDistance < minDeltaE && distance > bestDistance) {
+					best, bestDistance, bestCost = *candidate, distance, cost
+				}
+			}
+		}
+		safe[i] = best
+	}
+
+	return safe
+}
+
+// Checks that every pair of colors is at least minDeltaE apart when simulated for protanopia, deuteranopia and
+// tritanopia, returning a *ColorBlindSafeError otherwise. A zero minDeltaE uses DefaultColorBlindSafeDeltaE
+func (pc *PaletteCalculator) CheckColorBlindSafe(colors []Color, minDeltaE float64) error {
+	if minDeltaE == 0 {
+		minDeltaE = DefaultColorBlindSafeDeltaE
+	}
+
+	closest := math.Inf(1)
+	for i := 1; i < len(colors); i++ {
+		closest = math.Min(closest, pc.minSimulatedDistance(&colors[i], colors[:i]))
+	}
+	if closest < minDeltaE {
+		return &ColorBlindSafeError{MinDeltaE: minDeltaE, DeltaE: closest}
+	}
+
+	return nil
+}
+
+// Smallest Delta E between c and any of others across the common deficiencies
+func (pc *PaletteCalculator) minSimulatedDistance(c *Color, others []Color) float64 {
+	distance := math.Inf(1)
+	for _, deficiency := range commonDeficiencies {
+		simulated := pc.SimulateColorVisionDeficiency(c, deficiency)
+		for j := range others {
+			distance = math.Min(distance, pc.deltaE76(simulated, pc.SimulateColorVisionDeficiency(&others[j], deficiency)))
+		}
+	}
+
+	return distance
+}
@@ -0,0 +1,101 @@
+package palettecalculator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCalculateColorSchemeWithColorBlindSafe(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		scheme      SchemeType
+		expectedRGB []Color
+	}{
+		{
+			name:        "should leave already distinguishable scheme unchanged",
+			scheme:      Complimentary,
+			expectedRGB: []Color{{Red, Green, Blue, Hex}, {119, 45, 24, "772d18"}},
+		},
+		{
+			name:        "should nudge colors that are confused under deficiencies",
+			scheme:      Triadic,
+			expectedRGB: []Color{{Red, Green, Blue, Hex}, {126, 15, 83, "7e0f53"}, {96, 119, 24, "607718"}},
+		},
+		{
+			name:        "should nudge analogous colors",
+			scheme:      Analogous,
+			expectedRGB: []Color{{Red, Green, Blue, Hex}, {51, 140, 112, "338c70"}, {24, 51, 119, "183377"}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dominantColor := &Color{Red, Green, Blue, Hex}
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedRGB, _ := paletteCalculator.CalculateScheme(dominantColor, test.scheme, ColorBlindSafe{MinDeltaE: 15})
+
+			if !reflect.DeepEqual(test.expectedRGB, returnedRGB) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedRGB, returnedRGB)
+			}
+		})
+	}
+}
+
+func TestColorBlindSafeSeparation(t *testing.T) {
+	dominantColor := &Color{Red, Green, Blue, Hex}
+	paletteCalculator := new(PaletteCalculator)
+
+	for _, scheme := range []SchemeType{SplitComplimentary, Triadic, Tetradic, Analogous, DoubleSplitComplimentary} {
+		t.Run(string(scheme), func(t *testing.T) {
+			returnedRGB, _ := paletteCalculator.CalculateScheme(dominantColor, scheme, ColorBlindSafe{})
+
+			if !reflect.DeepEqual(*dominantColor, returnedRGB[0]) {
+				t.Errorf("expected dominant color %v to be kept, returned %v", *dominantColor, returnedRGB[0])
+			}
+
+			for i := 1; i < len(returnedRGB); i++ {
+				if d := paletteCalculator.minSimulatedDistance(&returnedRGB[i], returnedRGB[:i]); d < DefaultColorBlindSafeDeltaE {
+					t.Errorf("color %v is %f from earlier colors, below %f", returnedRGB[i], d, DefaultColorBlindSafeDeltaE)
+				}
+			}
+		})
+	}
+}
+
+func TestCalculateSchemeColorBlindSafeUnreachable(t *testing.T) {
+	dominantColor := &Color{Red, Green, Blue, Hex}
+	paletteCalculator := new(PaletteCalculator)
+
+	returnedRGB, err := paletteCalculator.CalculateScheme(dominantColor, Monochromatic, ColorBlindSafe{MinDeltaE: 150})
+
+	if returnedRGB != nil {
+		t.Errorf("expected: %v\n returned: %v\n", nil, returnedRGB)
+	}
+	var safeErr *ColorBlindSafeError
+	if !errors.Is(err, ErrNotColorBlindSafe) || !errors.As(err, &safeErr) || safeErr.MinDeltaE != 150 {
+		t.Errorf("expected: %v\n returned: %v\n", ErrNotColorBlindSafe, err)
+	}
+}
+
+func TestCheckColorBlindSafe(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		colors      []Color
+		minDeltaE   float64
+		expectedErr bool
+	}{
+		{name: "should accept distinguishable colors", colors: []Color{{0, 0, 0, "000000"}, {255, 255, 255, "ffffff"}}},
+		{name: "should reject red and green", colors: []Color{{200, 30, 30, "c81e1e"}, {30, 140, 30, "1e8c1e"}}, minDeltaE: 30, expectedErr: true},
+		{name: "should accept a single color", colors: []Color{{Red, Green, Blue, Hex}}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			err := paletteCalculator.CheckColorBlindSafe(test.colors, test.minDeltaE)
+
+			if (err != nil) != test.expectedErr {
+				t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
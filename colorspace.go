@@ -0,0 +1,223 @@
+package palettecalculator
+
+import "math"
+
+// D65 reference white used for XYZ <-> LAB conversions
+const whiteX = 0.95047
+const whiteY = 1.0
+const whiteZ = 1.08883
+
+// Representation of CIELAB (lightness, green-red, blue-yellow) color
+type LAB struct {
+	L float64 `json:"l"`
+	A float64 `json:"a"`
+	B float64 `json:"b"`
+}
+
+// Representation of CIELCh (lightness, chroma, hue) color, the polar form of LAB
+type LCH struct {
+	L float64 `json:"l"`
+	C float64 `json:"c"`
+	H float64 `json:"h"`
+}
+
+// Representation of OKLAB (lightness, green-red, blue-yellow) color, a perceptually uniform LAB
+type OKLAB struct {
+	L float64 `json:"l"`
+	A float64 `json:"a"`
+	B float64 `json:"b"`
+}
+
+// Representation of OKLCH (lightness, chroma, hue) color, the polar form of OKLAB
+type OKLCH struct {
+	L float64 `json:"l"`
+	C float64 `json:"c"`
+	H float64 `json:"h"`
+}
+
+// Converting method for Color to LAB
+func (pc *PaletteCalculator) ConvertRGBToLAB(rgb *Color) *LAB {
+	r := linearize(rgb.Red / RGBMax)
+	g := linearize(rgb.Green / RGBMax)
+	b := linearize(rgb.Blue / RGBMax)
+
+	x := (0.4124564*r + 0.3575761*g + 0.1804375*b) / whiteX
+	y := (0.2126729*r + 0.7151522*g + 0.0721750*b) / whiteY
+	z := (0.0193339*r + 0.1191920*g + 0.9503041*b) / whiteZ
+
+	fx, fy, fz := pc.labF(x), pc.labF(y), pc.labF(z)
+
+	return &LAB{L: 116*fy - 16, A: 500 * (fx - fy), B: 200 * (fy - fz)}
+}
+
+// Converting method for LAB to Color. Out of gamut channels are clamped unless CompressChroma is given
+func (pc *PaletteCalculator) ConvertLABToRGB(lab *LAB, mapping ...GamutMapping) *Color {
+	if gamutMapping(mapping) == CompressChroma {
+		return pc.ConvertLCHToRGB(pc.ConvertLABToLCH(lab))
+	}
+
+	return pc.linearRGBToColor(pc.labToLinearRGB(lab))
+}
+
+// Converting method for LAB to LCH
+func (pc *PaletteCalculator) ConvertLABToLCH(lab *LAB) *LCH {
+	h := math.Atan2(lab.B, lab.A) * 180 / math.Pi
+	return &LCH{L: lab.L, C: math.Hypot(lab.A, lab.B), H: math.Mod(h+360, 360)}
+}
+
+// Converting method for LCH to LAB
+func (pc *PaletteCalculator) ConvertLCHToLAB(lch *LCH) *LAB {
+	rad := lch.H * math.Pi / 180
+	return &LAB{L: lch.L, A: lch.C * math.Cos(rad), B: lch.C * math.Sin(rad)}
+}
+
+// Converting method for LCH to Color. Chroma is reduced until the color fits in sRGB
+func (pc *PaletteCalculator) ConvertLCHToRGB(lch *LCH) *Color {
+	chroma := pc.maxChromaInGamut(lch.C, func(c float64) (float64, float64, float64) {
+		return pc.labToLinearRGB(pc.ConvertLCHToLAB(&LCH{L: lch.L, C: c, H: lch.H}))
+	})
+
+	return pc.ConvertLABToRGB(pc.ConvertLCHToLAB(&LCH{L: lch.L, C: chroma, H: lch.H}))
+}
+
+// Binary searches the largest chroma up to c whose linear RGB, as produced by toLinear, stays in gamut
+func (pc *PaletteCalculator) maxChromaInGamut(c float64, toLinear func(c float64) (float64, float64, float64)) float64 {
+	if pc.inGamut(toLinear(c)) {
+		return c
+	}
+
+	low, high := float64(0), c
+	for i := 0; i < 20; i++ {
+		mid := (low + high) / 2
+		if pc.inGamut(toLinear(mid)) {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return low
+}
+
+// Converts linear RGB channels in [0,1] to Color. Out of gamut channels are clamped
+func (pc *PaletteCalculator) linearRGBToColor(r float64, g float64, b float64) *Color {
+	red := clampChannel(pc.round(delinearize(r)*RGBMax, 0))
+	green := clampChannel(pc.round(delinearize(g)*RGBMax, 0))
+	blue := clampChannel(pc.round(delinearize(b)*RGBMax, 0))
+
+	return &Color{Red: red, Green: green, Blue: blue, Hex: pc.generateHex(red, green, blue)}
+}
+
+func (pc *PaletteCalculator) inGamut(r float64, g float64, b float64) bool {
+	const epsilon = 1e-6
+
+	for _, channel := range []float64{r, g, b} {
+		if channel < -epsilon || channel > 1+epsilon {
+			return false
+		}
+	}
+	return true
+}
+
+// Converting method for Color to OKLAB
+func (pc *PaletteCalculator) ConvertRGBToOKLAB(rgb *Color) *OKLAB {
+	r := linearize(rgb.Red / RGBMax)
+	g := linearize(rgb.Green / RGBMax)
+	b := linearize(rgb.Blue / RGBMax)
+
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	return &OKLAB{
+		L: 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		A: 1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		B: 0.0259040371*l + 0.7827717662*m - 0.8086757660*s,
+	}
+}
+
+// Converting method for OKLAB to Color. Out of gamut channels are clamped unless CompressChroma is given
+func (pc *PaletteCalculator) ConvertOKLABToRGB(oklab *OKLAB, mapping ...GamutMapping) *Color {
+	if gamutMapping(mapping) == CompressChroma {
+		return pc.ConvertOKLCHToRGB(pc.ConvertOKLABToOKLCH(oklab))
+	}
+
+	return pc.linearRGBToColor(pc.oklabToLinearRGB(oklab))
+}
+
+// Converting method for OKLAB to OKLCH
+func (pc *PaletteCalculator) ConvertOKLABToOKLCH(oklab *OKLAB) *OKLCH {
+	h := math.Atan2(oklab.B, oklab.A) * 180 / math.Pi
+	return &OKLCH{L: oklab.L, C: math.Hypot(oklab.A, oklab.B), H: math.Mod(h+360, 360)}
+}
+
+// Converting method for OKLCH to OKLAB
+func (pc *PaletteCalculator) ConvertOKLCHToOKLAB(oklch *OKLCH) *OKLAB {
+	rad := oklch.H * math.Pi / 180
+	return &OKLAB{L: oklch.L, A: oklch.C * math.Cos(rad), B: oklch.C * math.Sin(rad)}
+}
+
+// Converting method for OKLCH to Color. Chroma is reduced until the color fits in sRGB
+func (pc *PaletteCalculator) ConvertOKLCHToRGB(oklch *OKLCH) *Color {
+	chroma := pc.maxChromaInGamut(oklch.C, func(c float64) (float64, float64, float64) {
+		return pc.oklabToLinearRGB(pc.ConvertOKLCHToOKLAB(&OKLCH{L: oklch.L, C: c, H: oklch.H}))
+	})
+
+	return pc.ConvertOKLABToRGB(pc.ConvertOKLCHToOKLAB(&OKLCH{L: oklch.L, C: chroma, H: oklch.H}))
+}
+
+func (pc *PaletteCalculator) oklabToLinearRGB(oklab *OKLAB) (float64, float64, float64) {
+	l := oklab.L + 0.3963377774*oklab.A + 0.2158037573*oklab.B
+	m := oklab.L - 0.1055613458*oklab.A - 0.0638541728*oklab.B
+	s := oklab.L - 0.0894841775*oklab.A - 1.2914855480*oklab.B
+	l, m, s = l*l*l, m*m*m, s*s*s
+
+	r := 4.0767416621*l - 3.3077115913*m + 0.2309699292*s
+	g := -1.2684380046*l + 2.6097574011*m - 0.3413193965*s
+	b := -0.0041960863*l - 0.7034186147*m + 1.7076147010*s
+	return r, g, b
+}
+
+func (pc *PaletteCalculator) labToLinearRGB(lab *LAB) (float64, float64, float64) {
+	fy := (lab.L + 16) / 116
+	fx := fy + lab.A/500
+	fz := fy - lab.B/200
+
+	x := pc.labFInverse(fx) * whiteX
+	y := pc.labFInverse(fy) * whiteY
+	z := pc.labFInverse(fz) * whiteZ
+
+	r := 3.2404542*x - 1.5371385*y - 0.4985314*z
+	g := -0.9692660*x + 1.8760108*y + 0.0415560*z
+	b := 0.0556434*x - 0.2040259*y + 1.0572252*z
+	return r, g, b
+}
+
+func (pc *PaletteCalculator) labF(t float64) float64 {
+	if t > 216.0/24389.0 {
+		return math.Cbrt(t)
+	}
+	return (24389.0/27.0*t + 16) / 116
+}
+
+func (pc *PaletteCalculator) labFInverse(t float64) float64 {
+	if t*t*t > 216.0/24389.0 {
+		return t * t * t
+	}
+	return (116*t - 16) * 27.0 / 24389.0
+}
+
+// sRGB companding helper, channel in [0,1]
+func linearize(channel float64) float64 {
+	if channel <= 0.04045 {
+		return channel / 12.92
+	}
+	return math.Pow((channel+0.055)/1.055, 2.4)
+}
+
+// sRGB companding helper, channel in [0,1]
+func delinearize(channel float64) float64 {
+	if channel <= 0.0031308 {
+		return channel * 12.92
+	}
+	return 1.055*math.Pow(channel, 1/2.4) - 0.055
+}
@@ -0,0 +1,165 @@
+package palettecalculator
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestConvertRGBToLAB(t *testing.T) {
+	testRGB := &Color{Red: Red, Green: Green, Blue: Blue}
+	paletteCalculator := new(PaletteCalculator)
+	expectedLAB := &LAB{L: 38.31, A: -14.29, B: -18.14}
+
+	returnedLAB := paletteCalculator.ConvertRGBToLAB(testRGB)
+	roundedLAB := &LAB{L: roundFloat(returnedLAB.L, 2), A: roundFloat(returnedLAB.A, 2), B: roundFloat(returnedLAB.B, 2)}
+
+	if !reflect.DeepEqual(expectedLAB, roundedLAB) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedLAB, roundedLAB)
+	}
+
+}
+
+func TestConvertLABToRGB(t *testing.T) {
+	testLAB := &LAB{L: 38.31, A: -14.29, B: -18.14}
+	paletteCalculator := new(PaletteCalculator)
+	expectedRGB := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+
+	returnedRGB := paletteCalculator.ConvertLABToRGB(testLAB)
+
+	if !reflect.DeepEqual(expectedRGB, returnedRGB) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedRGB, returnedRGB)
+	}
+
+}
+
+func TestConvertLABToLCH(t *testing.T) {
+	testLAB := &LAB{L: 38.31, A: -14.29, B: -18.14}
+	paletteCalculator := new(PaletteCalculator)
+	expectedLCH := &LCH{L: 38.31, C: 23.09, H: 231.77}
+
+	returnedLCH := paletteCalculator.ConvertLABToLCH(testLAB)
+	roundedLCH := &LCH{L: roundFloat(returnedLCH.L, 2), C: roundFloat(returnedLCH.C, 2), H: roundFloat(returnedLCH.H, 2)}
+
+	if !reflect.DeepEqual(expectedLCH, roundedLCH) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedLCH, roundedLCH)
+	}
+
+}
+
+func TestConvertLCHToRGB(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		lch         *LCH
+		expectedRGB *Color
+	}{
+		{
+			name:        "should convert in gamut color",
+			lch:         &LCH{L: 38.31, C: 23.09, H: 231.77},
+			expectedRGB: &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+		},
+		{
+			name:        "should reduce chroma of out of gamut color",
+			lch:         &LCH{L: 99, C: 23.09, H: 231.77},
+			expectedRGB: &Color{Red: 248, Green: 253, Blue: 255, Hex: "f8fdff"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedRGB := paletteCalculator.ConvertLCHToRGB(test.lch)
+
+			if !reflect.DeepEqual(test.expectedRGB, returnedRGB) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedRGB, returnedRGB)
+			}
+		})
+	}
+}
+
+func TestConvertRGBToOKLAB(t *testing.T) {
+	testRGB := &Color{Red: Red, Green: Green, Blue: Blue}
+	paletteCalculator := new(PaletteCalculator)
+	expectedOKLAB := &OKLAB{L: .462, A: -.057, B: -.051}
+
+	returnedOKLAB := paletteCalculator.ConvertRGBToOKLAB(testRGB)
+	roundedOKLAB := &OKLAB{L: roundFloat(returnedOKLAB.L, 3), A: roundFloat(returnedOKLAB.A, 3), B: roundFloat(returnedOKLAB.B, 3)}
+
+	if !reflect.DeepEqual(expectedOKLAB, roundedOKLAB) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedOKLAB, roundedOKLAB)
+	}
+
+}
+
+func TestConvertOKLCHToRGB(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		oklch       *OKLCH
+		expectedRGB *Color
+	}{
+		{
+			name:        "should convert in gamut color",
+			oklch:       &OKLCH{L: .4623, C: .0765, H: 221.56},
+			expectedRGB: &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+		},
+		{
+			name:        "should reduce chroma of out of gamut color",
+			oklch:       &OKLCH{L: 1, C: .0765, H: 221.56},
+			expectedRGB: &Color{Red: 255, Green: 255, Blue: 255, Hex: "ffffff"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedRGB := paletteCalculator.ConvertOKLCHToRGB(test.oklch)
+
+			if !reflect.DeepEqual(test.expectedRGB, returnedRGB) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedRGB, returnedRGB)
+			}
+		})
+	}
+}
+
+func TestConvertToRGBWithGamutMapping(t *testing.T) {
+	wideLAB := &LAB{L: 60, A: 90, B: -90}
+	wideOKLAB := &OKLAB{L: .7, A: .3, B: .1}
+
+	for _, test := range []struct {
+		name        string
+		returnedRGB *Color
+		expectedRGB *Color
+	}{
+		{name: "should clip wide-gamut LAB by default", returnedRGB: new(PaletteCalculator).ConvertLABToRGB(wideLAB), expectedRGB: &Color{208, 64, 255, "d040ff"}},
+		{name: "should compress chroma of wide-gamut LAB", returnedRGB: new(PaletteCalculator).ConvertLABToRGB(wideLAB, CompressChroma), expectedRGB: &Color{193, 102, 255, "c166ff"}},
+		{name: "should clip wide-gamut OKLAB by default", returnedRGB: new(PaletteCalculator).ConvertOKLABToRGB(wideOKLAB, ClipGamut), expectedRGB: &Color{255, 0, 74, "ff004a"}},
+		{name: "should compress chroma of wide-gamut OKLAB", returnedRGB: new(PaletteCalculator).ConvertOKLABToRGB(wideOKLAB, CompressChroma), expectedRGB: &Color{255, 97, 111, "ff616f"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if !reflect.DeepEqual(test.expectedRGB, test.returnedRGB) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedRGB, test.returnedRGB)
+			}
+		})
+	}
+}
+
+func TestColorSpaceJSON(t *testing.T) {
+	for _, test := range []struct {
+		name         string
+		value        interface{}
+		expectedJSON string
+	}{
+		{name: "should marshal lab", value: LAB{L: 37.8, A: -12.9, B: -18.5}, expectedJSON: `{"l":37.8,"a":-12.9,"b":-18.5}`},
+		{name: "should marshal lch", value: LCH{L: 37.8, C: 22.55, H: 235.1}, expectedJSON: `{"l":37.8,"c":22.55,"h":235.1}`},
+		{name: "should marshal oklab", value: OKLAB{L: .4623, A: -.0572, B: -.0508}, expectedJSON: `{"l":0.4623,"a":-0.0572,"b":-0.0508}`},
+		{name: "should marshal oklch", value: OKLCH{L: .4623, C: .0765, H: 221.56}, expectedJSON: `{"l":0.4623,"c":0.0765,"h":221.56}`},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := json.Marshal(test.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != test.expectedJSON {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedJSON, string(data))
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package palettecalculator
+
+import "math"
+
+// Size of text a contrast check is made for, large text is at least 18pt or 14pt bold
+type TextSize int
+
+const (
+	NormalText TextSize = iota
+	LargeText
+)
+
+// WCAG 2.x conformance level
+type ContrastLevel int
+
+const (
+	AA ContrastLevel = iota
+	AAA
+)
+
+// CIELAB lightness and maximum chroma of suggested text tints
+const textTintLightLightness = float64(97)
+const textTintDarkLightness = float64(12)
+const textTintChroma = float64(8)
+
+// WCAG 2.x minimum contrast ratios
+const AANormalText = 4.5
+const AALargeText = 3.0
+const AAANormalText = 7.0
+const AAALargeText = 4.5
+
+// Calculates the WCAG 2.x contrast ratio of two colors, from 1 (identical luminance) to 21 (black on white)
+func (pc *PaletteCalculator) ContrastRatio(a *Color, b *Color) float64 {
+	la := a.Luminance()
+	lb := b.Luminance()
+
+	return (math.Max(la, lb) + 0.05) / (math.Min(la, lb) + 0.05)
+}
+
+// Reports whether foreground on background meets WCAG AA for the given text size
+func (pc *PaletteCalculator) PassesAA(fg *Color, bg *Color, size TextSize) bool {
+	return pc.ContrastRatio(fg, bg) >= pc.requiredContrast(AA, size)
+}
+
+// Reports whether foreground on background meets WCAG AAA for the given text size
+func (pc *PaletteCalculator) PassesAAA(fg *Color, bg *Color, size TextSize) bool {
+	return pc.ContrastRatio(fg, bg) >= pc.requiredContrast(AAA, size)
+}
+
+// Suggests a text color for background meeting level for the given text size. A light or dark tint of the
+// background's hue is preferred, falling back to white or black. Returns false if even white or black fall short
+func (pc *PaletteCalculator) SuggestTextColor(bg *Color, level ContrastLevel, size TextSize) (*Color, bool) {
+	required := pc.requiredContrast(level, size)
+	white := &Color{Red: RGBMax, Green: RGBMax, Blue: RGBMax, Hex: pc.generateHex(RGBMax, RGBMax, RGBMax)}
+	black := &Color{Red: 0, Green: 0, Blue: 0, Hex: pc.generateHex(0, 0, 0)}
+
+	// go light or dark, whichever has more contrast available
+	extreme, tintLightness := white, textTintLightLightness
+	if pc.ContrastRatio(black, bg) > pc.ContrastRatio(white, bg) {
+		extreme, tintLightness = black, textTintDarkLightness
+	}
+
+	lch := pc.ConvertLABToLCH(pc.ConvertRGBToLAB(bg))
+	tint := pc.ConvertLCHToRGB(&LCH{L: tintLightness, C: math.Min(lch.C, textTintChroma), H: lch.H})
+	if pc.ContrastRatio(tint, bg) >= required {
+		return tint, true
+	}
+
+	return extreme, pc.ContrastRatio(extreme, bg) >= required
+}
+
+func (pc *PaletteCalculator) requiredContrast(level ContrastLevel, size TextSize) float64 {
+	if level == AAA {
+		if size == LargeText {
+			return AAALargeText
+		}
+		return AAANormalText
+	}
+
+	if size == LargeText {
+		return AALargeText
+	}
+	return AANormalText
+}
@@ -0,0 +1,129 @@
+package palettecalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestContrastRatio(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		a             *Color
+		b             *Color
+		expectedRatio float64
+	}{
+		{name: "black on white", a: &Color{0, 0, 0, "000000"}, b: &Color{255, 255, 255, "ffffff"}, expectedRatio: 21},
+		{name: "order does not matter", a: &Color{255, 255, 255, "ffffff"}, b: &Color{0, 0, 0, "000000"}, expectedRatio: 21},
+		{name: "identical colors", a: &Color{Red, Green, Blue, Hex}, b: &Color{Red, Green, Blue, Hex}, expectedRatio: 1},
+		{name: "dominant color on white", a: &Color{Red, Green, Blue, Hex}, b: &Color{255, 255, 255, "ffffff"}, expectedRatio: 6.88},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedRatio := roundFloat(paletteCalculator.ContrastRatio(test.a, test.b), 2)
+
+			if test.expectedRatio != returnedRatio {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedRatio, returnedRatio)
+			}
+		})
+	}
+}
+
+func TestPassesAA(t *testing.T) {
+	for _, test := range []struct {
+		name           string
+		fg             *Color
+		size           TextSize
+		expectedPasses bool
+	}{
+		{name: "dominant color passes normal text", fg: &Color{Red, Green, Blue, Hex}, size: NormalText, expectedPasses: true},
+		{name: "mid gray fails normal text", fg: &Color{119, 119, 119, "777777"}, size: NormalText, expectedPasses: false},
+		{name: "mid gray passes large text", fg: &Color{119, 119, 119, "777777"}, size: LargeText, expectedPasses: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedPasses := paletteCalculator.PassesAA(test.fg, &Color{255, 255, 255, "ffffff"}, test.size)
+
+			if test.expectedPasses != returnedPasses {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedPasses, returnedPasses)
+			}
+		})
+	}
+}
+
+func TestPassesAAA(t *testing.T) {
+	for _, test := range []struct {
+		name           string
+		fg             *Color
+		size           TextSize
+		expectedPasses bool
+	}{
+		{name: "dominant color fails normal text", fg: &Color{Red, Green, Blue, Hex}, size: NormalText, expectedPasses: false},
+		{name: "dominant color passes large text", fg: &Color{Red, Green, Blue, Hex}, size: LargeText, expectedPasses: true},
+		{name: "black passes normal text", fg: &Color{0, 0, 0, "000000"}, size: NormalText, expectedPasses: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedPasses := paletteCalculator.PassesAAA(test.fg, &Color{255, 255, 255, "ffffff"}, test.size)
+
+			if test.expectedPasses != returnedPasses {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedPasses, returnedPasses)
+			}
+		})
+	}
+}
+
+func TestSuggestTextColor(t *testing.T) {
+	for _, test := range []struct {
+		name           string
+		bg             *Color
+		level          ContrastLevel
+		expectedColor  *Color
+		expectedPasses bool
+	}{
+		{
+			name:           "should suggest light tint on dark background",
+			bg:             &Color{Red, Green, Blue, Hex},
+			level:          AA,
+			expectedColor:  &Color{234, 249, 255, "eaf9ff"},
+			expectedPasses: true,
+		},
+		{
+			name:           "should suggest dark tint on light background",
+			bg:             &Color{250, 240, 10, "faf00a"},
+			level:          AAA,
+			expectedColor:  &Color{34, 32, 21, "222015"},
+			expectedPasses: true,
+		},
+		{
+			name:           "should fall back to black when tint falls short",
+			bg:             &Color{119, 119, 119, "777777"},
+			level:          AA,
+			expectedColor:  &Color{0, 0, 0, "000000"},
+			expectedPasses: true,
+		},
+		{
+			name:           "should report failure when level cannot be met",
+			bg:             &Color{Red, Green, Blue, Hex},
+			level:          AAA,
+			expectedColor:  &Color{255, 255, 255, "ffffff"},
+			expectedPasses: false,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedColor, returnedPasses := paletteCalculator.SuggestTextColor(test.bg, test.level, NormalText)
+
+			if !reflect.DeepEqual(test.expectedColor, returnedColor) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedColor, returnedColor)
+			}
+
+			if test.expectedPasses != returnedPasses {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedPasses, returnedPasses)
+			}
+		})
+	}
+}
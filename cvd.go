@@ -0,0 +1,60 @@
+package palettecalculator
+
+// Kind of color vision deficiency to simulate
+type ColorVisionDeficiency int
+
+const (
+	Protanopia ColorVisionDeficiency = iota
+	Deuteranopia
+	Tritanopia
+	Achromatopsia
+)
+
+// Machado, Oliveira and Fernandes (2009) simulation matrices at full severity, applied to linear RGB
+var deficiencyMatrices = map[ColorVisionDeficiency][3][3]float64{
+	Protanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	Deuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	Tritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+	Achromatopsia: {
+		{0.2126, 0.7152, 0.0722},
+		{0.2126, 0.7152, 0.0722},
+		{0.2126, 0.7152, 0.0722},
+	},
+}
+
+// Simulates how a color appears to someone with the given color vision deficiency
+func (pc *PaletteCalculator) SimulateColorVisionDeficiency(c *Color, deficiency ColorVisionDeficiency) *Color {
+	m, ok := deficiencyMatrices[deficiency]
+	if !ok {
+		return &Color{Red: c.Red, Green: c.Green, Blue: c.Blue, Hex: c.Hex}
+	}
+
+	rgb := []float64{linearize(c.Red / RGBMax), linearize(c.Green / RGBMax), linearize(c.Blue / RGBMax)}
+	return pc.linearRGBToColor(
+		m[RED][RED]*rgb[RED]+m[RED][GREEN]*rgb[GREEN]+m[RED][BLUE]*rgb[BLUE],
+		m[GREEN][RED]*rgb[RED]+m[GREEN][GREEN]*rgb[GREEN]+m[GREEN][BLUE]*rgb[BLUE],
+		m[BLUE][RED]*rgb[RED]+m[BLUE][GREEN]*rgb[GREEN]+m[BLUE][BLUE]*rgb[BLUE],
+	)
+}
+
+// Simulates how every color of a palette appears to someone with the given color vision deficiency
+func (pc *PaletteCalculator) SimulateColorVisionDeficiencyPalette(colors []Color, deficiency ColorVisionDeficiency) []Color {
+	var simulated []Color
+	for i := range colors {
+		simulated = append(simulated, *pc.SimulateColorVisionDeficiency(&colors[i], deficiency))
+	}
+
+	return simulated
+}
@@ -0,0 +1,51 @@
+package palettecalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSimulateColorVisionDeficiencyPalette(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		deficiency  ColorVisionDeficiency
+		expectedRGB []Color
+	}{
+		{
+			name:        "should simulate protanopia",
+			deficiency:  Protanopia,
+			expectedRGB: []Color{{86, 95, 120, "565f78"}, {66, 58, 21, "423a15"}, {255, 255, 255, "ffffff"}},
+		},
+		{
+			name:        "should simulate deuteranopia",
+			deficiency:  Deuteranopia,
+			expectedRGB: []Color{{73, 86, 119, "495677"}, {84, 75, 22, "544b16"}, {255, 255, 255, "ffffff"}},
+		},
+		{
+			name:        "should simulate tritanopia",
+			deficiency:  Tritanopia,
+			expectedRGB: []Color{{0, 105, 105, "006969"}, {131, 28, 41, "831c29"}, {255, 255, 255, "ffffff"}},
+		},
+		{
+			name:        "should simulate achromatopsia",
+			deficiency:  Achromatopsia,
+			expectedRGB: []Color{{90, 90, 90, "5a5a5a"}, {68, 68, 68, "444444"}, {255, 255, 255, "ffffff"}},
+		},
+		{
+			name:        "should return colors unchanged for unknown deficiency",
+			deficiency:  ColorVisionDeficiency(-1),
+			expectedRGB: []Color{{Red, Green, Blue, Hex}, {119, 45, 24, "772d18"}, {255, 255, 255, "ffffff"}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			colors := []Color{{Red, Green, Blue, Hex}, {119, 45, 24, "772d18"}, {255, 255, 255, "ffffff"}}
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedRGB := paletteCalculator.SimulateColorVisionDeficiencyPalette(colors, test.deficiency)
+
+			if !reflect.DeepEqual(test.expectedRGB, returnedRGB) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedRGB, returnedRGB)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package palettecalculator
+
+import "sort"
+
+// Returns a copy of the palette without colors within threshold CIEDE2000 of a heavier color. Each dropped
+// color's weight is added to the color it duplicates, colors keep their original order
+func (pc *PaletteCalculator) DedupePalette(p *Palette, threshold float64) *Palette {
+	indices := make([]int, len(p.Colors))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool { return p.weight(indices[i]) > p.weight(indices[j]) })
+
+	representative := make([]int, len(p.Colors))
+	var kept []int
+	for _, i := range indices {
+		representative[i] = i
+		for _, k := range kept {
+			if pc.DistanceDeltaE(&p.Colors[i], &p.Colors[k], CIEDE2000) <= threshold {
+				representative[i] = k
+				break
+			}
+		}
+		if representative[i] == i {
+			kept = append(kept, i)
+		}
+	}
+
+	weights := make([]float64, len(p.Colors))
+	for i := range p.Colors {
+		weights[representative[i]] += p.weight(i)
+	}
+
+	deduped := &Palette{Name: p.Name}
+	for i := range p.Colors {
+		if representative[i] != i {
+			continue
+		}
+		deduped.Colors = append(deduped.Colors, p.Colors[i])
+		if p.Weights != nil {
+			deduped.Weights = append(deduped.Weights, weights[i])
+		}
+	}
+
+	return deduped
+}
@@ -0,0 +1,49 @@
+package palettecalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDedupePalette(t *testing.T) {
+	teal := Color{Red, Green, Blue, Hex}
+	nearTeal := Color{26, 100, 120, "1a6478"}
+	brown := Color{119, 45, 24, "772d18"}
+	nearBrown := Color{120, 47, 24, "782f18"}
+
+	for _, test := range []struct {
+		name            string
+		palette         *Palette
+		threshold       float64
+		expectedPalette *Palette
+	}{
+		{
+			name:            "should keep heaviest representative and combine weights",
+			palette:         &Palette{Name: "merged", Colors: []Color{teal, brown, nearTeal, nearBrown}, Weights: []float64{.1, .2, .4, .3}},
+			threshold:       2,
+			expectedPalette: &Palette{Name: "merged", Colors: []Color{nearTeal, nearBrown}, Weights: []float64{.5, .5}},
+		},
+		{
+			name:            "should keep first representative when palette is unweighted",
+			palette:         &Palette{Colors: []Color{teal, brown, nearTeal, nearBrown}},
+			threshold:       2,
+			expectedPalette: &Palette{Colors: []Color{teal, brown}},
+		},
+		{
+			name:            "should keep colors further apart than threshold",
+			palette:         &Palette{Colors: []Color{teal, brown, nearTeal, nearBrown}},
+			threshold:       .5,
+			expectedPalette: &Palette{Colors: []Color{teal, brown, nearTeal, nearBrown}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			pc := &PaletteCalculator{}
+
+			returnedPalette := pc.DedupePalette(test.palette, test.threshold)
+
+			if !reflect.DeepEqual(test.expectedPalette, returnedPalette) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedPalette, returnedPalette)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package palettecalculator
+
+import "math"
+
+// Formula used to measure perceptual difference between two colors
+type DeltaEMethod int
+
+const (
+	// Euclidean distance in LAB
+	CIE76 DeltaEMethod = iota
+	// CIE94 with graphic arts weights
+	CIE94
+	// CIEDE2000, the most perceptually accurate
+	CIEDE2000
+)
+
+// Calculates the Delta E between two colors with method. Around 1 is the smallest difference most people notice
+func (pc *PaletteCalculator) DistanceDeltaE(a *Color, b *Color, method DeltaEMethod) float64 {
+	labA, labB := pc.ConvertRGBToLAB(a), pc.ConvertRGBToLAB(b)
+
+	switch method {
+	case CIE94:
+		return pc.deltaE94(labA, labB)
+	case CIEDE2000:
+		return pc.deltaE2000(labA, labB)
+	}
+	return pc.deltaE76LAB(labA, labB)
+}
+
+// CIE76 Delta E of two colors
+func (pc *PaletteCalculator) deltaE76(a *Color, b *Color) float64 {
+	return pc.deltaE76LAB(pc.ConvertRGBToLAB(a), pc.ConvertRGBToLAB(b))
+}
+
+func (pc *PaletteCalculator) deltaE76LAB(a *LAB, b *LAB) float64 {
+	return math.Sqrt((a.L-b.L)*(a.L-b.L) + (a.A-b.A)*(a.A-b.A) + (a.B-b.B)*(a.B-b.B))
+}
+
+func (pc *PaletteCalculator) deltaE94(a *LAB, b *LAB) float64 {
+	const k1 = 0.045
+	const k2 = 0.015
+
+	dl := a.L - b.L
+	c1 := math.Hypot(a.A, a.B)
+	c2 := math.Hypot(b.A, b.B)
+	dc := c1 - c2
+	da := a.A - b.A
+	db := a.B - b.B
+	dh := math.Sqrt(math.Max(0, da*da+db*db-dc*dc))
+
+	sc := 1 + k1*c1
+	sh := 1 + k2*c1
+
+	return math.Sqrt(dl*dl + (dc/sc)*(dc/sc) + (dh/sh)*(dh/sh))
+}
+
+func (pc *PaletteCalculator) deltaE2000(a *LAB, b *LAB) float64 {
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	deg := func(rad float64) float64 { return rad * 180 / math.Pi }
+	pow25to7 := math.Pow(25, 7)
+
+	// adjust a* for chroma so neutral colors are handled consistently
+	cBar := (math.Hypot(a.A, a.B) + math.Hypot(b.A, b.B)) / 2
+	g := 0.5 * (1 - math.Sqrt(math.Pow(cBar, 7)/(math.Pow(cBar, 7)+pow25to7)))
+	a1, a2 := a.A*(1+g), b.A*(1+g)
+	c1, c2 := math.Hypot(a1, a.B), math.Hypot(a2, b.B)
+
+	h1 := 0.0
+	if a1 != 0 || a.B != 0 {
+		h1 = math.Mod(deg(math.Atan2(a.B, a1))+360, 360)
+	}
+	h2 := 0.0
+	if a2 != 0 || b.B != 0 {
+		h2 = math.Mod(deg(math.Atan2(b.B, a2))+360, 360)
+	}
+
+	dl := b.L - a.L
+	dc := c2 - c1
+	dh := 0.0
+	if c1*c2 != 0 {
+		dh = h2 - h1
+		if dh > 180 {
+			dh -= 360
+		} else if dh < -180 {
+			dh += 360
+		}
+	}
+	dH := 2 * math.Sqrt(c1*c2) * math.Sin(rad(dh/2))
+
+	lBar := (a.L + b.L) / 2
+	cBarPrime := (c1 + c2) / 2
+	hBar := h1 + h2
+	if c1*c2 != 0 {
+		switch {
+		case math.Abs(h1-h2) <= 180:
+			hBar = (h1 + h2) / 2
+		case h1+h2 < 360:
+			hBar = (h1 + h2 + 360) / 2
+		default:
+			hBar = (h1 + h2 - 360) / 2
+		}
+	}
+
+	t := 1 - 0.17*math.Cos(rad(hBar-30)) + 0.24*math.Cos(rad(2*hBar)) + 0.32*math.Cos(rad(3*hBar+6)) - 0.20*math.Cos(rad(4*hBar-63))
+	dTheta := 30 * math.Exp(-((hBar-275)/25)*((hBar-275)/25))
+	rc := 2 * math.Sqrt(math.Pow(cBarPrime, 7)/(math.Pow(cBarPrime, 7)+pow25to7))
+	sl := 1 + (0.015*(lBar-50)*(lBar-50))/math.Sqrt(20+(lBar-50)*(lBar-50))
+	sc := 1 + 0.045*cBarPrime
+	sh := 1 + 0.015*cBarPrime*t
+	rt := -math.Sin(rad(2*dTheta)) * rc
+
+	return math.Sqrt((dl/sl)*(dl/sl) + (dc/sc)*(dc/sc) + (dH/sh)*(dH/sh) + rt*(dc/sc)*(dH/sh))
+}
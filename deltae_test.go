@@ -0,0 +1,52 @@
+package palettecalculator
+
+import (
+	"testing"
+)
+
+func TestDistanceDeltaE(t *testing.T) {
+	for _, test := range []struct {
+		name             string
+		method           DeltaEMethod
+		expectedDistance float64
+	}{
+		{name: "CIE76", method: CIE76, expectedDistance: 66.3982},
+		{name: "CIE94", method: CIE94, expectedDistance: 48.4676},
+		{name: "CIEDE2000", method: CIEDE2000, expectedDistance: 44.7805},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedDistance := roundFloat(paletteCalculator.DistanceDeltaE(&Color{Red, Green, Blue, Hex}, &Color{119, 45, 24, "772d18"}, test.method), 4)
+
+			if test.expectedDistance != returnedDistance {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedDistance, returnedDistance)
+			}
+		})
+	}
+}
+
+// Reference pairs from Sharma, Wu and Dalal, "The CIEDE2000 Color-Difference Formula"
+func TestDeltaE2000(t *testing.T) {
+	for _, test := range []struct {
+		name             string
+		a                *LAB
+		b                *LAB
+		expectedDistance float64
+	}{
+		{name: "pair 1", a: &LAB{50, 2.6772, -79.7751}, b: &LAB{50, 0, -82.7485}, expectedDistance: 2.0425},
+		{name: "pair 17", a: &LAB{50, 2.5, 0}, b: &LAB{73, 25, -18}, expectedDistance: 27.1492},
+		{name: "pair 34", a: &LAB{2.0776, 0.0795, -1.1350}, b: &LAB{0.9033, -0.0636, -0.5514}, expectedDistance: 0.9082},
+		{name: "identical", a: &LAB{50, 2.5, 0}, b: &LAB{50, 2.5, 0}, expectedDistance: 0},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedDistance := roundFloat(paletteCalculator.deltaE2000(test.a, test.b), 4)
+
+			if test.expectedDistance != returnedDistance {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedDistance, returnedDistance)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package palettecalculator
+
+// Upper HSL hue bound of each hue name, hues from the last bound to 360 are red again
+var hueNames = []struct {
+	maxHue float64
+	name   string
+}{
+	{15, "red"},
+	{40, "orange"},
+	{65, "yellow"},
+	{90, "lime"},
+	{160, "green"},
+	{200, "teal"},
+	{250, "blue"},
+	{290, "purple"},
+	{330, "magenta"},
+	{345, "pink"},
+	{360, "red"},
+}
+
+// Describes the color in plain words, e.g. "dark desaturated teal" or "vivid orange", for reports read by people
+// who don't read hex codes
+func (pc *PaletteCalculator) Describe(c *Color) string {
+	hsl := pc.ConvertRGBToHSL(c)
+
+	if hsl.Saturation < NeutralSaturation {
+		switch {
+		case hsl.Luminosity < .08:
+			return "black"
+		case hsl.Luminosity > .95:
+			return "white"
+		}
+		return pc.describeLightness(hsl.Luminosity, "gray")
+	}
+
+	name := "red"
+	for _, h := range hueNames {
+		if hsl.Hue < h.maxHue {
+			name = h.name
+			break
+		}
+	}
+	// dark oranges read as brown rather than orange
+	if name == "orange" && hsl.Luminosity < .4 {
+		return pc.describeSaturation(hsl.Saturation, hsl.Luminosity, "brown")
+	}
+
+	return pc.describeLightness(hsl.Luminosity, pc.describeSaturation(hsl.Saturation, hsl.Luminosity, name))
+}
+
+func (pc *PaletteCalculator) describeLightness(luminosity float64, name string) string {
+	switch {
+	case luminosity < .2:
+		return "very dark " + name
+	case luminosity < .4:
+		return "dark " + name
+	case luminosity > .85:
+		return "very light " + name
+	case luminosity > .65:
+		return "light " + name
+	}
+
+	return name
+}
+
+// Only mid lightness colors can look vivid, very light and very dark colors are named by lightness alone
+func (pc *PaletteCalculator) describeSaturation(saturation float64, luminosity float64, name string) string {
+	switch {
+	case saturation < .35:
+		return "desaturated " + name
+	case saturation > .8 && luminosity >= .3 && luminosity <= .7:
+		return "vivid " + name
+	}
+
+	return name
+}
@@ -0,0 +1,31 @@
+package palettecalculator
+
+import "testing"
+
+func TestDescribe(t *testing.T) {
+	for _, test := range []struct {
+		name                string
+		color               *Color
+		expectedDescription string
+	}{
+		{name: "should describe dominant color", color: &Color{Red, Green, Blue, Hex}, expectedDescription: "dark teal"},
+		{name: "should describe desaturated color", color: &Color{70, 100, 110, "46646e"}, expectedDescription: "dark desaturated teal"},
+		{name: "should describe vivid color", color: &Color{255, 128, 0, "ff8000"}, expectedDescription: "vivid orange"},
+		{name: "should describe dark orange as brown", color: &Color{120, 70, 20, "784614"}, expectedDescription: "brown"},
+		{name: "should describe very light color without vivid", color: &Color{255, 200, 220, "ffc8dc"}, expectedDescription: "very light pink"},
+		{name: "should describe very dark color", color: &Color{20, 10, 60, "140a3c"}, expectedDescription: "very dark purple"},
+		{name: "should describe black", color: &Color{0, 0, 0, "000000"}, expectedDescription: "black"},
+		{name: "should describe white", color: &Color{255, 255, 255, "ffffff"}, expectedDescription: "white"},
+		{name: "should describe gray", color: &Color{60, 60, 62, "3c3c3e"}, expectedDescription: "dark gray"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedDescription := paletteCalculator.Describe(test.color)
+
+			if test.expectedDescription != returnedDescription {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedDescription, returnedDescription)
+			}
+		})
+	}
+}
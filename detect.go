@@ -0,0 +1,121 @@
+package palettecalculator
+
+import (
+	"math"
+	"sort"
+)
+
+// Hue distance (in degrees) within which two colors count as the same hue
+const hueClusterTolerance = float64(15)
+
+// Mean hue error (in degrees) at which a scheme match drops to zero confidence
+const schemeMatchTolerance = float64(30)
+
+// Confidence below which a palette is reported as unstructured
+const minSchemeConfidence = .5
+
+// Scheme a palette most resembles and how confident the match is, in [0,1]
+type SchemeDetection struct {
+	Scheme     SchemeType `json:"scheme"`
+	Confidence float64    `json:"confidence"`
+}
+
+// Detects which scheme a palette was built from by matching its hues against each scheme's hue offsets
+func (pc *PaletteCalculator) DetectScheme(colors []Color) *SchemeDetection {
+	hues := pc.clusterHues(colors)
+
+	// a single hue (or none at all) can only be monochromatic
+	span := pc.hueSpan(hues)
+	if span <= hueClusterTolerance {
+		return &SchemeDetection{Scheme: Monochromatic, Confidence: roundFloat(1-span/(2*hueClusterTolerance), 2)}
+	}
+
+	best := &SchemeDetection{Scheme: Analogous, Confidence: math.Max(0, math.Min(1, 1-(span-60)/60))}
+	for _, scheme := range []SchemeType{Complimentary, SplitComplimentary, Triadic, Tetradic} {
+		if confidence := pc.matchScheme(hues, schemeOffsets[scheme]); confidence > best.Confidence {
+			best = &SchemeDetection{Scheme: scheme, Confidence: confidence}
+		}
+	}
+	best.Confidence = roundFloat(best.Confidence, 2)
+
+	if best.Confidence < minSchemeConfidence {
+		return &SchemeDetection{Scheme: Unstructured, Confidence: roundFloat(1-best.Confidence, 2)}
+	}
+	return best
+}
+
+// Scores how well hues fit offsets, trying each hue as the anchor of each offset
+func (pc *PaletteCalculator) matchScheme(hues []float64, offsets []float64) float64 {
+	best := float64(0)
+	for _, anchor := range hues {
+		for _, offset := range offsets {
+			rotation := anchor - offset
+
+			// every hue must sit near an offset and every offset must be covered by a hue
+			var errors []float64
+			for _, hue := range hues {
+				errors = append(errors, pc.nearestHueDistance(hue, offsets, rotation))
+			}
+			for _, o := range offsets {
+				errors = append(errors, pc.nearestHueDistance(o+rotation, hues, 0))
+			}
+
+			best = math.Max(best, 1-sumFloats(errors)/float64(len(errors))/schemeMatchTolerance)
+		}
+	}
+
+	return best
+}
+
+func (pc *PaletteCalculator) nearestHueDistance(hue float64, hues []float64, rotation float64) float64 {
+	nearest := float64(180)
+	for _, h := range hues {
+		nearest = math.Min(nearest, pc.hueDistance(hue, h+rotation))
+	}
+
+	return nearest
+}
+
+// Shortest angular distance between two hues
+func (pc *PaletteCalculator) hueDistance(a float64, b float64) float64 {
+	d := math.Mod(math.Abs(a-b), 360)
+	return math.Min(d, 360-d)
+}
+
+// Smallest arc of the wheel containing every hue
+func (pc *PaletteCalculator) hueSpan(hues []float64) float64 {
+	if len(hues) < 2 {
+		return 0
+	}
+
+	largestGap := 360 - hues[len(hues)-1] + hues[0]
+	for i := 1; i < len(hues); i++ {
+		largestGap = math.Max(largestGap, hues[i]-hues[i-1])
+	}
+	return 360 - largestGap
+}
+
+// Sorted hues of the chromatic colors, merging hues within hueClusterTolerance of each other
+func (pc *PaletteCalculator) clusterHues(colors []Color) []float64 {
+	var hues []float64
+	for i := range colors {
+		if hsl := pc.ConvertRGBToHSL(&colors[i]); hsl.Saturation >= NeutralSaturation {
+			hues = append(hues, hsl.Hue)
+		}
+	}
+	sort.Float64s(hues)
+
+	var clusters []float64
+	for _, hue := range hues {
+		if len(clusters) > 0 && hue-clusters[len(clusters)-1] <= hueClusterTolerance {
+			continue
+		}
+		clusters = append(clusters, hue)
+	}
+
+	// first and last cluster may meet across 0 degrees
+	if len(clusters) > 1 && pc.hueDistance(clusters[0], clusters[len(clusters)-1]) <= hueClusterTolerance {
+		clusters = clusters[:len(clusters)-1]
+	}
+	return clusters
+}
@@ -0,0 +1,66 @@
+package palettecalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectScheme(t *testing.T) {
+	dominantColor := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+	paletteCalculator := new(PaletteCalculator)
+
+	for _, test := range []struct {
+		name              string
+		colors            []Color
+		expectedDetection *SchemeDetection
+	}{
+		{
+			name:              "should detect complimentary scheme",
+			colors:            paletteCalculator.CalculateComplimentaryColorScheme(dominantColor),
+			expectedDetection: &SchemeDetection{Scheme: Complimentary, Confidence: 1},
+		},
+		{
+			name:              "should detect split complimentary scheme",
+			colors:            paletteCalculator.CalculateSplitComplimentaryColorScheme(dominantColor),
+			expectedDetection: &SchemeDetection{Scheme: SplitComplimentary, Confidence: 1},
+		},
+		{
+			name:              "should detect triadic scheme",
+			colors:            paletteCalculator.CalculateTriadicColorScheme(dominantColor),
+			expectedDetection: &SchemeDetection{Scheme: Triadic, Confidence: .98},
+		},
+		{
+			name:              "should detect tetradic scheme",
+			colors:            paletteCalculator.CalculateTetradicColorScheme(dominantColor),
+			expectedDetection: &SchemeDetection{Scheme: Tetradic, Confidence: .98},
+		},
+		{
+			name:              "should detect analogous scheme",
+			colors:            []Color{*dominantColor, {24, 80, 119, "185077"}, {24, 119, 110, "18776e"}},
+			expectedDetection: &SchemeDetection{Scheme: Analogous, Confidence: 1},
+		},
+		{
+			name:              "should detect monochromatic scheme",
+			colors:            []Color{*dominantColor, {50, 150, 180, "3296b4"}},
+			expectedDetection: &SchemeDetection{Scheme: Monochromatic, Confidence: 1},
+		},
+		{
+			name:              "should detect achromatic palette as monochromatic",
+			colors:            []Color{{10, 10, 10, "0a0a0a"}, {200, 200, 200, "c8c8c8"}},
+			expectedDetection: &SchemeDetection{Scheme: Monochromatic, Confidence: 1},
+		},
+		{
+			name:              "should detect unstructured palette",
+			colors:            []Color{*dominantColor, {119, 45, 24, "772d18"}, {60, 119, 24, "3c7718"}},
+			expectedDetection: &SchemeDetection{Scheme: Unstructured, Confidence: .51},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			returnedDetection := paletteCalculator.DetectScheme(test.colors)
+
+			if !reflect.DeepEqual(test.expectedDetection, returnedDetection) {
+				t.Errorf("expected: %+v\n returned %+v\n", test.expectedDetection, returnedDetection)
+			}
+		})
+	}
+}
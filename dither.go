@@ -0,0 +1,99 @@
+package palettecalculator
+
+import (
+	"errors"
+	"image"
+	"image/color"
+)
+
+// How quantization error is spread when remapping an image to a palette
+type Dither int
+
+const (
+	// Each pixel becomes its nearest palette color, flat areas band
+	NoDither Dither = iota
+	// Error diffusion to neighbouring pixels, smooth gradients with organic noise
+	FloydSteinberg
+	// 4x4 Bayer threshold pattern, a regular cross-hatch that compresses well and is stable between frames
+	OrderedDither
+)
+
+// Channel offset range of the Bayer pattern, roughly the gap between neighbouring colors of a small palette
+const orderedDitherSpread = 64
+
+var bayer4x4 = [4][4]float64{
+	{0, 8, 2, 10},
+	{12, 4, 14, 6},
+	{3, 11, 1, 9},
+	{15, 7, 13, 5},
+}
+
+// Remaps every pixel of img to a color of the palette using dither, e.g. to stylize an image with the palette
+// extracted from it. Alpha is ignored and the returned image is an *image.Paletted of the palette's colors
+func (pc *PaletteCalculator) ApplyPalette(img image.Image, p *Palette, dither Dither) (image.Image, error) {
+	if len(p.Colors) == 0 || len(p.Colors) > 256 {
+		return nil, errors.New("palette must have between 1 and 256 colors")
+	}
+
+	var clamped []Color
+	for i := range p.Colors {
+		clamped = append(clamped, *p.Colors[i].Clamp())
+	}
+
+	bounds := img.Bounds()
+	out := image.NewPaletted(bounds, p.ColorPalette())
+	width := bounds.Dx()
+
+	// Floyd–Steinberg error carried to the current and next row
+	current, next := make([][3]float64, width+2), make([][3]float64, width+2)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			px := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			rgb := [3]float64{float64(px.R), float64(px.G), float64(px.B)}
+
+			i := x - bounds.Min.X + 1
+			switch dither {
+			case FloydSteinberg:
+				for ch := range rgb {
+					rgb[ch] = clampChannel(rgb[ch] + current[i][ch])
+				}
+			case OrderedDither:
+				offset := (bayer4x4[y&3][x&3]/16 - .5) * orderedDitherSpread
+				for ch := range rgb {
+					rgb[ch] = clampChannel(rgb[ch] + offset)
+				}
+			}
+
+			index := pc.nearestPaletteIndex(rgb, clamped)
+			out.SetColorIndex(x, y, uint8(index))
+
+			if dither == FloydSteinberg {
+				chosen := clamped[index]
+				for ch, value := range []float64{chosen.Red, chosen.Green, chosen.Blue} {
+					e := rgb[ch] - value
+					current[i+1][ch] += e * 7 / 16
+					next[i-1][ch] += e * 3 / 16
+					next[i][ch] += e * 5 / 16
+					next[i+1][ch] += e * 1 / 16
+				}
+			}
+		}
+		current, next = next, make([][3]float64, width+2)
+	}
+
+	return out, nil
+}
+
+// Index of the palette color closest to rgb by squared sRGB distance
+func (pc *PaletteCalculator) nearestPaletteIndex(rgb [3]float64, colors []Color) int {
+	nearest, nearestDistance := 0, float64(-1)
+	for i := range colors {
+		dr, dg, db := rgb[RED]-colors[i].Red, rgb[GREEN]-colors[i].Green, rgb[BLUE]-colors[i].Blue
+		if distance := dr*dr + dg*dg + db*db; nearestDistance < 0 || distance < nearestDistance {
+			nearest, nearestDistance = i, distance
+		}
+	}
+
+	return nearest
+}
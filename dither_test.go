@@ -0,0 +1,70 @@
+package palettecalculator
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestApplyPalette(t *testing.T) {
+	for _, test := range []struct {
+		name           string
+		palette        *Palette
+		dither         Dither
+		expectedPixels []uint8
+		expectedErr    error
+	}{
+		{
+			name:           "should map pixels to nearest color without dithering",
+			palette:        &Palette{Colors: []Color{{0, 0, 0, "000000"}, {255, 255, 255, "ffffff"}}},
+			dither:         NoDither,
+			expectedPixels: []uint8{0, 0, 0, 0, 1, 1, 1, 1, 0, 0, 0, 1, 1, 1, 1, 1},
+		},
+		{
+			name:           "should diffuse error with Floyd-Steinberg",
+			palette:        &Palette{Colors: []Color{{0, 0, 0, "000000"}, {255, 255, 255, "ffffff"}}},
+			dither:         FloydSteinberg,
+			expectedPixels: []uint8{0, 0, 0, 1, 0, 1, 1, 1, 0, 0, 0, 1, 0, 1, 1, 1},
+		},
+		{
+			name:           "should apply ordered dithering",
+			palette:        &Palette{Colors: []Color{{0, 0, 0, "000000"}, {255, 255, 255, "ffffff"}}},
+			dither:         OrderedDither,
+			expectedPixels: []uint8{0, 0, 0, 0, 0, 1, 1, 1, 0, 0, 0, 0, 1, 1, 1, 1},
+		},
+		{
+			name:        "error occurs for empty palette",
+			palette:     &Palette{},
+			dither:      FloydSteinberg,
+			expectedErr: errors.New("palette must have between 1 and 256 colors"),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			// gray ramp from black to white, two rows high
+			img := image.NewRGBA(image.Rect(0, 0, 8, 2))
+			for x := 0; x < 8; x++ {
+				for y := 0; y < 2; y++ {
+					v := uint8(x * 255 / 7)
+					img.Set(x, y, color.RGBA{R: v, G: v, B: v, A: 255})
+				}
+			}
+			img.Set(3, 1, color.RGBA{R: 128, G: 128, B: 128, A: 255})
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedImg, err := paletteCalculator.ApplyPalette(img, test.palette, test.dither)
+
+			if test.expectedPixels != nil {
+				paletted, ok := returnedImg.(*image.Paletted)
+				if !ok || !reflect.DeepEqual(test.expectedPixels, paletted.Pix) {
+					t.Errorf("expected: %v\n returned: %v\n", test.expectedPixels, returnedImg)
+				}
+			}
+
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package palettecalculator
+
+import (
+	"image"
+	"image/color"
+)
+
+// Maps every pixel of img between two colors by its lightness, shadows become shadow and highlights become
+// highlight, interpolating through space. Alpha is kept
+func (pc *PaletteCalculator) Duotone(img image.Image, shadow *Color, highlight *Color, space InterpolationSpace) image.Image {
+	// gradient map from gray level to duotone color, and sRGB to linear lookup for the gray level
+	var ramp [256]color.NRGBA
+	var linear [256]float64
+	for i := range ramp {
+		c := pc.Interpolate(shadow, highlight, float64(i)/RGBMax, space)
+		ramp[i] = color.NRGBA{R: uint8(c.Red), G: uint8(c.Green), B: uint8(c.Blue)}
+		linear[i] = linearize(float64(i) / RGBMax)
+	}
+
+	bounds := img.Bounds()
+	out := image.NewNRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			px := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+
+			// gray level with the pixel's WCAG relative luminance, as in LuminosityGrayscale
+			luminance := 0.2126*linear[px.R] + 0.7152*linear[px.G] + 0.0722*linear[px.B]
+			gray := int(clampChannel(delinearize(luminance)*RGBMax + .5))
+
+			c := ramp[gray]
+			c.A = px.A
+			out.SetNRGBA(x, y, c)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,36 @@
+package palettecalculator
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestDuotone(t *testing.T) {
+	for _, test := range []struct {
+		name           string
+		space          InterpolationSpace
+		expectedPixels []uint8
+	}{
+		{name: "should map pixels between colors in sRGB", space: InterpolateSRGB, expectedPixels: []uint8{24, 98, 119, 255, 250, 220, 120, 255, 137, 159, 120, 128, 115, 147, 119, 255}},
+		{name: "should map pixels between colors in OKLCH", space: InterpolateOKLCH, expectedPixels: []uint8{24, 98, 119, 255, 250, 220, 120, 255, 96, 172, 128, 128, 70, 159, 130, 255}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			// black, white, translucent gray and red
+			img := image.NewNRGBA(image.Rect(0, 0, 4, 1))
+			img.Set(0, 0, color.NRGBA{R: 0, G: 0, B: 0, A: 255})
+			img.Set(1, 0, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+			img.Set(2, 0, color.NRGBA{R: 128, G: 128, B: 128, A: 128})
+			img.Set(3, 0, color.NRGBA{R: 200, G: 30, B: 60, A: 255})
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedImg := paletteCalculator.Duotone(img, &Color{Red, Green, Blue, Hex}, &Color{250, 220, 120, "fadc78"}, test.space)
+
+			nrgba, ok := returnedImg.(*image.NRGBA)
+			if !ok || !reflect.DeepEqual(test.expectedPixels, nrgba.Pix) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedPixels, returnedImg)
+			}
+		})
+	}
+}
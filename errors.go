@@ -0,0 +1,91 @@
+package palettecalculator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Failures callers can check for with errors.Is. The errors returned wrap their cause, so errors.Is and errors.As
+// also match it, e.g. fs.ErrNotExist from ErrFileOpen
+var (
+	// An image file could not be opened, returned as a *FileError
+	ErrFileOpen = errors.New("unable to open image file")
+	// An image could not be decoded, returned as a *DecodeError
+	ErrDecode = errors.New("unable to decode image")
+	// The Vision API quota or rate limit was exceeded, returned as a *VisionError. Retry later
+	ErrVisionQuota = errors.New("vision api quota exceeded")
+	// The Vision API found no dominant colors in the image
+	ErrNoDominantColors = errors.New("vision api found no dominant colors")
+	// A color could not be parsed, returned as a *ColorError
+	ErrInvalidColor = errors.New("invalid color")
+	// Scheme colors could not be separated enough for color blind viewers, returned as a *ColorBlindSafeError
+	ErrNotColorBlindSafe = errors.New("scheme is not color blind safe")
+)
+
+// Failure to open the image File
+type FileError struct {
+	File string
+	Err  error
+}
+
+func (e *FileError) Error() string {
+	return fmt.Sprintf("unable to open image file %s: %v", e.File, e.Err)
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+func (e *FileError) Is(target error) bool {
+	return target == ErrFileOpen
+}
+
+// Failure to decode an image, e.g. an unknown format or truncated data
+type DecodeError struct {
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("unable to decode image: %v", e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+func (e *DecodeError) Is(target error) bool {
+	return target == ErrDecode
+}
+
+// Failure to parse Input as a color of Syntax, e.g. hex, css or json
+type ColorError struct {
+	Syntax string
+	Input  string
+	Err    error
+}
+
+func (e *ColorError) Error() string {
+	return fmt.Sprintf("invalid %s color %q: %v", e.Syntax, e.Input, e.Err)
+}
+
+func (e *ColorError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ColorError) Is(target error) bool {
+	return target == ErrInvalidColor
+}
+
+// Failure of a scheme to keep its colors MinDeltaE apart under the common deficiencies, DeltaE is the closest pair
+type ColorBlindSafeError struct {
+	MinDeltaE float64
+	DeltaE    float64
+}
+
+func (e *ColorBlindSafeError) Error() string {
+	return fmt.Sprintf("scheme is not color blind safe: closest colors are %.2f apart, below %.2f", e.DeltaE, e.MinDeltaE)
+}
+
+func (e *ColorBlindSafeError) Is(target error) bool {
+	return target == ErrNotColorBlindSafe
+}
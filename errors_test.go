@@ -0,0 +1,61 @@
+package palettecalculator
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestErrorsIs(t *testing.T) {
+	pc := &PaletteCalculator{Opener: new(FileOpener)}
+	_, openErr := pc.CalculatePaletteFromIndexedFile(filepath.Join(t.TempDir(), "missing.png"))
+
+	notImage := filepath.Join(t.TempDir(), "notes.png")
+	if err := os.WriteFile(notImage, []byte("not an image"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, decodeErr := pc.CalculatePaletteFromIndexedFile(notImage)
+
+	_, hexErr := ParseHex("#12345")
+	_, cssErr := ParseCSS("rgb(a,b,c)")
+	var c Color
+	jsonErr := c.UnmarshalJSON([]byte(`{}`))
+
+	for _, test := range []struct {
+		name    string
+		err     error
+		targets []error
+		notErr  error
+	}{
+		{name: "missing file", err: openErr, targets: []error{ErrFileOpen, fs.ErrNotExist}, notErr: ErrDecode},
+		{name: "undecodable file", err: decodeErr, targets: []error{ErrDecode}, notErr: ErrFileOpen},
+		{name: "invalid hex", err: hexErr, targets: []error{ErrInvalidColor}, notErr: ErrDecode},
+		{name: "invalid css", err: cssErr, targets: []error{ErrInvalidColor}, notErr: ErrDecode},
+		{name: "invalid json", err: jsonErr, targets: []error{ErrInvalidColor}, notErr: ErrDecode},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			for _, target := range test.targets {
+				if !errors.Is(test.err, target) {
+					t.Errorf("expected: %v\n returned: %v\n", target, test.err)
+				}
+			}
+			if errors.Is(test.err, test.notErr) {
+				t.Errorf("expected: not %v\n returned: %v\n", test.notErr, test.err)
+			}
+		})
+	}
+}
+
+func TestErrorsAs(t *testing.T) {
+	_, err := ParseCSS("nope")
+
+	var colorErr *ColorError
+	if !errors.As(err, &colorErr) {
+		t.Fatalf("expected: %T\n returned: %T\n", colorErr, err)
+	}
+	if colorErr.Syntax != "css" || colorErr.Input != "nope" {
+		t.Errorf("expected: %v\n returned: %v\n", "css nope", colorErr.Syntax+" "+colorErr.Input)
+	}
+}
@@ -0,0 +1,92 @@
+package palettecalculator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Names the color at index i of a palette for exporters, e.g. "brand-primary". Names are used as given,
+// repeated names get a -2, -3, ... suffix
+type ColorNamer func(i int, c *Color) string
+
+// Names colors color-1, color-2, ... by position, the default when exporters are given a nil ColorNamer
+func IndexColorNamer(i int, c *Color) string {
+	return fmt.Sprintf("color-%d", i+1)
+}
+
+// Names colors after their nearest CSS named color, e.g. "darkslategray"
+func (pc *PaletteCalculator) NearestNamedColorNamer(i int, c *Color) string {
+	named, _ := pc.NearestNamedColor(c)
+	return named.Name
+}
+
+// Formats a palette as SCSS variables, e.g. "$color-1: #186277;"
+func (pc *PaletteCalculator) ExportSCSS(p *Palette, namer ColorNamer) string {
+	return pc.exportVariables(p, namer, "$")
+}
+
+// Formats a palette as LESS variables, e.g. "@color-1: #186277;"
+func (pc *PaletteCalculator) ExportLESS(p *Palette, namer ColorNamer) string {
+	return pc.exportVariables(p, namer, "@")
+}
+
+func (pc *PaletteCalculator) exportVariables(p *Palette, namer ColorNamer, prefix string) string {
+	var sb strings.Builder
+
+	if p.Name != "" {
+		sb.WriteString(fmt.Sprintf("// %s\n", p.Name))
+	}
+	names := pc.colorNames(p, namer)
+	for i := range p.Colors {
+		sb.WriteString(fmt.Sprintf("%s%s: %s;\n", prefix, names[i], cssHex(&p.Colors[i])))
+	}
+
+	return sb.String()
+}
+
+// Names every palette color with namer, suffixing repeats so each name is unique
+func (pc *PaletteCalculator) colorNames(p *Palette, namer ColorNamer) []string {
+	if namer == nil {
+		namer = IndexColorNamer
+	}
+
+	var names []string
+	seen := make(map[string]int)
+	for i := range p.Colors {
+		name := namer(i, &p.Colors[i])
+		seen[name]++
+		if seen[name] > 1 {
+			name = fmt.Sprintf("%s-%d", name, seen[name])
+		}
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// Six digit #rrggbb hex of a color, rounded and clamped to valid channels
+func cssHex(c *Color) string {
+	return new(PaletteCalculator).FormatHex(c.Clamp(), HexOptions{Prefix: true})
+}
+
+// Formats a palette as a Markdown table of swatch, nearest CSS name, hex, RGB, HSL and contrast against white and
+// black, for READMEs and design docs. Swatches are shields.io badges in the color
+func (pc *PaletteCalculator) ExportMarkdown(p *Palette) string {
+	var sb strings.Builder
+	white := &Color{Red: RGBMax, Green: RGBMax, Blue: RGBMax}
+	black := &Color{}
+
+	sb.WriteString("| Swatch | Name | Hex | RGB | HSL | Contrast vs white | Contrast vs black |\n")
+	sb.WriteString("| --- | --- | --- | --- | --- | --- | --- |\n")
+	for i := range p.Colors {
+		c := p.Colors[i].Clamp()
+		hex := cssHex(c)
+		named, _ := pc.NearestNamedColor(c)
+		hsl := pc.ConvertRGBToHSL(c)
+		sb.WriteString(fmt.Sprintf("| ![%s](https://img.shields.io/badge/-%%20-%s?style=flat-square) | %s | `%s` | rgb(%d, %d, %d) | hsl(%g, %g%%, %g%%) | %g:1 | %g:1 |\n",
+			hex, hex[1:], named.Name, hex, int(c.Red), int(c.Green), int(c.Blue), hsl.Hue, roundFloat(hsl.Saturation*100, 0), roundFloat(hsl.Luminosity*100, 0),
+			roundFloat(pc.ContrastRatio(c, white), 2), roundFloat(pc.ContrastRatio(c, black), 2)))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,58 @@
+package palettecalculator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportSCSS(t *testing.T) {
+	palette := &Palette{Name: "photo", Colors: []Color{{24, 98, 119, "186277"}, {14, 60, 73, "0e3c49"}}}
+	upper := func(i int, c *Color) string { return "brand-" + strings.ToUpper(c.Hex) }
+	tests := []struct {
+		name     string
+		palette  *Palette
+		namer    ColorNamer
+		expected string
+	}{
+		{"index names", palette, nil, "// photo\n$color-1: #186277;\n$color-2: #0e3c49;\n"},
+		{"custom names", palette, upper, "// photo\n$brand-186277: #186277;\n$brand-0E3C49: #0e3c49;\n"},
+		{"unnamed palette", &Palette{Colors: []Color{{255, 255, 255, "ffffff"}}}, nil, "$color-1: #ffffff;\n"},
+		{"empty palette", &Palette{}, nil, ""},
+	}
+	paletteCalculator := new(PaletteCalculator)
+
+	for _, test := range tests {
+		returned := paletteCalculator.ExportSCSS(test.palette, test.namer)
+
+		if test.expected != returned {
+			t.Errorf("%s expected: %v\n returned: %v\n", test.name, test.expected, returned)
+		}
+	}
+}
+
+func TestExportLESS(t *testing.T) {
+	paletteCalculator := new(PaletteCalculator)
+	palette := &Palette{Colors: []Color{{24, 98, 119, "186277"}, {14, 60, 73, "0e3c49"}, {255, 255, 255, "ffffff"}}}
+	expected := "@darkslategray: #186277;\n@darkslategray-2: #0e3c49;\n@white: #ffffff;\n"
+
+	returned := paletteCalculator.ExportLESS(palette, paletteCalculator.NearestNamedColorNamer)
+
+	if expected != returned {
+		t.Errorf("expected: %v\n returned: %v\n", expected, returned)
+	}
+}
+
+func TestExportMarkdown(t *testing.T) {
+	palette := &Palette{Colors: []Color{{24, 98, 119, "186277"}, {255, 255, 255, "ffffff"}}}
+	expected := "| Swatch | Name | Hex | RGB | HSL | Contrast vs white | Contrast vs black |\n" +
+		"| --- | --- | --- | --- | --- | --- | --- |\n" +
+		"| ![#186277](https://img.shields.io/badge/-%20-186277?style=flat-square) | darkslategray | `#186277` | rgb(24, 98, 119) | hsl(193, 66%, 28%) | 6.88:1 | 3.05:1 |\n" +
+		"| ![#ffffff](https://img.shields.io/badge/-%20-ffffff?style=flat-square) | white | `#ffffff` | rgb(255, 255, 255) | hsl(0, 0%, 100%) | 1:1 | 21:1 |\n"
+	paletteCalculator := new(PaletteCalculator)
+
+	returned := paletteCalculator.ExportMarkdown(palette)
+
+	if expected != returned {
+		t.Errorf("expected: %v\n returned: %v\n", expected, returned)
+	}
+}
@@ -0,0 +1,41 @@
+package palettecalculator
+
+import (
+	"image"
+	"image/color"
+	"log/slog"
+	"time"
+)
+
+// Extracts a palette of at most k colors from img locally, without the Vision API. Colors are ordered and weighted
+// by their share of opaque pixels
+func (pc *PaletteCalculator) ExtractPalette(img image.Image, k int) (*Palette, error) {
+	start := time.Now()
+	if paletted, ok := img.(*image.Paletted); ok && len(paletted.Palette) <= k {
+		p := pc.PaletteFromIndexedImage(paletted)
+		pc.logPalette(p, "indexed", start, "k", k)
+		return p, nil
+	}
+	bounds := img.Bounds()
+	pc.log(slog.LevelDebug, "quantizing image", "backend", "local", "width", bounds.Dx(), "height", bounds.Dy(), "k", k)
+
+	colors, err := pc.QuantizeImage(img, k)
+	if err != nil {
+		return nil, err
+	}
+
+	// count the opaque pixels each quantized color stands for
+	counts := make([]int, len(colors))
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if px := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA); px.A > 0 {
+				counts[colors.Index(px)]++
+			}
+		}
+	}
+
+	p := pc.weightedPalette(colors, counts)
+	pc.logPalette(p, "local", start, "k", k)
+
+	return p, nil
+}
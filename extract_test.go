@@ -0,0 +1,26 @@
+package palettecalculator
+
+import (
+	"image"
+	stdcolor "image/color"
+	"reflect"
+	"testing"
+)
+
+func TestExtractPalette(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 1))
+	for x, c := range []stdcolor.NRGBA{{24, 98, 119, 255}, {24, 98, 119, 255}, {24, 98, 119, 255}, {255, 255, 255, 255}} {
+		img.SetNRGBA(x, 0, c)
+	}
+	paletteCalculator := new(PaletteCalculator)
+	expectedPalette := &Palette{Colors: []Color{{24, 98, 119, "186277"}, {255, 255, 255, "ffffff"}}, Weights: []float64{.75, .25}}
+
+	returnedPalette, err := paletteCalculator.ExtractPalette(img, 5)
+
+	if err != nil {
+		t.Errorf("expected error: %v returned error: %v", nil, err)
+	}
+	if !reflect.DeepEqual(expectedPalette, returnedPalette) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedPalette, returnedPalette)
+	}
+}
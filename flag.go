@@ -0,0 +1,17 @@
+package palettecalculator
+
+// Sets the color from a CSS color, e.g. "#186277" or "teal", so a *Color can be a flag.Value
+func (c *Color) Set(s string) error {
+	parsed, err := ParseCSS(s)
+	if err != nil {
+		return err
+	}
+	*c = *parsed
+
+	return nil
+}
+
+// Type name of the flag value, for pflag
+func (c *Color) Type() string {
+	return "color"
+}
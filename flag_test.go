@@ -0,0 +1,61 @@
+package palettecalculator
+
+import (
+	"flag"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestColorFlag(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		args        []string
+		expected    Color
+		expectedErr string
+	}{
+		{
+			name:     "should parse hex",
+			args:     []string{"-seed-color", "#186277"},
+			expected: Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+		},
+		{
+			name:     "should parse css",
+			args:     []string{"-seed-color", "rgb(24, 98, 119)"},
+			expected: Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+		},
+		{
+			name:     "should keep default when unset",
+			args:     nil,
+			expected: Color{Red: 255, Green: 255, Blue: 255, Hex: "ffffff"},
+		},
+		{
+			name:        "should report invalid colors",
+			args:        []string{"-seed-color", "notacolor"},
+			expected:    Color{Red: 255, Green: 255, Blue: 255, Hex: "ffffff"},
+			expectedErr: `invalid value "notacolor" for flag -seed-color: invalid css color "notacolor": unknown color name or syntax`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			seed := MustColor(255, 255, 255)
+			fs.Var(&seed, "seed-color", "seed `color`")
+
+			err := fs.Parse(test.args)
+
+			if test.expectedErr == "" && err != nil || test.expectedErr != "" && (err == nil || err.Error() != test.expectedErr) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedErr, err)
+			}
+			if !reflect.DeepEqual(seed, test.expected) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expected, seed)
+			}
+		})
+	}
+}
+
+func TestColorType(t *testing.T) {
+	if returned := new(Color).Type(); returned != "color" {
+		t.Errorf("expected: %v\n returned: %v\n", "color", returned)
+	}
+}
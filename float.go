@@ -0,0 +1,60 @@
+package palettecalculator
+
+import "math"
+
+// Rounds x to places decimals, half away from zero. Whole numbers are returned unchanged and negative zero is
+// returned as zero
+func roundFloat(x float64, places int) float64 {
+	if x == 0 {
+		return 0
+	}
+	if places >= 0 && x == math.Trunc(x) {
+		return x
+	}
+
+	pow := math.Pow10(places)
+	scaled := x * pow
+	if math.IsInf(scaled, 0) {
+		return x
+	}
+	if x < 0 {
+		x = math.Ceil(scaled - .5)
+	} else {
+		x = math.Floor(scaled + .5)
+	}
+	if x == 0 {
+		return 0
+	}
+
+	return x / pow
+}
+
+// Smallest of values, which must not be empty
+func minFloat(values []float64) float64 {
+	min := values[0]
+	for _, v := range values[1:] {
+		if v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+// Largest of values, which must not be empty
+func maxFloat(values []float64) float64 {
+	max := values[0]
+	for _, v := range values[1:] {
+		if v > max {
+			max = v
+		}
+	}
+	return max
+}
+
+func sumFloats(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum
+}
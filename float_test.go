@@ -0,0 +1,42 @@
+package palettecalculator
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRoundFloat(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		value    float64
+		places   int
+		expected float64
+	}{
+		{name: "should round half away from zero", value: .125, places: 2, expected: .13},
+		{name: "should round negative half away from zero", value: -2.5, places: 0, expected: -3},
+		{name: "should keep whole numbers", value: 193, places: 2, expected: 193},
+		{name: "should round to tens with negative places", value: 1234, places: -1, expected: 1230},
+		{name: "should return zero for negative zero", value: -.001, places: 2, expected: 0},
+		{name: "should keep infinity", value: math.Inf(1), places: 2, expected: math.Inf(1)},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			returned := roundFloat(test.value, test.places)
+
+			if returned != test.expected || math.Signbit(returned) != math.Signbit(test.expected) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expected, returned)
+			}
+		})
+	}
+}
+
+func TestMinMaxSumFloats(t *testing.T) {
+	values := []float64{24, 119, 98}
+
+	returned := []float64{minFloat(values), maxFloat(values), sumFloats(values)}
+	expected := []float64{24, 119, 241}
+	for i := range expected {
+		if returned[i] != expected[i] {
+			t.Errorf("expected: %v\n returned: %v\n", expected, returned)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package palettecalculator
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Formats c as CSS rgb(), e.g. "rgb(24, 98, 119)"
+func (c Color) String() string {
+	return "rgb(" + formatNumber(c.Red) + ", " + formatNumber(c.Green) + ", " + formatNumber(c.Blue) + ")"
+}
+
+// Formats c for fmt: %x and %X write its hex, with "#" under the # flag, %v and %s write rgb() and %+v its fields
+func (c Color) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'x', 'X':
+		opts := HexOptions{Prefix: f.Flag('#'), Uppercase: verb == 'X'}
+		fmt.Fprint(f, new(PaletteCalculator).FormatHex(&c, opts))
+	case 'v':
+		if f.Flag('+') {
+			fmt.Fprintf(f, "{Red:%v Green:%v Blue:%v Hex:%v}", c.Red, c.Green, c.Blue, c.Hex)
+			return
+		}
+		fmt.Fprint(f, c.String())
+	case 's':
+		fmt.Fprint(f, c.String())
+	case 'q':
+		fmt.Fprint(f, strconv.Quote(c.String()))
+	default:
+		fmt.Fprintf(f, "%%!%c(Color=%s)", verb, c.String())
+	}
+}
+
+// Formats h as CSS hsl(), e.g. "hsl(193, 66%, 28%)"
+func (h HSL) String() string {
+	return "hsl(" + formatNumber(h.Hue) + ", " + formatNumber(h.Saturation*100) + "%, " + formatNumber(h.Luminosity*100) + "%)"
+}
+
+// Formats p as its name and colors' hex with their weights, e.g. "photo: #186277 75%, #0e3c49 25%"
+func (p Palette) String() string {
+	var sb strings.Builder
+	if p.Name != "" {
+		sb.WriteString(p.Name + ": ")
+	}
+	for i := range p.Colors {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "%#x", p.Colors[i])
+		if i < len(p.Weights) {
+			sb.WriteString(" " + formatNumber(p.Weights[i]*100) + "%")
+		}
+	}
+
+	return sb.String()
+}
+
+// Shortest decimal of n rounded to two places, e.g. "12.5" or "98"
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(math.Round(n*100)/100, 'f', -1, 64)
+}
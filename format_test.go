@@ -0,0 +1,42 @@
+package palettecalculator
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestColorFormat(t *testing.T) {
+	c := Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+
+	for _, test := range []struct {
+		format         string
+		value          interface{}
+		expectedString string
+	}{
+		{format: "%v", value: c, expectedString: "rgb(24, 98, 119)"},
+		{format: "%s", value: &c, expectedString: "rgb(24, 98, 119)"},
+		{format: "%x", value: c, expectedString: "186277"},
+		{format: "%#x", value: c, expectedString: "#186277"},
+		{format: "%X", value: Color{Red: 171, Green: 205, Blue: 239}, expectedString: "ABCDEF"},
+		{format: "%+v", value: c, expectedString: "{Red:24 Green:98 Blue:119 Hex:186277}"},
+		{format: "%q", value: c, expectedString: `"rgb(24, 98, 119)"`},
+		{format: "%d", value: c, expectedString: "%!d(Color=rgb(24, 98, 119))"},
+		{format: "%v", value: Color{Red: 12.5, Green: 200.004, Blue: 3}, expectedString: "rgb(12.5, 200, 3)"},
+		{format: "%v", value: []Color{c, {}}, expectedString: "[rgb(24, 98, 119) rgb(0, 0, 0)]"},
+		{format: "%v", value: HSL{Hue: hue, Saturation: saturation, Luminosity: luminosity}, expectedString: "hsl(193, 66%, 28%)"},
+		{
+			format:         "%v",
+			value:          &Palette{Name: "photo", Colors: []Color{c, {Red: 14, Green: 60, Blue: 73}}, Weights: []float64{.75, .25}},
+			expectedString: "photo: #186277 75%, #0e3c49 25%",
+		},
+		{format: "%s", value: Palette{Colors: []Color{c, {}}}, expectedString: "#186277, #000000"},
+	} {
+		t.Run(test.format+" "+test.expectedString, func(t *testing.T) {
+			returnedString := fmt.Sprintf(test.format, test.value)
+
+			if returnedString != test.expectedString {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedString, returnedString)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package palettecalculator
+
+import (
+	"math"
+)
+
+// How colors outside sRGB, e.g. wide-gamut LAB or OKLAB values, are brought into gamut
+type GamutMapping int
+
+const (
+	// Clamp each channel independently, fast but can shift hue
+	ClipGamut GamutMapping = iota
+	// Reduce chroma keeping lightness and hue until the color fits
+	CompressChroma
+)
+
+// Returns a copy of the color with channels rounded and clamped to [0,255], channels that are not a number become 0
+func (c *Color) Clamp() *Color {
+	return new(PaletteCalculator).Clamp(c)
+}
+
+// Clamps the color like Color.Clamp, rounding channels under the calculator's rounding policy
+func (pc *PaletteCalculator) Clamp(c *Color) *Color {
+	var channels []float64
+	for _, channel := range []float64{c.Red, c.Green, c.Blue} {
+		if math.IsNaN(channel) {
+			channel = 0
+		}
+		channels = append(channels, clampChannel(pc.round(channel, 0)))
+	}
+
+	r, g, b := channels[RED], channels[GREEN], channels[BLUE]
+	return &Color{Red: r, Green: g, Blue: b, Hex: hexString(r, g, b)}
+}
+
+// Reports whether every channel of the color is a number in [0,255]
+func (c *Color) IsValid() bool {
+	for _, channel := range []float64{c.Red, c.Green, c.Blue} {
+		if math.IsNaN(channel) || channel < 0 || channel > RGBMax {
+			return false
+		}
+	}
+
+	return true
+}
+
+func gamutMapping(mapping []GamutMapping) GamutMapping {
+	if len(mapping) == 0 {
+		return ClipGamut
+	}
+
+	return mapping[len(mapping)-1]
+}
+
+func clampChannel(channel float64) float64 {
+	return math.Max(0, math.Min(RGBMax, channel))
+}
+
+func clampUnit(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
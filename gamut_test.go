@@ -0,0 +1,48 @@
+package palettecalculator
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestClamp(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		color         *Color
+		expectedColor *Color
+	}{
+		{name: "should leave valid color unchanged", color: &Color{Red, Green, Blue, Hex}, expectedColor: &Color{Red, Green, Blue, Hex}},
+		{name: "should clamp and round out of range channels", color: &Color{-3, 260.4, 99.6, ""}, expectedColor: &Color{0, 255, 100, "00ff64"}},
+		{name: "should zero channels that are not a number", color: &Color{math.NaN(), 255, 0, ""}, expectedColor: &Color{0, 255, 0, "00ff00"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			returnedColor := test.color.Clamp()
+
+			if !reflect.DeepEqual(test.expectedColor, returnedColor) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedColor, returnedColor)
+			}
+		})
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		color         *Color
+		expectedValid bool
+	}{
+		{name: "should be valid in range", color: &Color{0, 255, 3.5, ""}, expectedValid: true},
+		{name: "should be invalid below range", color: &Color{-3, 2, 3, ""}, expectedValid: false},
+		{name: "should be invalid above range", color: &Color{3, 256, 3, ""}, expectedValid: false},
+		{name: "should be invalid when not a number", color: &Color{math.NaN(), 2, 3, ""}, expectedValid: false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			returnedValid := test.color.IsValid()
+
+			if test.expectedValid != returnedValid {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedValid, returnedValid)
+			}
+		})
+	}
+}
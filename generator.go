@@ -0,0 +1,43 @@
+package palettecalculator
+
+import "math"
+
+// Golden angle in degrees (360 / φ²), the hue step that keeps successive colors maximally distinct
+const GoldenAngle = float64(137.50776405003785)
+
+// Generator of an unbounded sequence of colors stepping hue by the golden angle from a dominant color
+type GoldenRatioColorGenerator struct {
+	pc    *PaletteCalculator
+	seed  Color
+	hsl   *HSL
+	count int
+}
+
+// Creates a golden ratio color generator seeded with the dominant color
+func (pc *PaletteCalculator) NewGoldenRatioColorGenerator(dc *Color) *GoldenRatioColorGenerator {
+	colors, hsl := pc.generateInitialRGBAndHSLForColor(dc)
+
+	return &GoldenRatioColorGenerator{pc: pc, seed: colors[0], hsl: hsl}
+}
+
+// Returns the next color in the sequence. The first call returns the dominant color itself
+func (g *GoldenRatioColorGenerator) Next() Color {
+	defer func() { g.count++ }()
+
+	if g.count == 0 {
+		return g.seed
+	}
+
+	transformedHSL := g.pc.transformHue(g.hsl, math.Mod(GoldenAngle*float64(g.count), 360))
+	return *g.pc.ConvertHSLToRGB(transformedHSL)
+}
+
+// Returns the next n colors in the sequence
+func (g *GoldenRatioColorGenerator) Take(n int) []Color {
+	var colors []Color
+	for i := 0; i < n; i++ {
+		colors = append(colors, g.Next())
+	}
+
+	return colors
+}
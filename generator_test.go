@@ -0,0 +1,38 @@
+package palettecalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGoldenRatioColorGeneratorNext(t *testing.T) {
+	dominantColor := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+	expectedRGB := []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {119, 24, 73, "771849"}, {45, 119, 24, "2d7718"}, {36, 24, 119, "241877"}}
+	paletteCalculator := new(PaletteCalculator)
+	generator := paletteCalculator.NewGoldenRatioColorGenerator(dominantColor)
+
+	var returnedRGB []Color
+	for i := 0; i < len(expectedRGB); i++ {
+		returnedRGB = append(returnedRGB, generator.Next())
+	}
+
+	if !reflect.DeepEqual(expectedRGB, returnedRGB) {
+		t.Errorf("expected: %v\n returned %v\n", expectedRGB, returnedRGB)
+	}
+
+}
+
+func TestGoldenRatioColorGeneratorTake(t *testing.T) {
+	dominantColor := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+	paletteCalculator := new(PaletteCalculator)
+	generator := paletteCalculator.NewGoldenRatioColorGenerator(dominantColor)
+	expectedRGB := []Color{{119, 24, 73, "771849"}, {45, 119, 24, "2d7718"}}
+
+	generator.Next()
+	returnedRGB := generator.Take(2)
+
+	if !reflect.DeepEqual(expectedRGB, returnedRGB) {
+		t.Errorf("expected: %v\n returned %v\n", expectedRGB, returnedRGB)
+	}
+
+}
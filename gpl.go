@@ -0,0 +1,71 @@
+package palettecalculator
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Writes the palette in GIMP's .gpl palette format, also read by Inkscape, Krita and Aseprite. Colors are labelled
+// by namer, a nil namer labels them color-1, color-2, ...
+func (pc *PaletteCalculator) WriteGPL(w io.Writer, p *Palette, namer ColorNamer) error {
+	var sb strings.Builder
+
+	sb.WriteString("GIMP Palette\n")
+	if p.Name != "" {
+		sb.WriteString(fmt.Sprintf("Name: %s\n", p.Name))
+	}
+	sb.WriteString("Columns: 0\n#\n")
+
+	names := pc.colorNames(p, namer)
+	for i := range p.Colors {
+		c := p.Colors[i].Clamp()
+		sb.WriteString(fmt.Sprintf("%3d %3d %3d\t%s\n", int(c.Red), int(c.Green), int(c.Blue), names[i]))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// Reads a GIMP .gpl palette. Color labels are dropped
+func LoadGPLPalette(r io.Reader) (*Palette, error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() || strings.TrimSpace(scanner.Text()) != "GIMP Palette" {
+		return nil, errors.New("invalid gpl file: missing GIMP Palette header")
+	}
+
+	p := &Palette{}
+	for line := 2; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, "Columns:"):
+			continue
+		case strings.HasPrefix(text, "Name:"):
+			p.Name = strings.TrimSpace(strings.TrimPrefix(text, "Name:"))
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("invalid gpl line %d: expected red green blue", line)
+		}
+		var channels []float64
+		for _, field := range fields[:3] {
+			channel, err := strconv.ParseUint(field, 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid gpl line %d: %q is not a channel", line, field)
+			}
+			channels = append(channels, float64(channel))
+		}
+		r, g, b := channels[RED], channels[GREEN], channels[BLUE]
+		p.Colors = append(p.Colors, Color{Red: r, Green: g, Blue: b, Hex: hexString(r, g, b)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("invalid gpl file: %w", err)
+	}
+
+	return p, nil
+}
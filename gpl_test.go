@@ -0,0 +1,86 @@
+package palettecalculator
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteGPL(t *testing.T) {
+	tests := []struct {
+		palette  *Palette
+		namer    ColorNamer
+		expected string
+	}{
+		{
+			&Palette{Name: "photo", Colors: []Color{{24, 98, 119, "186277"}, {255, 255, 255, "ffffff"}}},
+			nil,
+			"GIMP Palette\nName: photo\nColumns: 0\n#\n 24  98 119\tcolor-1\n255 255 255\tcolor-2\n",
+		},
+		{
+			&Palette{Colors: []Color{{14, 60, 73, "0e3c49"}}},
+			new(PaletteCalculator).NearestNamedColorNamer,
+			"GIMP Palette\nColumns: 0\n#\n 14  60  73\tdarkslategray\n",
+		},
+	}
+	paletteCalculator := new(PaletteCalculator)
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+
+		err := paletteCalculator.WriteGPL(&buf, test.palette, test.namer)
+
+		if err != nil {
+			t.Errorf("expected error: %v returned error: %v", nil, err)
+		}
+		if test.expected != buf.String() {
+			t.Errorf("expected: %v\n returned: %v\n", test.expected, buf.String())
+		}
+	}
+}
+
+func TestLoadGPLPalette(t *testing.T) {
+	tests := []struct {
+		gpl             string
+		expectedPalette *Palette
+		expectedErr     error
+	}{
+		{
+			"GIMP Palette\nName: photo\nColumns: 0\n#\n 24  98 119\tcolor-1\n255 255 255\n",
+			&Palette{Name: "photo", Colors: []Color{{24, 98, 119, "186277"}, {255, 255, 255, "ffffff"}}},
+			nil,
+		},
+		{"JASC-PAL\n", nil, errors.New("invalid gpl file: missing GIMP Palette header")},
+		{"GIMP Palette\n24 98\n", nil, errors.New("invalid gpl line 2: expected red green blue")},
+		{"GIMP Palette\n24 98 300 red\n", nil, errors.New(`invalid gpl line 2: "300" is not a channel`)},
+	}
+
+	for _, test := range tests {
+		returnedPalette, err := LoadGPLPalette(strings.NewReader(test.gpl))
+
+		if !reflect.DeepEqual(test.expectedErr, err) {
+			t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
+		}
+		if !reflect.DeepEqual(test.expectedPalette, returnedPalette) {
+			t.Errorf("expected: %v\n returned: %v\n", test.expectedPalette, returnedPalette)
+		}
+	}
+}
+
+func TestGPLRoundTrip(t *testing.T) {
+	palette := &Palette{Name: "photo", Colors: []Color{{24, 98, 119, "186277"}, {14, 60, 73, "0e3c49"}}}
+	paletteCalculator := new(PaletteCalculator)
+	var buf bytes.Buffer
+
+	paletteCalculator.WriteGPL(&buf, palette, nil)
+	returnedPalette, err := LoadGPLPalette(&buf)
+
+	if err != nil {
+		t.Errorf("expected error: %v returned error: %v", nil, err)
+	}
+	if !reflect.DeepEqual(palette, returnedPalette) {
+		t.Errorf("expected: %v\n returned: %v\n", palette, returnedPalette)
+	}
+}
@@ -0,0 +1,161 @@
+package palettecalculator
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Color space a gradient is interpolated in
+type InterpolationSpace int
+
+const (
+	InterpolateSRGB InterpolationSpace = iota
+	InterpolateLinearRGB
+	InterpolateHSLShortest
+	InterpolateHSLLongest
+	InterpolateOKLCH
+)
+
+// Shape of a CSS gradient
+type GradientType int
+
+const (
+	LinearGradient GradientType = iota
+	RadialGradient
+	ConicGradient
+)
+
+// Color stop of a CSS gradient at Position in [0,1] along the gradient
+type GradientStop struct {
+	Color    Color   `json:"color"`
+	Position float64 `json:"position"`
+}
+
+// CSS gradient. Angle is the direction of a linear gradient and the starting angle of a conic one in degrees,
+// radial gradients ignore it
+type Gradient struct {
+	Type  GradientType   `json:"type"`
+	Angle float64        `json:"angle"`
+	Stops []GradientStop `json:"stops"`
+}
+
+// Builds a CSS gradient through colors with evenly spaced stops, e.g. from an extracted palette
+func (pc *PaletteCalculator) NewGradient(colors []Color, gradientType GradientType, angle float64) *Gradient {
+	g := &Gradient{Type: gradientType, Angle: angle}
+	for i := range colors {
+		position := float64(0)
+		if len(colors) > 1 {
+			position = float64(i) / float64(len(colors)-1)
+		}
+		g.Stops = append(g.Stops, GradientStop{Color: colors[i], Position: position})
+	}
+
+	return g
+}
+
+// Formats the gradient as a CSS linear-gradient(), radial-gradient() or conic-gradient() value, e.g.
+// "linear-gradient(90deg, #186277 0%, #ffffff 100%)" for a style attribute
+func (g *Gradient) ToCSS() string {
+	var args []string
+	switch g.Type {
+	case LinearGradient:
+		args = append(args, fmt.Sprintf("%gdeg", roundFloat(g.Angle, 2)))
+	case ConicGradient:
+		args = append(args, fmt.Sprintf("from %gdeg", roundFloat(g.Angle, 2)))
+	}
+	for i := range g.Stops {
+		args = append(args, fmt.Sprintf("%s %g%%", cssHex(&g.Stops[i].Color), roundFloat(clampUnit(g.Stops[i].Position)*100, 2)))
+	}
+
+	function := "linear-gradient"
+	switch g.Type {
+	case RadialGradient:
+		function = "radial-gradient"
+	case ConicGradient:
+		function = "conic-gradient"
+	}
+
+	return fmt.Sprintf("%s(%s)", function, strings.Join(args, ", "))
+}
+
+// Calculates a gradient of steps colors from one color to another, both ends included
+func (pc *PaletteCalculator) Gradient(from *Color, to *Color, steps int, space InterpolationSpace) []Color {
+	if steps < 1 {
+		return nil
+	}
+	if steps == 1 {
+		return []Color{*from}
+	}
+
+	var gradient []Color
+	for i := 0; i < steps; i++ {
+		gradient = append(gradient, *pc.Interpolate(from, to, float64(i)/float64(steps-1), space))
+	}
+
+	return gradient
+}
+
+// Calculates the single color at t between two colors in the given space, t = 0 returns a and t = 1 returns b.
+// t is clamped to [0,1] so eased animation progress that overshoots stays between the two colors
+func (pc *PaletteCalculator) Interpolate(a *Color, b *Color, t float64, space InterpolationSpace) *Color {
+	t = clampUnit(t)
+
+	switch space {
+	case InterpolateLinearRGB:
+		return pc.linearRGBToColor(
+			pc.lerp(linearize(a.Red/RGBMax), linearize(b.Red/RGBMax), t),
+			pc.lerp(linearize(a.Green/RGBMax), linearize(b.Green/RGBMax), t),
+			pc.lerp(linearize(a.Blue/RGBMax), linearize(b.Blue/RGBMax), t),
+		)
+	case InterpolateHSLShortest, InterpolateHSLLongest:
+		hslA, hslB := pc.ConvertRGBToHSL(a), pc.ConvertRGBToHSL(b)
+		hueA, hueB := pc.missingHue(hslA.Hue, hslA.Saturation, hslB.Hue, hslB.Saturation)
+		return pc.ConvertHSLToRGB(&HSL{
+			Hue:        pc.lerpHue(hueA, hueB, t, space == InterpolateHSLLongest),
+			Saturation: pc.lerp(hslA.Saturation, hslB.Saturation, t),
+			Luminosity: pc.lerp(hslA.Luminosity, hslB.Luminosity, t),
+		})
+	case InterpolateOKLCH:
+		lchA := pc.ConvertOKLABToOKLCH(pc.ConvertRGBToOKLAB(a))
+		lchB := pc.ConvertOKLABToOKLCH(pc.ConvertRGBToOKLAB(b))
+		hueA, hueB := pc.missingHue(lchA.H, lchA.C, lchB.H, lchB.C)
+		return pc.ConvertOKLCHToRGB(&OKLCH{
+			L: pc.lerp(lchA.L, lchB.L, t),
+			C: pc.lerp(lchA.C, lchB.C, t),
+			H: pc.lerpHue(hueA, hueB, t, false),
+		})
+	default:
+		red := pc.round(pc.lerp(a.Red, b.Red, t), 0)
+		green := pc.round(pc.lerp(a.Green, b.Green, t), 0)
+		blue := pc.round(pc.lerp(a.Blue, b.Blue, t), 0)
+		return &Color{Red: red, Green: green, Blue: blue, Hex: pc.generateHex(red, green, blue)}
+	}
+}
+
+func (pc *PaletteCalculator) lerp(a float64, b float64, t float64) float64 {
+	return a + (b-a)*t
+}
+
+// Interpolates hue along the shortest or longest arc of the wheel
+func (pc *PaletteCalculator) lerpHue(a float64, b float64, t float64, longest bool) float64 {
+	delta := math.Mod(b-a+540, 360) - 180
+	if longest && delta != 0 {
+		delta -= math.Copysign(360, delta)
+	}
+
+	return math.Mod(a+delta*t+360, 360)
+}
+
+// Achromatic colors have no hue, borrow the other color's so the gradient doesn't swing through red
+func (pc *PaletteCalculator) missingHue(hueA float64, chromaA float64, hueB float64, chromaB float64) (float64, float64) {
+	const epsilon = 1e-4
+	if chromaA < epsilon {
+		hueA = hueB
+	}
+	if chromaB < epsilon {
+		hueB = hueA
+	}
+
+	return hueA, hueB
+}
@@ -0,0 +1,136 @@
+package palettecalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGradient(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		from        *Color
+		steps       int
+		space       InterpolationSpace
+		expectedRGB []Color
+	}{
+		{
+			name:        "should interpolate in sRGB",
+			from:        &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+			steps:       3,
+			space:       InterpolateSRGB,
+			expectedRGB: []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {72, 72, 72, "484848"}, {119, 45, 24, "772d18"}},
+		},
+		{
+			name:        "should interpolate in linear RGB",
+			from:        &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+			steps:       3,
+			space:       InterpolateLinearRGB,
+			expectedRGB: []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {88, 77, 88, "584d58"}, {119, 45, 24, "772d18"}},
+		},
+		{
+			name:        "should interpolate along shortest HSL hue arc",
+			from:        &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+			steps:       3,
+			space:       InterpolateHSLShortest,
+			expectedRGB: []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {51, 119, 24, "337718"}, {119, 45, 24, "772d18"}},
+		},
+		{
+			name:        "should interpolate along longest HSL hue arc",
+			from:        &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+			steps:       3,
+			space:       InterpolateHSLLongest,
+			expectedRGB: []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {92, 24, 119, "5c1877"}, {119, 45, 24, "772d18"}},
+		},
+		{
+			name:        "should interpolate in OKLCH",
+			from:        &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+			steps:       3,
+			space:       InterpolateOKLCH,
+			expectedRGB: []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {95, 65, 119, "5f4177"}, {119, 45, 24, "772d18"}},
+		},
+		{
+			name:        "should return only the start color for a single step",
+			from:        &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+			steps:       1,
+			space:       InterpolateOKLCH,
+			expectedRGB: []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			to := &Color{Red: 119, Green: 45, Blue: 24, Hex: "772d18"}
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedRGB := paletteCalculator.Gradient(test.from, to, test.steps, test.space)
+
+			if !reflect.DeepEqual(test.expectedRGB, returnedRGB) {
+				t.Errorf("expected: %v\n returned %v\n", test.expectedRGB, returnedRGB)
+			}
+		})
+	}
+}
+
+func TestGradientFromAchromaticColor(t *testing.T) {
+	from := &Color{Red: 255, Green: 255, Blue: 255, Hex: "ffffff"}
+	to := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+	expectedRGB := []Color{{255, 255, 255, "ffffff"}, {142, 174, 185, "8eaeb9"}, {Red: Red, Green: Green, Blue: Blue, Hex: Hex}}
+	paletteCalculator := new(PaletteCalculator)
+
+	returnedRGB := paletteCalculator.Gradient(from, to, 3, InterpolateOKLCH)
+
+	if !reflect.DeepEqual(expectedRGB, returnedRGB) {
+		t.Errorf("expected: %v\n returned %v\n", expectedRGB, returnedRGB)
+	}
+
+}
+
+func TestInterpolate(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		t             float64
+		space         InterpolationSpace
+		expectedColor *Color
+	}{
+		{name: "should return first color at 0", t: 0, space: InterpolateOKLCH, expectedColor: &Color{Red, Green, Blue, Hex}},
+		{name: "should return in-between color", t: .5, space: InterpolateOKLCH, expectedColor: &Color{95, 65, 119, "5f4177"}},
+		{name: "should return second color at 1", t: 1, space: InterpolateOKLCH, expectedColor: &Color{119, 45, 24, "772d18"}},
+		{name: "should clamp overshooting t", t: 1.2, space: InterpolateSRGB, expectedColor: &Color{119, 45, 24, "772d18"}},
+		{name: "should clamp undershooting t", t: -.2, space: InterpolateSRGB, expectedColor: &Color{Red, Green, Blue, Hex}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			a := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+			b := &Color{Red: 119, Green: 45, Blue: 24, Hex: "772d18"}
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedColor := paletteCalculator.Interpolate(a, b, test.t, test.space)
+
+			if !reflect.DeepEqual(test.expectedColor, returnedColor) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedColor, returnedColor)
+			}
+		})
+	}
+}
+
+func TestGradientToCSS(t *testing.T) {
+	colors := []Color{{24, 98, 119, "186277"}, {14, 60, 73, "0e3c49"}, {255, 255, 255, "ffffff"}}
+	paletteCalculator := new(PaletteCalculator)
+	tests := []struct {
+		gradient *Gradient
+		expected string
+	}{
+		{paletteCalculator.NewGradient(colors, LinearGradient, 90), "linear-gradient(90deg, #186277 0%, #0e3c49 50%, #ffffff 100%)"},
+		{paletteCalculator.NewGradient(colors[:2], RadialGradient, 90), "radial-gradient(#186277 0%, #0e3c49 100%)"},
+		{paletteCalculator.NewGradient(colors, ConicGradient, 45.5), "conic-gradient(from 45.5deg, #186277 0%, #0e3c49 50%, #ffffff 100%)"},
+		{
+			&Gradient{Angle: 180, Stops: []GradientStop{{colors[0], 0}, {colors[2], 1 / float64(3)}, {colors[1], 1.2}}},
+			"linear-gradient(180deg, #186277 0%, #ffffff 33.33%, #0e3c49 100%)",
+		},
+	}
+
+	for _, test := range tests {
+		returned := test.gradient.ToCSS()
+
+		if test.expected != returned {
+			t.Errorf("expected: %v\n returned: %v\n", test.expected, returned)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package palettecalculator
+
+import (
+	"math"
+)
+
+// OKLCH chroma standard deviation at which chroma consistency drops to zero
+const harmonyChromaTolerance = .1
+
+// OKLCH lightness standard deviation at which lightness consistency drops to zero
+const harmonyLightnessTolerance = .3
+
+// How harmonious a palette is, every score in [0,1] with 1 being most harmonious
+type HarmonyScore struct {
+	// Weighted overall score, half hue spacing and a quarter each of chroma and lightness consistency
+	Score float64 `json:"score"`
+	// How closely hues follow a known scheme, from DetectScheme
+	HueSpacing float64 `json:"hue-spacing"`
+	// How similar the colors' OKLCH chroma are
+	ChromaConsistency float64 `json:"chroma-consistency"`
+	// How similar the colors' OKLCH lightness are
+	LightnessConsistency float64 `json:"lightness-consistency"`
+}
+
+// Scores how harmonious a palette is so generated palettes below a threshold can be rejected and regenerated
+func (pc *PaletteCalculator) ScoreHarmony(colors []Color) *HarmonyScore {
+	if len(colors) == 0 {
+		return &HarmonyScore{Score: 1, HueSpacing: 1, ChromaConsistency: 1, LightnessConsistency: 1}
+	}
+
+	detection := pc.DetectScheme(colors)
+	hueSpacing := detection.Confidence
+	if detection.Scheme == Unstructured {
+		hueSpacing = 1 - detection.Confidence
+	}
+
+	var chromas, lightnesses []float64
+	for i := range colors {
+		lch := pc.ConvertOKLABToOKLCH(pc.ConvertRGBToOKLAB(&colors[i]))
+		chromas = append(chromas, lch.C)
+		lightnesses = append(lightnesses, lch.L)
+	}
+	chroma := clampUnit(1 - pc.standardDeviation(chromas)/harmonyChromaTolerance)
+	lightness := clampUnit(1 - pc.standardDeviation(lightnesses)/harmonyLightnessTolerance)
+
+	return &HarmonyScore{
+		Score:                roundFloat(.5*hueSpacing+.25*chroma+.25*lightness, 2),
+		HueSpacing:           roundFloat(hueSpacing, 2),
+		ChromaConsistency:    roundFloat(chroma, 2),
+		LightnessConsistency: roundFloat(lightness, 2),
+	}
+}
+
+// Population standard deviation
+func (pc *PaletteCalculator) standardDeviation(values []float64) float64 {
+	mean := sumFloats(values) / float64(len(values))
+
+	variance := float64(0)
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+
+	return math.Sqrt(variance / float64(len(values)))
+}
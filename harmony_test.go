@@ -0,0 +1,45 @@
+package palettecalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScoreHarmony(t *testing.T) {
+	paletteCalculator := new(PaletteCalculator)
+	dc := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+
+	for _, test := range []struct {
+		name          string
+		colors        []Color
+		expectedScore *HarmonyScore
+	}{
+		{
+			name:          "should score complimentary scheme highly",
+			colors:        paletteCalculator.CalculateComplimentaryColorScheme(dc),
+			expectedScore: &HarmonyScore{Score: .93, HueSpacing: 1, ChromaConsistency: .84, LightnessConsistency: .9},
+		},
+		{
+			name:          "should score triadic scheme highly",
+			colors:        paletteCalculator.CalculateTriadicColorScheme(dc),
+			expectedScore: &HarmonyScore{Score: .87, HueSpacing: .98, ChromaConsistency: .7, LightnessConsistency: .82},
+		},
+		{
+			name:          "should score clashing colors poorly",
+			colors:        []Color{{24, 98, 119, "186277"}, {250, 240, 10, "faf00a"}, {200, 30, 160, "c81ea0"}, {20, 20, 20, "141414"}, {90, 200, 60, "5ac83c"}},
+			expectedScore: &HarmonyScore{Score: .41, HueSpacing: .69, ChromaConsistency: .11, LightnessConsistency: .16},
+		},
+		{
+			name:          "should score empty palette as harmonious",
+			expectedScore: &HarmonyScore{Score: 1, HueSpacing: 1, ChromaConsistency: 1, LightnessConsistency: 1},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			returnedScore := paletteCalculator.ScoreHarmony(test.colors)
+
+			if !reflect.DeepEqual(test.expectedScore, returnedScore) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedScore, returnedScore)
+			}
+		})
+	}
+}
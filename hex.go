@@ -0,0 +1,30 @@
+package palettecalculator
+
+import "strings"
+
+// How FormatHex writes the hex of a color
+type HexOptions struct {
+	// Starts the hex with "#"
+	Prefix bool
+	// Writes the digits a to f in upper case
+	Uppercase bool
+	// Writes three digits when each channel's two digits repeat, e.g. "fff" for "ffffff", so nothing is lost
+	Short bool
+}
+
+// Formats the hex of c's channels as set by opts, six zero padded digits by default, e.g. "0a0a0a" rather than the
+// ambiguous "aaa". Channels are clamped to 0 to 255
+func (pc *PaletteCalculator) FormatHex(c *Color, opts HexOptions) string {
+	hex := hexString(c.Red, c.Green, c.Blue)
+	if opts.Short && hex[0] == hex[1] && hex[2] == hex[3] && hex[4] == hex[5] {
+		hex = string([]byte{hex[0], hex[2], hex[4]})
+	}
+	if opts.Uppercase {
+		hex = strings.ToUpper(hex)
+	}
+	if opts.Prefix {
+		hex = "#" + hex
+	}
+
+	return hex
+}
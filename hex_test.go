@@ -0,0 +1,30 @@
+package palettecalculator
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormatHex(t *testing.T) {
+	for _, test := range []struct {
+		color       Color
+		opts        HexOptions
+		expectedHex string
+	}{
+		{color: Color{Red: 10, Green: 10, Blue: 10}, opts: HexOptions{}, expectedHex: "0a0a0a"},
+		{color: Color{Red: Red, Green: Green, Blue: Blue}, opts: HexOptions{Prefix: true}, expectedHex: "#186277"},
+		{color: Color{Red: 171, Green: 205, Blue: 239}, opts: HexOptions{Uppercase: true}, expectedHex: "ABCDEF"},
+		{color: Color{Red: 255, Green: 255, Blue: 255}, opts: HexOptions{Short: true, Prefix: true}, expectedHex: "#fff"},
+		{color: Color{Red: 170, Green: 187, Blue: 204}, opts: HexOptions{Short: true, Uppercase: true}, expectedHex: "ABC"},
+		{color: Color{Red: Red, Green: Green, Blue: Blue}, opts: HexOptions{Short: true}, expectedHex: "186277"},
+		{color: Color{Red: 300, Green: -5, Blue: 0}, opts: HexOptions{}, expectedHex: "ff0000"},
+	} {
+		t.Run(fmt.Sprintf("%v %+v", test.color, test.opts), func(t *testing.T) {
+			returnedHex := new(PaletteCalculator).FormatHex(&test.color, test.opts)
+
+			if returnedHex != test.expectedHex {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedHex, returnedHex)
+			}
+		})
+	}
+}
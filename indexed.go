@@ -0,0 +1,121 @@
+package palettecalculator
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"io"
+	"sort"
+)
+
+// File signatures of the formats that can hold an indexed image
+var indexedSignatures = [][]byte{[]byte("\x89PNG\r\n\x1a\n"), []byte("GIF87a"), []byte("GIF89a")}
+
+// Reads the palette of an indexed image exactly from its color table, e.g. a decoded PNG8 or GIF, without the
+// Vision API. Colors are ordered by how many pixels use them and weighted by their share of opaque pixels,
+// unused and fully transparent entries are dropped
+func (pc *PaletteCalculator) PaletteFromIndexedImage(img *image.Paletted) *Palette {
+	counts := make([]int, len(img.Palette))
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if index := int(img.ColorIndexAt(x, y)); index < len(counts) {
+				counts[index]++
+			}
+		}
+	}
+
+	for i := range counts {
+		if color.NRGBAModel.Convert(img.Palette[i]).(color.NRGBA).A == 0 {
+			counts[i] = 0
+		}
+	}
+
+	return pc.weightedPalette(img.Palette, counts)
+}
+
+// Palette of the used colors, ordered by count and weighted by their share of the total count
+func (pc *PaletteCalculator) weightedPalette(colors color.Palette, counts []int) *Palette {
+	var indices []int
+	total := 0
+	for i, count := range counts {
+		if count == 0 {
+			continue
+		}
+		indices = append(indices, i)
+		total += count
+	}
+	sort.SliceStable(indices, func(a, b int) bool { return counts[indices[a]] > counts[indices[b]] })
+
+	p := &Palette{}
+	for _, i := range indices {
+		p.Colors = append(p.Colors, *FromColor(colors[i]))
+		p.Weights = append(p.Weights, float64(counts[i])/float64(total))
+	}
+
+	return p
+}
+
+// Reads the palette of an indexed PNG or GIF file with PaletteFromIndexedImage
+func (pc *PaletteCalculator) CalculatePaletteFromIndexedFile(file string) (*Palette, error) {
+	f, err := pc.Opener.Open(file)
+	if err != nil {
+		return nil, &FileError{File: file, Err: err}
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, &DecodeError{Err: err}
+	}
+	paletted, ok := img.(*image.Paletted)
+	if !ok {
+		return nil, errors.New("image is not indexed")
+	}
+
+	return pc.PaletteFromIndexedImage(paletted), nil
+}
+
+// Decodes r when it holds an indexed PNG or GIF. Otherwise returns a reader of everything read from r, so the
+// image can still be sent elsewhere. Only the header is read to tell, truecolor images are not decoded or buffered
+func (pc *PaletteCalculator) decodeIndexed(r io.Reader) (*image.Paletted, io.Reader) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(8)
+	if err != nil && len(header) < 6 {
+		return nil, br
+	}
+
+	indexed := false
+	for _, signature := range indexedSignatures {
+		if bytes.HasPrefix(header, signature) {
+			indexed = true
+		}
+	}
+	if !indexed {
+		return nil, br
+	}
+
+	var read bytes.Buffer
+	config, _, err := image.DecodeConfig(io.TeeReader(br, &read))
+	rest := io.MultiReader(bytes.NewReader(read.Bytes()), br)
+	if err != nil {
+		return nil, rest
+	}
+	if _, ok := config.ColorModel.(color.Palette); !ok {
+		return nil, rest
+	}
+
+	data, err := io.ReadAll(rest)
+	if err != nil {
+		return nil, io.MultiReader(bytes.NewReader(data), br)
+	}
+	if img, _, err := image.Decode(bytes.NewReader(data)); err == nil {
+		if paletted, ok := img.(*image.Paletted); ok {
+			return paletted, nil
+		}
+	}
+
+	return nil, bytes.NewReader(data)
+}
@@ -0,0 +1,101 @@
+package palettecalculator
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// 3x2 indexed image using navy for four pixels, white for one and a transparent entry for one. The red entry is unused
+func indexedTestImage() *image.Paletted {
+	palette := color.Palette{color.NRGBA{255, 255, 255, 255}, color.NRGBA{24, 98, 119, 255}, color.NRGBA{255, 0, 0, 255}, color.NRGBA{0, 0, 0, 0}}
+	img := image.NewPaletted(image.Rect(0, 0, 3, 2), palette)
+	img.Pix = []uint8{1, 1, 0, 1, 1, 3}
+	return img
+}
+
+func TestPaletteFromIndexedImage(t *testing.T) {
+	expectedPalette := &Palette{Colors: []Color{{24, 98, 119, "186277"}, {255, 255, 255, "ffffff"}}, Weights: []float64{.8, .2}}
+	paletteCalculator := new(PaletteCalculator)
+
+	returnedPalette := paletteCalculator.PaletteFromIndexedImage(indexedTestImage())
+
+	if !reflect.DeepEqual(expectedPalette, returnedPalette) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedPalette, returnedPalette)
+	}
+}
+
+func TestCalculatePaletteFromIndexedFile(t *testing.T) {
+	var gifData, pngData, rgbaData bytes.Buffer
+	gif.Encode(&gifData, indexedTestImage(), nil)
+	png.Encode(&pngData, indexedTestImage())
+	png.Encode(&rgbaData, image.NewRGBA(image.Rect(0, 0, 1, 1)))
+	tests := []struct {
+		name            string
+		data            []byte
+		expectedPalette *Palette
+		expectedErr     error
+	}{
+		{"should read gif color table", gifData.Bytes(), &Palette{Colors: []Color{{24, 98, 119, "186277"}, {255, 255, 255, "ffffff"}}, Weights: []float64{.8, .2}}, nil},
+		{"should read png plte", pngData.Bytes(), &Palette{Colors: []Color{{24, 98, 119, "186277"}, {255, 255, 255, "ffffff"}}, Weights: []float64{.8, .2}}, nil},
+		{"should fail on truecolor png", rgbaData.Bytes(), nil, errors.New("image is not indexed")},
+	}
+
+	for _, test := range tests {
+		path := filepath.Join(t.TempDir(), "image")
+		os.WriteFile(path, test.data, 0644)
+		file, _ := os.Open(path)
+		paletteCalculator := new(PaletteCalculator)
+		paletteCalculator.Opener = &MockFileOpener{data: file}
+
+		returnedPalette, err := paletteCalculator.CalculatePaletteFromIndexedFile(path)
+
+		if !reflect.DeepEqual(test.expectedErr, err) {
+			t.Errorf("%s expected error: %v returned error: %v", test.name, test.expectedErr, err)
+		}
+		if !reflect.DeepEqual(test.expectedPalette, returnedPalette) {
+			t.Errorf("%s expected: %v\n returned: %v\n", test.name, test.expectedPalette, returnedPalette)
+		}
+	}
+}
+
+func TestDecodeIndexed(t *testing.T) {
+	var gifData, pngData, rgbaData bytes.Buffer
+	gif.Encode(&gifData, indexedTestImage(), nil)
+	png.Encode(&pngData, indexedTestImage())
+	png.Encode(&rgbaData, image.NewRGBA(image.Rect(0, 0, 1, 1)))
+	tests := []struct {
+		name            string
+		data            []byte
+		expectedIndexed bool
+	}{
+		{"should decode gif", gifData.Bytes(), true},
+		{"should decode png8", pngData.Bytes(), true},
+		{"should pass truecolor png through", rgbaData.Bytes(), false},
+		{"should pass jpeg through", []byte("\xff\xd8\xff\xe0"), false},
+	}
+
+	for _, test := range tests {
+		paletteCalculator := new(PaletteCalculator)
+
+		returnedIndexed, r := paletteCalculator.decodeIndexed(bytes.NewReader(test.data))
+
+		if (returnedIndexed != nil) != test.expectedIndexed {
+			t.Errorf("%s expected indexed: %v returned: %v", test.name, test.expectedIndexed, returnedIndexed)
+		}
+		if test.expectedIndexed {
+			continue
+		}
+		if returnedData, _ := io.ReadAll(r); !bytes.Equal(returnedData, test.data) {
+			t.Errorf("%s expected: %v\n returned: %v\n", test.name, test.data, returnedData)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package palettecalculator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// JSON shape of a Color, channels are pointers so a color given only by hex can be told apart from black
+type colorJSON struct {
+	Red   *float64 `json:"red"`
+	Green *float64 `json:"green"`
+	Blue  *float64 `json:"blue"`
+	Hex   string   `json:"hex"`
+}
+
+// Marshals the color as {"red":24,"green":98,"blue":119,"hex":"186277"}, hex always six digits without "#"
+func (c Color) MarshalJSON() ([]byte, error) {
+	r, g, b := c.Red, c.Green, c.Blue
+	return json.Marshal(colorJSON{Red: &r, Green: &g, Blue: &b, Hex: strings.TrimPrefix(cssHex(&c), "#")})
+}
+
+// Unmarshals a color object or a CSS color string, e.g. "#186277" or "rebeccapurple". An object without channels
+// is parsed from its hex, otherwise the channels win and hex is recomputed from them
+func (c *Color) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		parsed, err := ParseCSS(s)
+		if err != nil {
+			return err
+		}
+		*c = *parsed
+		return nil
+	}
+
+	var raw colorJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Red == nil && raw.Green == nil && raw.Blue == nil {
+		if raw.Hex == "" {
+			return &ColorError{Syntax: "json", Input: string(data), Err: errors.New("expected channels or hex")}
+		}
+		parsed, err := ParseHex(raw.Hex)
+		if err != nil {
+			return err
+		}
+		*c = *parsed
+		return nil
+	}
+
+	var channels []float64
+	for _, channel := range []*float64{raw.Red, raw.Green, raw.Blue} {
+		if channel == nil {
+			return &ColorError{Syntax: "json", Input: string(data), Err: errors.New("expected red, green and blue")}
+		}
+		channels = append(channels, *channel)
+	}
+	r, g, b := channels[RED], channels[GREEN], channels[BLUE]
+	*c = Color{Red: r, Green: g, Blue: b, Hex: hexString(r, g, b)}
+
+	return nil
+}
+
+// Marshals the palette as {"name":"photo","colors":[...],"weights":[...]}, an empty palette has "colors":[]
+func (p Palette) MarshalJSON() ([]byte, error) {
+	type palette Palette
+	out := palette(p)
+	if out.Colors == nil {
+		out.Colors = []Color{}
+	}
+
+	return json.Marshal(out)
+}
+
+// Unmarshals a palette whose colors are color objects or CSS color strings. Weights, when given, must match colors
+func (p *Palette) UnmarshalJSON(data []byte) error {
+	type palette Palette
+	var in palette
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	decoded := Palette(in)
+	if err := decoded.validate(); err != nil {
+		return err
+	}
+	*p = decoded
+
+	return nil
+}
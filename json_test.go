@@ -0,0 +1,124 @@
+package palettecalculator
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestColorMarshalJSON(t *testing.T) {
+	tests := []struct {
+		color    Color
+		expected string
+	}{
+		{Color{24, 98, 119, "186277"}, `{"red":24,"green":98,"blue":119,"hex":"186277"}`},
+		{Color{14, 60, 73, "0e3c49"}, `{"red":14,"green":60,"blue":73,"hex":"0e3c49"}`},
+	}
+
+	for _, test := range tests {
+		returned, err := json.Marshal(test.color)
+
+		if err != nil {
+			t.Errorf("expected error: %v returned error: %v", nil, err)
+		}
+		if test.expected != string(returned) {
+			t.Errorf("expected: %v\n returned: %v\n", test.expected, string(returned))
+		}
+	}
+}
+
+func TestColorUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name          string
+		json          string
+		expectedColor Color
+		expectedErr   error
+	}{
+		{"should unmarshal channels", `{"red":14,"green":60,"blue":73,"hex":"0e3c49"}`, Color{14, 60, 73, "0e3c49"}, nil},
+		{"should recompute hex from channels", `{"red":24,"green":98,"blue":119,"hex":"ffffff"}`, Color{24, 98, 119, "186277"}, nil},
+		{"should parse hex without channels", `{"hex":"#186277"}`, Color{24, 98, 119, "186277"}, nil},
+		{"should parse css string", `"rebeccapurple"`, Color{102, 51, 153, "663399"}, nil},
+		{"should fail on missing channel", `{"red":24,"green":98}`, Color{}, &ColorError{Syntax: "json", Input: `{"red":24,"green":98}`, Err: errors.New("expected red, green and blue")}},
+		{"should fail on empty object", `{}`, Color{}, &ColorError{Syntax: "json", Input: `{}`, Err: errors.New("expected channels or hex")}},
+	}
+
+	for _, test := range tests {
+		var returned Color
+
+		err := json.Unmarshal([]byte(test.json), &returned)
+
+		if !reflect.DeepEqual(test.expectedErr, err) {
+			t.Errorf("%s expected error: %v returned error: %v", test.name, test.expectedErr, err)
+		}
+		if !reflect.DeepEqual(test.expectedColor, returned) {
+			t.Errorf("%s expected: %v\n returned: %v\n", test.name, test.expectedColor, returned)
+		}
+	}
+}
+
+func TestPaletteJSONRoundTrip(t *testing.T) {
+	palette := Palette{Name: "photo", Colors: []Color{{24, 98, 119, "186277"}, {14, 60, 73, "0e3c49"}}, Weights: []float64{.75, .25}}
+	expectedJSON := `{"name":"photo","colors":[{"red":24,"green":98,"blue":119,"hex":"186277"},{"red":14,"green":60,"blue":73,"hex":"0e3c49"}],"weights":[0.75,0.25]}`
+
+	returnedJSON, err := json.Marshal(palette)
+	if err != nil {
+		t.Errorf("expected error: %v returned error: %v", nil, err)
+	}
+	if expectedJSON != string(returnedJSON) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedJSON, string(returnedJSON))
+	}
+
+	var returned Palette
+	if err := json.Unmarshal(returnedJSON, &returned); err != nil {
+		t.Errorf("expected error: %v returned error: %v", nil, err)
+	}
+	if !reflect.DeepEqual(palette, returned) {
+		t.Errorf("expected: %v\n returned: %v\n", palette, returned)
+	}
+}
+
+func TestPaletteMarshalJSONWithNoColors(t *testing.T) {
+	expected := `{"colors":[]}`
+
+	returned, _ := json.Marshal(Palette{})
+
+	if expected != string(returned) {
+		t.Errorf("expected: %v\n returned: %v\n", expected, string(returned))
+	}
+}
+
+func TestPaletteUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name            string
+		json            string
+		expectedPalette Palette
+		expectedErr     error
+	}{
+		{"should unmarshal css strings", `{"colors":["#186277","white"]}`, Palette{Colors: []Color{{24, 98, 119, "186277"}, {255, 255, 255, "ffffff"}}}, nil},
+		{"should fail on mismatched weights", `{"colors":["#186277"],"weights":[0.5,0.5]}`, Palette{}, errors.New("palette has 2 weights for 1 colors")},
+	}
+
+	for _, test := range tests {
+		var returned Palette
+
+		err := json.Unmarshal([]byte(test.json), &returned)
+
+		if !reflect.DeepEqual(test.expectedErr, err) {
+			t.Errorf("%s expected error: %v returned error: %v", test.name, test.expectedErr, err)
+		}
+		if !reflect.DeepEqual(test.expectedPalette, returned) {
+			t.Errorf("%s expected: %v\n returned: %v\n", test.name, test.expectedPalette, returned)
+		}
+	}
+}
+
+func TestHSLMarshalJSON(t *testing.T) {
+	expected := `{"hue":194,"saturation":0.66,"luminosity":0.28}`
+
+	returned, _ := json.Marshal(HSL{Hue: 194, Saturation: .66, Luminosity: .28})
+
+	if expected != string(returned) {
+		t.Errorf("expected: %v\n returned: %v\n", expected, string(returned))
+	}
+}
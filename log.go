@@ -0,0 +1,35 @@
+package palettecalculator
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Copy of pc logging the steps of a calculation to logger: debug logs of file sizes, backends chosen and Vision
+// calls, and an info log of each extracted palette with its color count and duration
+func (pc *PaletteCalculator) WithLogger(logger *slog.Logger) *PaletteCalculator {
+	c := *pc
+	c.Logger = logger
+
+	return &c
+}
+
+// Logs msg under pc's context when pc has a logger
+func (pc *PaletteCalculator) log(level slog.Level, msg string, args ...interface{}) {
+	if pc.Logger == nil {
+		return
+	}
+
+	ctx := pc.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	pc.Logger.Log(ctx, level, msg, args...)
+}
+
+// Logs an extracted palette at info with the backend that extracted it and how long it took since start
+func (pc *PaletteCalculator) logPalette(p *Palette, backend string, start time.Time, args ...interface{}) {
+	args = append([]interface{}{"backend", backend, "colors", len(p.Colors), "duration", time.Since(start)}, args...)
+	pc.log(slog.LevelInfo, "extracted palette", args...)
+}
@@ -0,0 +1,51 @@
+package palettecalculator
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"log/slog"
+	"testing"
+)
+
+// Logger writing text logs to buf without their times and durations
+func testLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey || a.Key == "duration" {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+func TestWithLogger(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		img.Set(x, 0, color.RGBA{R: 255, A: 255})
+		img.Set(x, 1, color.RGBA{B: 255, A: 255})
+	}
+	var buf bytes.Buffer
+	pc := new(PaletteCalculator).WithLogger(testLogger(&buf))
+
+	if _, err := pc.ExtractPalette(img, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "level=DEBUG msg=\"quantizing image\" backend=local width=4 height=2 k=2\n" +
+		"level=INFO msg=\"extracted palette\" backend=local colors=2 k=2\n"
+	if buf.String() != expected {
+		t.Errorf("expected: %v\n returned: %v\n", expected, buf.String())
+	}
+}
+
+func TestWithLoggerCopies(t *testing.T) {
+	pc := new(PaletteCalculator)
+	logged := pc.WithLogger(slog.Default())
+
+	if pc.Logger != nil || logged.Logger != slog.Default() {
+		t.Errorf("expected: %v\n returned: %v\n", slog.Default(), logged.Logger)
+	}
+}
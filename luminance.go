@@ -0,0 +1,23 @@
+package palettecalculator
+
+import "math"
+
+// HSP brightness below which a color is considered dark
+const DarkBrightness = .5
+
+// WCAG 2.x relative luminance of the color, from 0 (black) to 1 (white)
+func (c *Color) Luminance() float64 {
+	return 0.2126*linearize(c.Red/RGBMax) + 0.7152*linearize(c.Green/RGBMax) + 0.0722*linearize(c.Blue/RGBMax)
+}
+
+// HSP perceived brightness of the color, from 0 (black) to 1 (white)
+func (c *Color) PerceivedBrightness() float64 {
+	r, g, b := c.Red/RGBMax, c.Green/RGBMax, c.Blue/RGBMax
+
+	return math.Sqrt(0.299*r*r + 0.587*g*g + 0.114*b*b)
+}
+
+// Reports whether the color is perceived as dark, i.e. light text reads better on it than dark text
+func (c *Color) IsDark() bool {
+	return c.PerceivedBrightness() < DarkBrightness
+}
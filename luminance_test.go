@@ -0,0 +1,51 @@
+package palettecalculator
+
+import (
+	"testing"
+)
+
+func TestLuminance(t *testing.T) {
+	for _, test := range []struct {
+		name              string
+		color             *Color
+		expectedLuminance float64
+	}{
+		{name: "black", color: &Color{0, 0, 0, "000000"}, expectedLuminance: 0},
+		{name: "white", color: &Color{255, 255, 255, "ffffff"}, expectedLuminance: 1},
+		{name: "dominant color", color: &Color{Red, Green, Blue, Hex}, expectedLuminance: .1026},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			returnedLuminance := roundFloat(test.color.Luminance(), 4)
+
+			if test.expectedLuminance != returnedLuminance {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedLuminance, returnedLuminance)
+			}
+		})
+	}
+}
+
+func TestPerceivedBrightness(t *testing.T) {
+	for _, test := range []struct {
+		name               string
+		color              *Color
+		expectedBrightness float64
+		expectedDark       bool
+	}{
+		{name: "black", color: &Color{0, 0, 0, "000000"}, expectedBrightness: 0, expectedDark: true},
+		{name: "white", color: &Color{255, 255, 255, "ffffff"}, expectedBrightness: 1, expectedDark: false},
+		{name: "dominant color", color: &Color{Red, Green, Blue, Hex}, expectedBrightness: .3379, expectedDark: true},
+		{name: "yellow", color: &Color{250, 240, 10, "faf00a"}, expectedBrightness: .8986, expectedDark: false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			returnedBrightness := roundFloat(test.color.PerceivedBrightness(), 4)
+
+			if test.expectedBrightness != returnedBrightness {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedBrightness, returnedBrightness)
+			}
+
+			if test.expectedDark != test.color.IsDark() {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedDark, test.color.IsDark())
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package palettecalculator
+
+import (
+	"math"
+)
+
+// Returns a copy of the color moved amount of the way towards white in OKLCH, e.g. Lighten(.1)
+func (c *Color) Lighten(amount float64) *Color {
+	return c.adjustOKLCH(func(lch *OKLCH) {
+		lch.L += (1 - lch.L) * clampUnit(amount)
+	})
+}
+
+// Returns a copy of the color moved amount of the way towards black in OKLCH, e.g. Darken(.1)
+func (c *Color) Darken(amount float64) *Color {
+	return c.adjustOKLCH(func(lch *OKLCH) {
+		lch.L *= 1 - clampUnit(amount)
+	})
+}
+
+// Returns a copy of the color with its OKLCH chroma increased by amount, e.g. Saturate(.2) is 20% more saturated.
+// Chroma is reduced back into gamut if needed
+func (c *Color) Saturate(amount float64) *Color {
+	return c.adjustOKLCH(func(lch *OKLCH) {
+		lch.C *= 1 + amount
+	})
+}
+
+// Returns a copy of the color with its OKLCH chroma reduced by amount, e.g. Desaturate(1) is fully gray
+func (c *Color) Desaturate(amount float64) *Color {
+	return c.adjustOKLCH(func(lch *OKLCH) {
+		lch.C *= 1 - clampUnit(amount)
+	})
+}
+
+func (c *Color) adjustOKLCH(adjust func(lch *OKLCH)) *Color {
+	pc := new(PaletteCalculator)
+
+	lch := pc.ConvertOKLABToOKLCH(pc.ConvertRGBToOKLAB(c))
+	adjust(lch)
+
+	return pc.ConvertOKLCHToRGB(lch)
+}
+
+// Returns a copy of the color with its HSL hue rotated by degrees, negative degrees rotate counterclockwise.
+// Rotating by the scheme offsets gives the same colors as the scheme calculations
+func (c *Color) RotateHue(degrees float64) *Color {
+	pc := new(PaletteCalculator)
+
+	return pc.rotateHue(c, pc.ConvertRGBToHSL(c), math.Mod(math.Mod(degrees, 360)+360, 360))
+}
+
+// Method used to reduce a color to gray
+type GrayscaleMethod int
+
+const (
+	// Gray with the same WCAG relative luminance as the color
+	LuminosityGrayscale GrayscaleMethod = iota
+	// Mean of the red, green and blue channels
+	AverageGrayscale
+	// Midpoint of the lightest and darkest channels, i.e. HSL saturation set to 0
+	DesaturationGrayscale
+)
+
+// Returns the color with each channel inverted
+func (c *Color) Invert() *Color {
+	r, g, b := RGBMax-c.Red, RGBMax-c.Green, RGBMax-c.Blue
+
+	return &Color{Red: r, Green: g, Blue: b, Hex: hexString(r, g, b)}
+}
+
+// Returns the gray equivalent of the color using the given method
+func (c *Color) Grayscale(method GrayscaleMethod) *Color {
+	return new(PaletteCalculator).Grayscale(c, method)
+}
+
+// Converts the color to gray like Color.Grayscale, rounding under the calculator's rounding policy
+func (pc *PaletteCalculator) Grayscale(c *Color, method GrayscaleMethod) *Color {
+	var gray float64
+	switch method {
+	case AverageGrayscale:
+		gray = (c.Red + c.Green + c.Blue) / 3
+	case DesaturationGrayscale:
+		gray = (math.Max(c.Red, math.Max(c.Green, c.Blue)) + math.Min(c.Red, math.Min(c.Green, c.Blue))) / 2
+	default:
+		gray = delinearize(c.Luminance()) * RGBMax
+	}
+	gray = clampChannel(pc.round(gray, 0))
+
+	return &Color{Red: gray, Green: gray, Blue: gray, Hex: hexString(gray, gray, gray)}
+}
@@ -0,0 +1,91 @@
+package palettecalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestManipulate(t *testing.T) {
+	c := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+
+	for _, test := range []struct {
+		name          string
+		returnedColor *Color
+		expectedColor *Color
+	}{
+		{name: "should leave color unchanged for zero amount", returnedColor: c.Lighten(0), expectedColor: &Color{Red, Green, Blue, Hex}},
+		{name: "should lighten color", returnedColor: c.Lighten(.2), expectedColor: &Color{61, 130, 151, "3d8297"}},
+		{name: "should lighten color to white", returnedColor: c.Lighten(1), expectedColor: &Color{255, 255, 255, "ffffff"}},
+		{name: "should darken color", returnedColor: c.Darken(.2), expectedColor: &Color{0, 71, 89, "004759"}},
+		{name: "should darken color to black", returnedColor: c.Darken(1), expectedColor: &Color{0, 0, 0, "000000"}},
+		{name: "should saturate color within gamut", returnedColor: c.Saturate(5), expectedColor: &Color{0, 99, 122, "00637a"}},
+		{name: "should desaturate color", returnedColor: c.Desaturate(.5), expectedColor: &Color{65, 94, 104, "415e68"}},
+		{name: "should desaturate color to gray", returnedColor: c.Desaturate(1), expectedColor: &Color{89, 89, 89, "595959"}},
+		{name: "should chain adjustments", returnedColor: c.Lighten(.1).Desaturate(.2), expectedColor: &Color{61, 112, 129, "3d7081"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if !reflect.DeepEqual(test.expectedColor, test.returnedColor) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedColor, test.returnedColor)
+			}
+		})
+	}
+
+	if !reflect.DeepEqual(&Color{Red, Green, Blue, Hex}, c) {
+		t.Errorf("expected receiver to be unchanged, returned: %v\n", c)
+	}
+}
+
+func TestRotateHue(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		degrees       float64
+		expectedColor *Color
+	}{
+		{name: "should rotate to complimentary color", degrees: 180, expectedColor: &Color{119, 45, 24, "772d18"}},
+		{name: "should rotate counterclockwise for negative degrees", degrees: -30, expectedColor: &Color{24, 119, 92, "18775c"}},
+		{name: "should wrap rotations past a full turn", degrees: 720, expectedColor: &Color{Red, Green, Blue, Hex}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+
+			returnedColor := c.RotateHue(test.degrees)
+
+			if !reflect.DeepEqual(test.expectedColor, returnedColor) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedColor, returnedColor)
+			}
+		})
+	}
+}
+
+func TestInvert(t *testing.T) {
+	c := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+	expectedColor := &Color{231, 157, 136, "e79d88"}
+
+	returnedColor := c.Invert()
+
+	if !reflect.DeepEqual(expectedColor, returnedColor) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedColor, returnedColor)
+	}
+}
+
+func TestGrayscale(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		method        GrayscaleMethod
+		expectedColor *Color
+	}{
+		{name: "should convert to gray of equal luminance", method: LuminosityGrayscale, expectedColor: &Color{90, 90, 90, "5a5a5a"}},
+		{name: "should convert to average of channels", method: AverageGrayscale, expectedColor: &Color{80, 80, 80, "505050"}},
+		{name: "should convert by desaturating", method: DesaturationGrayscale, expectedColor: &Color{72, 72, 72, "484848"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+
+			returnedColor := c.Grayscale(test.method)
+
+			if !reflect.DeepEqual(test.expectedColor, returnedColor) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedColor, returnedColor)
+			}
+		})
+	}
+}
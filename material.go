@@ -0,0 +1,17 @@
+package palettecalculator
+
+// Tones of the Material Design 3 tonal palette, each tone is the CIELAB lightness of the color
+var MaterialTones = []int{0, 10, 20, 30, 40, 50, 60, 70, 80, 90, 95, 99, 100}
+
+// Calculates the Material Design 3 tonal palette of the dominant color. Returns map of tone to Color{}
+func (pc *PaletteCalculator) CalculateMaterialTonalPalette(dc *Color) map[int]Color {
+	tonalPalette := make(map[int]Color)
+
+	// keep hue and chroma of the dominant color, only lightness changes between tones
+	lch := pc.ConvertLABToLCH(pc.ConvertRGBToLAB(dc))
+	for _, tone := range MaterialTones {
+		tonalPalette[tone] = *pc.ConvertLCHToRGB(&LCH{L: float64(tone), C: lch.C, H: lch.H})
+	}
+
+	return tonalPalette
+}
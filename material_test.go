@@ -0,0 +1,33 @@
+package palettecalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCalculateMaterialTonalPalette(t *testing.T) {
+	dominantColor := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+	expectedTonalPalette := map[int]Color{
+		0:   {0, 0, 0, "000000"},
+		10:  {0, 31, 40, "001f28"},
+		20:  {0, 53, 67, "003543"},
+		30:  {0, 78, 96, "004e60"},
+		40:  {30, 102, 123, "1e667b"},
+		50:  {61, 127, 149, "3d7f95"},
+		60:  {89, 153, 176, "5999b0"},
+		70:  {117, 180, 203, "75b4cb"},
+		80:  {144, 208, 231, "90d0e7"},
+		90:  {182, 234, 255, "b6eaff"},
+		95:  {220, 245, 255, "dcf5ff"},
+		99:  {248, 253, 255, "f8fdff"},
+		100: {255, 255, 255, "ffffff"},
+	}
+	paletteCalculator := new(PaletteCalculator)
+
+	returnedTonalPalette := paletteCalculator.CalculateMaterialTonalPalette(dominantColor)
+
+	if !reflect.DeepEqual(expectedTonalPalette, returnedTonalPalette) {
+		t.Errorf("expected: %v\n returned %v\n", expectedTonalPalette, returnedTonalPalette)
+	}
+
+}
@@ -0,0 +1,133 @@
+package palettecalculator
+
+import (
+	"errors"
+	"sort"
+)
+
+const mergeMaxIterations = 100
+
+// Calculates a palette of at most k colors representing several palettes, using weighted k-means in LAB.
+// Each palette contributes its weight, split between its colors by their own weights, nil weights count every
+// palette equally. The merged colors are ordered by weight, which is their share of the total
+func (pc *PaletteCalculator) MergePalettes(palettes []Palette, weights []float64, k int) (*Palette, error) {
+	if weights != nil && len(weights) != len(palettes) {
+		return nil, errors.New("number of weights must match number of palettes")
+	}
+
+	var labs []*LAB
+	var sampleWeights []float64
+	for i := range palettes {
+		paletteWeight := float64(1)
+		if weights != nil {
+			paletteWeight = weights[i]
+		}
+
+		total := float64(0)
+		for j := range palettes[i].Colors {
+			total += palettes[i].weight(j)
+		}
+		for j := range palettes[i].Colors {
+			if total == 0 {
+				continue
+			}
+			labs = append(labs, pc.ConvertRGBToLAB(&palettes[i].Colors[j]))
+			sampleWeights = append(sampleWeights, paletteWeight*palettes[i].weight(j)/total)
+		}
+	}
+	if k < 1 || len(labs) == 0 {
+		return &Palette{}, nil
+	}
+
+	centroids := pc.seedCentroids(labs, sampleWeights, k)
+	assignments := make([]int, len(labs))
+	for iteration := 0; iteration < mergeMaxIterations; iteration++ {
+		changed := iteration == 0
+		for i := range labs {
+			nearest := 0
+			for c := range centroids {
+				if pc.deltaE76LAB(labs[i], centroids[c]) < pc.deltaE76LAB(labs[i], centroids[nearest]) {
+					nearest = c
+				}
+			}
+			if assignments[i] != nearest {
+				assignments[i], changed = nearest, true
+			}
+		}
+		if !changed {
+			break
+		}
+
+		for c := range centroids {
+			sum, total := &LAB{}, float64(0)
+			for i := range labs {
+				if assignments[i] == c {
+					sum.L += labs[i].L * sampleWeights[i]
+					sum.A += labs[i].A * sampleWeights[i]
+					sum.B += labs[i].B * sampleWeights[i]
+					total += sampleWeights[i]
+				}
+			}
+			if total > 0 {
+				centroids[c] = &LAB{L: sum.L / total, A: sum.A / total, B: sum.B / total}
+			}
+		}
+	}
+
+	clusterWeights := make([]float64, len(centroids))
+	total := float64(0)
+	for i := range labs {
+		clusterWeights[assignments[i]] += sampleWeights[i]
+		total += sampleWeights[i]
+	}
+
+	var clusters []int
+	for c := range centroids {
+		if clusterWeights[c] > 0 {
+			clusters = append(clusters, c)
+		}
+	}
+	sort.SliceStable(clusters, func(i, j int) bool { return clusterWeights[clusters[i]] > clusterWeights[clusters[j]] })
+
+	merged := &Palette{}
+	for _, c := range clusters {
+		merged.Colors = append(merged.Colors, *pc.ConvertLABToRGB(centroids[c]))
+		merged.Weights = append(merged.Weights, clusterWeights[c]/total)
+	}
+
+	return merged, nil
+}
+
+// Deterministic k-means++ seeding, starting from the heaviest sample and repeatedly taking the sample with the
+// largest weighted squared distance to its nearest centroid
+func (pc *PaletteCalculator) seedCentroids(labs []*LAB, weights []float64, k int) []*LAB {
+	heaviest := 0
+	for i := range weights {
+		if weights[i] > weights[heaviest] {
+			heaviest = i
+		}
+	}
+	centroids := []*LAB{labs[heaviest]}
+
+	for len(centroids) < k {
+		farthest, farthestScore := -1, float64(0)
+		for i := range labs {
+			nearest := pc.deltaE76LAB(labs[i], centroids[0])
+			for _, c := range centroids[1:] {
+				if d := pc.deltaE76LAB(labs[i], c); d < nearest {
+					nearest = d
+				}
+			}
+			if score := weights[i] * nearest * nearest; score > farthestScore {
+				farthest, farthestScore = i, score
+			}
+		}
+		// fewer distinct colors than k
+		if farthest == -1 {
+			break
+		}
+		centroids = append(centroids, labs[farthest])
+	}
+
+	return centroids
+}
@@ -0,0 +1,64 @@
+package palettecalculator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMergePalettes(t *testing.T) {
+	first := Palette{Colors: []Color{{Red, Green, Blue, Hex}, {119, 45, 24, "772d18"}}, Weights: []float64{3, 1}}
+	second := Palette{Colors: []Color{{26, 100, 120, "1a6478"}, {120, 47, 24, "782f18"}, {255, 255, 255, "ffffff"}}}
+
+	for _, test := range []struct {
+		name            string
+		palettes        []Palette
+		weights         []float64
+		k               int
+		expectedPalette *Palette
+		expectedErr     error
+	}{
+		{
+			name:     "should cluster weighted palettes into k colors",
+			palettes: []Palette{first, second},
+			weights:  []float64{1, 3},
+			k:        3,
+			expectedPalette: &Palette{
+				Colors:  []Color{{25, 99, 120, "196378"}, {120, 47, 24, "782f18"}, {255, 255, 255, "ffffff"}},
+				Weights: []float64{.4375, .3125, .25},
+			},
+		},
+		{
+			name:            "should return fewer colors than k when palettes are small",
+			palettes:        []Palette{first},
+			k:               5,
+			expectedPalette: &Palette{Colors: []Color{{Red, Green, Blue, Hex}, {119, 45, 24, "772d18"}}, Weights: []float64{.75, .25}},
+		},
+		{
+			name:            "should return empty palette when there is nothing to merge",
+			k:               5,
+			expectedPalette: &Palette{},
+		},
+		{
+			name:        "error occurs when weights do not match palettes",
+			palettes:    []Palette{first},
+			weights:     []float64{1, 2},
+			k:           5,
+			expectedErr: errors.New("number of weights must match number of palettes"),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			pc := &PaletteCalculator{}
+
+			returnedPalette, err := pc.MergePalettes(test.palettes, test.weights, test.k)
+
+			if !reflect.DeepEqual(test.expectedPalette, returnedPalette) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedPalette, returnedPalette)
+			}
+
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
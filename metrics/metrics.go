@@ -0,0 +1,179 @@
+// Package metrics counts extractions, their latency by backend, cache lookups and errors, and exposes them in the
+// Prometheus text format.
+//
+// Services report to an Observer. Collector is an Observer serving /metrics for Prometheus to scrape without the
+// Prometheus client library, implement Observer yourself to report to another metrics system.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Receives the events of a service, safe for concurrent use
+type Observer interface {
+	// An extraction with backend, local or vision, that took duration whether or not it failed
+	ObserveExtraction(backend string, duration time.Duration)
+	// A lookup of a cached palette
+	ObserveCacheLookup(hit bool)
+	// A failed request by its type, e.g. bad_request or upstream
+	ObserveError(errorType string)
+}
+
+// Upper bounds in seconds of the extraction duration histogram buckets
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Observer keeping the metrics in memory and serving them in the Prometheus text format
+type Collector struct {
+	// Upper bounds of the duration histogram buckets in seconds, nil uses DefaultBuckets. Set before the first
+	// observation
+	Buckets []float64
+
+	mu          sync.Mutex
+	extractions map[string]*histogram
+	cacheHits   uint64
+	cacheMisses uint64
+	errors      map[string]uint64
+}
+
+// Durations of one backend, cumulative counts of each bucket are computed when written
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+func (c *Collector) ObserveExtraction(backend string, duration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.extractions == nil {
+		c.extractions = make(map[string]*histogram)
+	}
+	h, ok := c.extractions[backend]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(c.buckets()))}
+		c.extractions[backend] = h
+	}
+
+	seconds := duration.Seconds()
+	i := sort.SearchFloat64s(c.buckets(), seconds)
+	if i < len(h.counts) {
+		h.counts[i]++
+	}
+	h.count++
+	h.sum += seconds
+}
+
+func (c *Collector) ObserveCacheLookup(hit bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if hit {
+		c.cacheHits++
+	} else {
+		c.cacheMisses++
+	}
+}
+
+func (c *Collector) ObserveError(errorType string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.errors == nil {
+		c.errors = make(map[string]uint64)
+	}
+	c.errors[errorType]++
+}
+
+// Serves the metrics in the Prometheus text format
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.WriteTo(w)
+}
+
+// Writes the metrics in the Prometheus text format
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ew := &errWriter{w: w}
+	ew.printf("# HELP palettecalc_extractions_total Palettes extracted, including failed extractions.\n")
+	ew.printf("# TYPE palettecalc_extractions_total counter\n")
+	backends := make([]string, 0, len(c.extractions))
+	for backend := range c.extractions {
+		backends = append(backends, backend)
+	}
+	sort.Strings(backends)
+	for _, backend := range backends {
+		ew.printf("palettecalc_extractions_total{backend=%q} %d\n", backend, c.extractions[backend].count)
+	}
+
+	ew.printf("# HELP palettecalc_extraction_duration_seconds Time taken by extractions, by backend.\n")
+	ew.printf("# TYPE palettecalc_extraction_duration_seconds histogram\n")
+	for _, backend := range backends {
+		h := c.extractions[backend]
+		var cumulative uint64
+		for i, bound := range c.buckets() {
+			cumulative += h.counts[i]
+			ew.printf("palettecalc_extraction_duration_seconds_bucket{backend=%q,le=%q} %d\n", backend, formatFloat(bound), cumulative)
+		}
+		ew.printf("palettecalc_extraction_duration_seconds_bucket{backend=%q,le=\"+Inf\"} %d\n", backend, h.count)
+		ew.printf("palettecalc_extraction_duration_seconds_sum{backend=%q} %s\n", backend, formatFloat(h.sum))
+		ew.printf("palettecalc_extraction_duration_seconds_count{backend=%q} %d\n", backend, h.count)
+	}
+
+	ew.printf("# HELP palettecalc_cache_lookups_total Lookups of cached palettes, by result.\n")
+	ew.printf("# TYPE palettecalc_cache_lookups_total counter\n")
+	ew.printf("palettecalc_cache_lookups_total{result=\"hit\"} %d\n", c.cacheHits)
+	ew.printf("palettecalc_cache_lookups_total{result=\"miss\"} %d\n", c.cacheMisses)
+
+	ew.printf("# HELP palettecalc_errors_total Failed requests, by error type.\n")
+	ew.printf("# TYPE palettecalc_errors_total counter\n")
+	errorTypes := make([]string, 0, len(c.errors))
+	for errorType := range c.errors {
+		errorTypes = append(errorTypes, errorType)
+	}
+	sort.Strings(errorTypes)
+	for _, errorType := range errorTypes {
+		ew.printf("palettecalc_errors_total{type=%q} %d\n", errorType, c.errors[errorType])
+	}
+
+	return ew.n, ew.err
+}
+
+func (c *Collector) buckets() []float64 {
+	if c.Buckets != nil {
+		return c.Buckets
+	}
+	return DefaultBuckets
+}
+
+// Writes until the first error, which WriteTo returns
+type errWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (ew *errWriter) printf(format string, args ...interface{}) {
+	if ew.err != nil {
+		return
+	}
+	n, err := fmt.Fprintf(ew.w, format, args...)
+	ew.n += int64(n)
+	ew.err = err
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCollector(t *testing.T) {
+	c := NewCollector()
+	c.Buckets = []float64{0.1, 1}
+	c.ObserveExtraction("vision", 50*time.Millisecond)
+	c.ObserveExtraction("vision", 500*time.Millisecond)
+	c.ObserveExtraction("vision", 2*time.Second)
+	c.ObserveExtraction("local", 10*time.Millisecond)
+	c.ObserveCacheLookup(true)
+	c.ObserveCacheLookup(false)
+	c.ObserveCacheLookup(false)
+	c.ObserveError("upstream")
+
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if contentType := rec.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/plain; version=0.0.4") {
+		t.Errorf("expected: %v\n returned: %v\n", "text/plain; version=0.0.4", contentType)
+	}
+
+	expected := []string{
+		`palettecalc_extractions_total{backend="local"} 1`,
+		`palettecalc_extractions_total{backend="vision"} 3`,
+		`palettecalc_extraction_duration_seconds_bucket{backend="vision",le="0.1"} 1`,
+		`palettecalc_extraction_duration_seconds_bucket{backend="vision",le="1"} 2`,
+		`palettecalc_extraction_duration_seconds_bucket{backend="vision",le="+Inf"} 3`,
+		`palettecalc_extraction_duration_seconds_sum{backend="vision"} 2.55`,
+		`palettecalc_extraction_duration_seconds_count{backend="vision"} 3`,
+		`palettecalc_cache_lookups_total{result="hit"} 1`,
+		`palettecalc_cache_lookups_total{result="miss"} 2`,
+		`palettecalc_errors_total{type="upstream"} 1`,
+		`# TYPE palettecalc_extraction_duration_seconds histogram`,
+	}
+	lines := strings.Split(rec.Body.String(), "\n")
+	for _, line := range expected {
+		found := false
+		for _, returned := range lines {
+			found = found || returned == line
+		}
+		if !found {
+			t.Errorf("expected: %v\n returned: %v\n", line, rec.Body.String())
+		}
+	}
+}
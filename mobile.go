@@ -0,0 +1,186 @@
+package palettecalculator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// Named color of a native export, with an optional dark mode variant
+type nativeColor struct {
+	name  string
+	light Color
+	dark  *Color
+}
+
+// Asset catalog Contents.json of a colorset
+type colorsetContents struct {
+	Colors []colorsetColor `json:"colors"`
+	Info   assetInfo       `json:"info"`
+}
+
+type colorsetColor struct {
+	Appearances []assetAppearance `json:"appearances,omitempty"`
+	Color       colorsetValue     `json:"color"`
+	Idiom       string            `json:"idiom"`
+}
+
+type assetAppearance struct {
+	Appearance string `json:"appearance"`
+	Value      string `json:"value"`
+}
+
+type colorsetValue struct {
+	ColorSpace string            `json:"color-space"`
+	Components map[string]string `json:"components"`
+}
+
+type assetInfo struct {
+	Author  string `json:"author"`
+	Version int    `json:"version"`
+}
+
+// Writes the palette as an Xcode asset catalog at dir, e.g. Colors.xcassets, with one colorset per color. Colors
+// are named by namer, a nil namer names them color-1, color-2, ...
+func (pc *PaletteCalculator) WriteAssetCatalog(dir string, p *Palette, namer ColorNamer) error {
+	var colors []nativeColor
+	for i, name := range pc.colorNames(p, namer) {
+		colors = append(colors, nativeColor{name: name, light: p.Colors[i]})
+	}
+
+	return pc.writeAssetCatalog(dir, colors)
+}
+
+// Writes the theme as an Xcode asset catalog at dir with one colorset per token, e.g. on-primary.colorset, each
+// holding the light color and its dark appearance
+func (pc *PaletteCalculator) WriteThemeAssetCatalog(dir string, theme *Theme) error {
+	return pc.writeAssetCatalog(dir, pc.themeNativeColors(theme))
+}
+
+// Writes the palette as an Android res/values/colors.xml. Colors are named by namer, a nil namer names them
+// color_1, color_2, ..., names are lowercased with other characters than letters, digits and _ replaced by _
+func (pc *PaletteCalculator) WriteAndroidColors(w io.Writer, p *Palette, namer ColorNamer) error {
+	var colors []nativeColor
+	for i, name := range pc.colorNames(p, namer) {
+		colors = append(colors, nativeColor{name: name, light: p.Colors[i]})
+	}
+
+	return pc.writeAndroidColors(w, colors, false)
+}
+
+// Writes the theme tokens as Android colors.xml files, light to res/values and dark to res/values-night, so
+// the system picks the variant for the device's dark mode
+func (pc *PaletteCalculator) WriteAndroidThemeColors(light io.Writer, dark io.Writer, theme *Theme) error {
+	colors := pc.themeNativeColors(theme)
+	if err := pc.writeAndroidColors(light, colors, false); err != nil {
+		return err
+	}
+
+	return pc.writeAndroidColors(dark, colors, true)
+}
+
+func (pc *PaletteCalculator) themeNativeColors(theme *Theme) []nativeColor {
+	var colors []nativeColor
+	for _, token := range []struct {
+		name  string
+		light Color
+		dark  Color
+	}{
+		{"primary", theme.Light.Primary, theme.Dark.Primary},
+		{"on-primary", theme.Light.OnPrimary, theme.Dark.OnPrimary},
+		{"surface", theme.Light.Surface, theme.Dark.Surface},
+		{"background", theme.Light.Background, theme.Dark.Background},
+		{"border", theme.Light.Border, theme.Dark.Border},
+		{"error", theme.Light.Error, theme.Dark.Error},
+	} {
+		dark := token.dark
+		colors = append(colors, nativeColor{name: token.name, light: token.light, dark: &dark})
+	}
+
+	return colors
+}
+
+func (pc *PaletteCalculator) writeAssetCatalog(dir string, colors []nativeColor) error {
+	info := assetInfo{Author: "xcode", Version: 1}
+	if err := writeAssetContents(dir, struct {
+		Info assetInfo `json:"info"`
+	}{info}); err != nil {
+		return err
+	}
+
+	for _, c := range colors {
+		contents := colorsetContents{Colors: []colorsetColor{{Color: colorsetComponents(&c.light), Idiom: "universal"}}, Info: info}
+		if c.dark != nil {
+			contents.Colors = append(contents.Colors, colorsetColor{
+				Appearances: []assetAppearance{{Appearance: "luminosity", Value: "dark"}},
+				Color:       colorsetComponents(c.dark),
+				Idiom:       "universal",
+			})
+		}
+
+		name := strings.NewReplacer("/", "-", "\\", "-").Replace(c.name)
+		if err := writeAssetContents(filepath.Join(dir, name+".colorset"), contents); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeAssetContents(dir string, contents interface{}) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(contents, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, "Contents.json"), append(data, '\n'), 0644)
+}
+
+func colorsetComponents(c *Color) colorsetValue {
+	clamped := c.Clamp()
+	return colorsetValue{
+		ColorSpace: "srgb",
+		Components: map[string]string{
+			"red":   fmt.Sprintf("0x%02X", int(clamped.Red)),
+			"green": fmt.Sprintf("0x%02X", int(clamped.Green)),
+			"blue":  fmt.Sprintf("0x%02X", int(clamped.Blue)),
+			"alpha": "1.000",
+		},
+	}
+}
+
+func (pc *PaletteCalculator) writeAndroidColors(w io.Writer, colors []nativeColor, dark bool) error {
+	var sb strings.Builder
+
+	sb.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<resources>\n")
+	for _, c := range colors {
+		value := &c.light
+		if dark && c.dark != nil {
+			value = c.dark
+		}
+		hex := strings.ToUpper(strings.TrimPrefix(cssHex(value), "#"))
+		sb.WriteString(fmt.Sprintf("    <color name=\"%s\">#FF%s</color>\n", androidResourceName(c.name), hex))
+	}
+	sb.WriteString("</resources>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// Android resource names are lowercase letters, digits and _
+func androidResourceName(name string) string {
+	return strings.Map(func(r rune) rune {
+		r = unicode.ToLower(r)
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
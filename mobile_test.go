@@ -0,0 +1,114 @@
+package palettecalculator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteAssetCatalog(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "Colors.xcassets")
+	palette := &Palette{Colors: []Color{{24, 98, 119, "186277"}}}
+	expected := `{
+  "colors": [
+    {
+      "color": {
+        "color-space": "srgb",
+        "components": {
+          "alpha": "1.000",
+          "blue": "0x77",
+          "green": "0x62",
+          "red": "0x18"
+        }
+      },
+      "idiom": "universal"
+    }
+  ],
+  "info": {
+    "author": "xcode",
+    "version": 1
+  }
+}
+`
+	paletteCalculator := new(PaletteCalculator)
+
+	err := paletteCalculator.WriteAssetCatalog(dir, palette, nil)
+
+	if err != nil {
+		t.Fatalf("expected error: %v returned error: %v", nil, err)
+	}
+	returned, err := os.ReadFile(filepath.Join(dir, "color-1.colorset", "Contents.json"))
+	if err != nil {
+		t.Fatalf("expected error: %v returned error: %v", nil, err)
+	}
+	if expected != string(returned) {
+		t.Errorf("expected: %v\n returned: %v\n", expected, string(returned))
+	}
+	if _, err := os.Stat(filepath.Join(dir, "Contents.json")); err != nil {
+		t.Errorf("expected catalog Contents.json returned error: %v", err)
+	}
+}
+
+func TestWriteThemeAssetCatalog(t *testing.T) {
+	dir := t.TempDir()
+	paletteCalculator := new(PaletteCalculator)
+	theme := paletteCalculator.GenerateTheme(&Color{Red, Green, Blue, Hex})
+
+	err := paletteCalculator.WriteThemeAssetCatalog(dir, theme)
+
+	if err != nil {
+		t.Fatalf("expected error: %v returned error: %v", nil, err)
+	}
+	for _, token := range []string{"primary", "on-primary", "surface", "background", "border", "error"} {
+		returned, err := os.ReadFile(filepath.Join(dir, token+".colorset", "Contents.json"))
+		if err != nil {
+			t.Errorf("expected error: %v returned error: %v", nil, err)
+		}
+		if !strings.Contains(string(returned), `"appearance": "luminosity"`) || !strings.Contains(string(returned), `"value": "dark"`) {
+			t.Errorf("expected %s dark appearance\n returned: %v\n", token, string(returned))
+		}
+	}
+}
+
+func TestWriteAndroidColors(t *testing.T) {
+	palette := &Palette{Colors: []Color{{24, 98, 119, "186277"}, {14, 60, 73, "0e3c49"}}}
+	namer := func(i int, c *Color) string { return []string{"Brand Primary", "brand-dark"}[i] }
+	expected := "<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<resources>\n" +
+		"    <color name=\"brand_primary\">#FF186277</color>\n" +
+		"    <color name=\"brand_dark\">#FF0E3C49</color>\n" +
+		"</resources>\n"
+	paletteCalculator := new(PaletteCalculator)
+	var buf bytes.Buffer
+
+	err := paletteCalculator.WriteAndroidColors(&buf, palette, namer)
+
+	if err != nil {
+		t.Errorf("expected error: %v returned error: %v", nil, err)
+	}
+	if expected != buf.String() {
+		t.Errorf("expected: %v\n returned: %v\n", expected, buf.String())
+	}
+}
+
+func TestWriteAndroidThemeColors(t *testing.T) {
+	paletteCalculator := new(PaletteCalculator)
+	theme := paletteCalculator.GenerateTheme(&Color{Red, Green, Blue, Hex})
+	var light, dark bytes.Buffer
+
+	err := paletteCalculator.WriteAndroidThemeColors(&light, &dark, theme)
+
+	if err != nil {
+		t.Errorf("expected error: %v returned error: %v", nil, err)
+	}
+	for _, test := range []struct {
+		xml    string
+		tokens ThemeTokens
+	}{{light.String(), theme.Light}, {dark.String(), theme.Dark}} {
+		expected := "<color name=\"on_primary\">#FF" + strings.ToUpper(cssHex(&test.tokens.OnPrimary)[1:]) + "</color>"
+		if !strings.Contains(test.xml, expected) {
+			t.Errorf("expected xml to contain: %s\n returned: %s\n", expected, test.xml)
+		}
+	}
+}
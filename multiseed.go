@@ -0,0 +1,61 @@
+package palettecalculator
+
+import (
+	"math"
+	"sort"
+)
+
+// Calculates a palette of k colors harmonizing several seed colors. Seeds are kept as given and remaining colors
+// fill the widest hue gaps between them, taking saturation and luminosity from their neighbouring seeds.
+// Returns array of max(k, len(seeds)) Color{}
+func (pc *PaletteCalculator) CalculateMultiSeedColorScheme(seeds []Color, k int) []Color {
+	colors := append([]Color(nil), seeds...)
+
+	// achromatic seeds have no hue to build around
+	var wheel []*HSL
+	for i := range seeds {
+		if hsl := pc.ConvertRGBToHSL(&seeds[i]); hsl.Saturation > 0 {
+			wheel = append(wheel, hsl)
+		}
+	}
+	if len(wheel) == 0 {
+		return colors
+	}
+	sort.SliceStable(wheel, func(i, j int) bool { return wheel[i].Hue < wheel[j].Hue })
+
+	// seeds sharing a hue leave no gap between them, keep the first of each
+	distinct := wheel[:1]
+	for _, hsl := range wheel[1:] {
+		if hsl.Hue != distinct[len(distinct)-1].Hue {
+			distinct = append(distinct, hsl)
+		}
+	}
+	wheel = distinct
+
+	for len(colors) < k {
+		// find widest gap between hue neighbours, wrapping around the wheel
+		widest, widestGap := 0, float64(-1)
+		for i := range wheel {
+			next := wheel[(i+1)%len(wheel)]
+			gap := math.Mod(next.Hue-wheel[i].Hue+360, 360)
+			if len(wheel) == 1 {
+				gap = 360
+			}
+			if gap > widestGap {
+				widest, widestGap = i, gap
+			}
+		}
+
+		prev, next := wheel[widest], wheel[(widest+1)%len(wheel)]
+		filler := &HSL{
+			Hue:        pc.round(math.Mod(prev.Hue+widestGap/2, 360), 0),
+			Saturation: pc.round((prev.Saturation+next.Saturation)/2, 2),
+			Luminosity: pc.round((prev.Luminosity+next.Luminosity)/2, 2),
+		}
+
+		wheel = append(wheel[:widest+1], append([]*HSL{filler}, wheel[widest+1:]...)...)
+		colors = append(colors, *pc.ConvertHSLToRGB(filler))
+	}
+
+	return colors
+}
@@ -0,0 +1,50 @@
+package palettecalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCalculateMultiSeedColorScheme(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		seeds       []Color
+		k           int
+		expectedRGB []Color
+	}{
+		{
+			name:        "should fill widest hue gaps between seeds",
+			seeds:       []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {119, 45, 24, "772d18"}},
+			k:           4,
+			expectedRGB: []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {119, 45, 24, "772d18"}, {51, 119, 24, "337718"}, {92, 24, 119, "5c1877"}},
+		},
+		{
+			name:        "should keep achromatic seeds without building around them",
+			seeds:       []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {200, 200, 200, "c8c8c8"}},
+			k:           3,
+			expectedRGB: []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {200, 200, 200, "c8c8c8"}, {119, 45, 24, "772d18"}},
+		},
+		{
+			name:        "should fill between distinct hues when seeds share one",
+			seeds:       []Color{{255, 0, 0, "ff0000"}, {128, 0, 0, "800000"}, {0, 255, 255, "00ffff"}},
+			k:           4,
+			expectedRGB: []Color{{255, 0, 0, "ff0000"}, {128, 0, 0, "800000"}, {0, 255, 255, "00ffff"}, {133, 255, 0, "85ff00"}},
+		},
+		{
+			name:        "should return seeds when k does not exceed them",
+			seeds:       []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {119, 45, 24, "772d18"}},
+			k:           1,
+			expectedRGB: []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {119, 45, 24, "772d18"}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedRGB := paletteCalculator.CalculateMultiSeedColorScheme(test.seeds, test.k)
+
+			if !reflect.DeepEqual(test.expectedRGB, returnedRGB) {
+				t.Errorf("expected: %v\n returned %v\n", test.expectedRGB, returnedRGB)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package palettecalculator
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// Color with its CSS Color Module Level 4 / X11 name
+type NamedColor struct {
+	Name  string `json:"name"`
+	Color Color  `json:"color"`
+}
+
+// CSS Color Module Level 4 named colors, which include the X11 names, in alphabetical order
+var CSSNamedColors = []NamedColor{
+	{"aliceblue", Color{240, 248, 255, "f0f8ff"}},
+	{"antiquewhite", Color{250, 235, 215, "faebd7"}},
+	{"aqua", Color{0, 255, 255, "00ffff"}},
+	{"aquamarine", Color{127, 255, 212, "7fffd4"}},
+	{"azure", Color{240, 255, 255, "f0ffff"}},
+	{"beige", Color{245, 245, 220, "f5f5dc"}},
+	{"bisque", Color{255, 228, 196, "ffe4c4"}},
+	{"black", Color{0, 0, 0, "000000"}},
+	{"blanchedalmond", Color{255, 235, 205, "ffebcd"}},
+	{"blue", Color{0, 0, 255, "0000ff"}},
+	{"blueviolet", Color{138, 43, 226, "8a2be2"}},
+	{"brown", Color{165, 42, 42, "a52a2a"}},
+	{"burlywood", Color{222, 184, 135, "deb887"}},
+	{"cadetblue", Color{95, 158, 160, "5f9ea0"}},
+	{"chartreuse", Color{127, 255, 0, "7fff00"}},
+	{"chocolate", Color{210, 105, 30, "d2691e"}},
+	{"coral", Color{255, 127, 80, "ff7f50"}},
+	{"cornflowerblue", Color{100, 149, 237, "6495ed"}},
+	{"cornsilk", Color{255, 248, 220, "fff8dc"}},
+	{"crimson", Color{220, 20, 60, "dc143c"}},
+	{"cyan", Color{0, 255, 255, "00ffff"}},
+	{"darkblue", Color{0, 0, 139, "00008b"}},
+	{"darkcyan", Color{0, 139, 139, "008b8b"}},
+	{"darkgoldenrod", Color{184, 134, 11, "b8860b"}},
+	{"darkgray", Color{169, 169, 169, "a9a9a9"}},
+	{"darkgreen", Color{0, 100, 0, "006400"}},
+	{"darkgrey", Color{169, 169, 169, "a9a9a9"}},
+	{"darkkhaki", Color{189, 183, 107, "bdb76b"}},
+	{"darkmagenta", Color{139, 0, 139, "8b008b"}},
+	{"darkolivegreen", Color{85, 107, 47, "556b2f"}},
+	{"darkorange", Color{255, 140, 0, "ff8c00"}},
+	{"darkorchid", Color{153, 50, 204, "9932cc"}},
+	{"darkred", Color{139, 0, 0, "8b0000"}},
+	{"darksalmon", Color{233, 150, 122, "e9967a"}},
+	{"darkseagreen", Color{143, 188, 143, "8fbc8f"}},
+	{"darkslateblue", Color{72, 61, 139, "483d8b"}},
+	{"darkslategray", Color{47, 79, 79, "2f4f4f"}},
+	{"darkslategrey", Color{47, 79, 79, "2f4f4f"}},
+	{"darkturquoise", Color{0, 206, 209, "00ced1"}},
+	{"darkviolet", Color{148, 0, 211, "9400d3"}},
+	{"deeppink", Color{255, 20, 147, "ff1493"}},
+	{"deepskyblue", Color{0, 191, 255, "00bfff"}},
+	{"dimgray", Color{105, 105, 105, "696969"}},
+	{"dimgrey", Color{105, 105, 105, "696969"}},
+	{"dodgerblue", Color{30, 144, 255, "1e90ff"}},
+	{"firebrick", Color{178, 34, 34, "b22222"}},
+	{"floralwhite", Color{255, 250, 240, "fffaf0"}},
+	{"forestgreen", Color{34, 139, 34, "228b22"}},
+	{"fuchsia", Color{255, 0, 255, "ff00ff"}},
+	{"gainsboro", Color{220, 220, 220, "dcdcdc"}},
+	{"ghostwhite", Color{248, 248, 255, "f8f8ff"}},
+	{"gold", Color{255, 215, 0, "ffd700"}},
+	{"goldenrod", Color{218, 165, 32, "daa520"}},
+	{"gray", Color{128, 128, 128, "808080"}},
+	{"green", Color{0, 128, 0, "008000"}},
+	{"greenyellow", Color{173, 255, 47, "adff2f"}},
+	{"grey", Color{128, 128, 128, "808080"}},
+	{"honeydew", Color{240, 255, 240, "f0fff0"}},
+	{"hotpink", Color{255, 105, 180, "ff69b4"}},
+	{"indianred", Color{205, 92, 92, "cd5c5c"}},
+	{"indigo", Color{75, 0, 130, "4b0082"}},
+	{"ivory", Color{255, 255, 240, "fffff0"}},
+	{"khaki", Color{240, 230, 140, "f0e68c"}},
+	{"lavender", Color{230, 230, 250, "e6e6fa"}},
+	{"lavenderblush", Color{255, 240, 245, "fff0f5"}},
+	{"lawngreen", Color{124, 252, 0, "7cfc00"}},
+	{"lemonchiffon", Color{255, 250, 205, "fffacd"}},
+	{"lightblue", Color{173, 216, 230, "add8e6"}},
+	{"lightcoral", Color{240, 128, 128, "f08080"}},
+	{"lightcyan", Color{224, 255, 255, "e0ffff"}},
+	{"lightgoldenrodyellow", Color{250, 250, 210, "fafad2"}},
+	{"lightgray", Color{211, 211, 211, "d3d3d3"}},
+	{"lightgreen", Color{144, 238, 144, "90ee90"}},
+	{"lightgrey", Color{211, 211, 211, "d3d3d3"}},
+	{"lightpink", Color{255, 182, 193, "ffb6c1"}},
+	{"lightsalmon", Color{255, 160, 122, "ffa07a"}},
+	{"lightseagreen", Color{32, 178, 170, "20b2aa"}},
+	{"lightskyblue", Color{135, 206, 250, "87cefa"}},
+	{"lightslategray", Color{119, 136, 153, "778899"}},
+	{"lightslategrey", Color{119, 136, 153, "778899"}},
+	{"lightsteelblue", Color{176, 196, 222, "b0c4de"}},
+	{"lightyellow", Color{255, 255, 224, "ffffe0"}},
+	{"lime", Color{0, 255, 0, "00ff00"}},
+	{"limegreen", Color{50, 205, 50, "32cd32"}},
+	{"linen", Color{250, 240, 230, "faf0e6"}},
+	{"magenta", Color{255, 0, 255, "ff00ff"}},
+	{"maroon", Color{128, 0, 0, "800000"}},
+	{"mediumaquamarine", Color{102, 205, 170, "66cdaa"}},
+	{"mediumblue", Color{0, 0, 205, "0000cd"}},
+	{"mediumorchid", Color{186, 85, 211, "ba55d3"}},
+	{"mediumpurple", Color{147, 112, 219, "9370db"}},
+	{"mediumseagreen", Color{60, 179, 113, "3cb371"}},
+	{"mediumslateblue", Color{123, 104, 238, "7b68ee"}},
+	{"mediumspringgreen", Color{0, 250, 154, "00fa9a"}},
+	{"mediumturquoise", Color{72, 209, 204, "48d1cc"}},
+	{"mediumvioletred", Color{199, 21, 133, "c71585"}},
+	{"midnightblue", Color{25, 25, 112, "191970"}},
+	{"mintcream", Color{245, 255, 250, "f5fffa"}},
+	{"mistyrose", Color{255, 228, 225, "ffe4e1"}},
+	{"moccasin", Color{255, 228, 181, "ffe4b5"}},
+	{"navajowhite", Color{255, 222, 173, "ffdead"}},
+	{"navy", Color{0, 0, 128, "000080"}},
+	{"oldlace", Color{253, 245, 230, "fdf5e6"}},
+	{"olive", Color{128, 128, 0, "808000"}},
+	{"olivedrab", Color{107, 142, 35, "6b8e23"}},
+	{"orange", Color{255, 165, 0, "ffa500"}},
+	{"orangered", Color{255, 69, 0, "ff4500"}},
+	{"orchid", Color{218, 112, 214, "da70d6"}},
+	{"palegoldenrod", Color{238, 232, 170, "eee8aa"}},
+	{"palegreen", Color{152, 251, 152, "98fb98"}},
+	{"paleturquoise", Color{175, 238, 238, "afeeee"}},
+	{"palevioletred", Color{219, 112, 147, "db7093"}},
+	{"papayawhip", Color{255, 239, 213, "ffefd5"}},
+	{"peachpuff", Color{255, 218, 185, "ffdab9"}},
+	{"peru", Color{205, 133, 63, "cd853f"}},
+	{"pink", Color{255, 192, 203, "ffc0cb"}},
+	{"plum", Color{221, 160, 221, "dda0dd"}},
+	{"powderblue", Color{176, 224, 230, "b0e0e6"}},
+	{"purple", Color{128, 0, 128, "800080"}},
+	{"rebeccapurple", Color{102, 51, 153, "663399"}},
+	{"red", Color{255, 0, 0, "ff0000"}},
+	{"rosybrown", Color{188, 143, 143, "bc8f8f"}},
+	{"royalblue", Color{65, 105, 225, "4169e1"}},
+	{"saddlebrown", Color{139, 69, 19, "8b4513"}},
+	{"salmon", Color{250, 128, 114, "fa8072"}},
+	{"sandybrown", Color{244, 164, 96, "f4a460"}},
+	{"seagreen", Color{46, 139, 87, "2e8b57"}},
+	{"seashell", Color{255, 245, 238, "fff5ee"}},
+	{"sienna", Color{160, 82, 45, "a0522d"}},
+	{"silver", Color{192, 192, 192, "c0c0c0"}},
+	{"skyblue", Color{135, 206, 235, "87ceeb"}},
+	{"slateblue", Color{106, 90, 205, "6a5acd"}},
+	{"slategray", Color{112, 128, 144, "708090"}},
+	{"slategrey", Color{112, 128, 144, "708090"}},
+	{"snow", Color{255, 250, 250, "fffafa"}},
+	{"springgreen", Color{0, 255, 127, "00ff7f"}},
+	{"steelblue", Color{70, 130, 180, "4682b4"}},
+	{"tan", Color{210, 180, 140, "d2b48c"}},
+	{"teal", Color{0, 128, 128, "008080"}},
+	{"thistle", Color{216, 191, 216, "d8bfd8"}},
+	{"tomato", Color{255, 99, 71, "ff6347"}},
+	{"turquoise", Color{64, 224, 208, "40e0d0"}},
+	{"violet", Color{238, 130, 238, "ee82ee"}},
+	{"wheat", Color{245, 222, 179, "f5deb3"}},
+	{"white", Color{255, 255, 255, "ffffff"}},
+	{"whitesmoke", Color{245, 245, 245, "f5f5f5"}},
+	{"yellow", Color{255, 255, 0, "ffff00"}},
+	{"yellowgreen", Color{154, 205, 50, "9acd32"}},
+}
+
+// Finds the CSS named color closest to c by CIEDE2000. Returns a copy of the named color and its distance from c
+func (pc *PaletteCalculator) NearestNamedColor(c *Color) (*NamedColor, float64) {
+	return pc.nearestNamedColor(c, CSSNamedColors)
+}
+
+// Finds the color of table closest to c by CIEDE2000, e.g. MaterialColors or TailwindColors. Returns the named
+// color, copied from table, and its distance from c
+func (pc *PaletteCalculator) NearestColorInTable(c *Color, table []NamedColor) (*NamedColor, float64) {
+	return pc.nearestNamedColor(c, table)
+}
+
+// Looks up a color of table by name, ignoring case and surrounding space
+func (pc *PaletteCalculator) LookupNamedColor(name string, table []NamedColor) (*Color, bool) {
+	return lookupNamedColor(name, table)
+}
+
+// Reads a color table from CSV rows of name and hex, e.g. "Brand Red,#C8102E". A first row whose hex
+// does not parse is treated as a header
+func LoadColorTable(r io.Reader) ([]NamedColor, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	var table []NamedColor
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid color table: %w", err)
+		}
+
+		c, err := ParseHex(record[1])
+		if err != nil {
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("invalid color table line %d: %w", line, err)
+		}
+		table = append(table, NamedColor{Name: record[0], Color: *c})
+	}
+
+	return table, nil
+}
+
+func lookupNamedColor(name string, table []NamedColor) (*Color, bool) {
+	name = strings.TrimSpace(name)
+	for i := range table {
+		if strings.EqualFold(table[i].Name, name) {
+			c := table[i].Color
+			return &c, true
+		}
+	}
+
+	return nil, false
+}
+
+func (pc *PaletteCalculator) nearestNamedColor(c *Color, table []NamedColor) (*NamedColor, float64) {
+	var nearest *NamedColor
+	distance := math.Inf(1)
+
+	for i := range table {
+		if d := pc.DistanceDeltaE(c, &table[i].Color, CIEDE2000); d < distance {
+			// copy so callers can't edit the shared table
+			n := table[i]
+			nearest, distance = &n, d
+		}
+	}
+
+	return nearest, distance
+}
@@ -0,0 +1,140 @@
+package palettecalculator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNearestNamedColor(t *testing.T) {
+	for _, test := range []struct {
+		name             string
+		color            *Color
+		expectedName     string
+		expectedDistance float64
+	}{
+		{name: "exact match", color: &Color{102, 51, 153, "663399"}, expectedName: "rebeccapurple", expectedDistance: 0},
+		{name: "first alias wins", color: &Color{0, 255, 255, "00ffff"}, expectedName: "aqua", expectedDistance: 0},
+		{name: "dominant color", color: &Color{Red, Green, Blue, Hex}, expectedName: "darkslategray", expectedDistance: 11.0136},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedNamedColor, returnedDistance := paletteCalculator.NearestNamedColor(test.color)
+
+			if test.expectedName != returnedNamedColor.Name {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedName, returnedNamedColor.Name)
+			}
+
+			if test.expectedDistance != roundFloat(returnedDistance, 4) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedDistance, returnedDistance)
+			}
+		})
+	}
+}
+
+func TestNearestNamedColorReturnsCopy(t *testing.T) {
+	paletteCalculator := new(PaletteCalculator)
+
+	returnedNamedColor, _ := paletteCalculator.NearestNamedColor(&Color{255, 0, 0, "ff0000"})
+	returnedNamedColor.Name = "edited"
+	returnedNamedColor.Color.Hex = "000000"
+
+	if c, ok := lookupNamedColor("red", CSSNamedColors); !ok || c.Hex != "ff0000" {
+		t.Errorf("expected: %v\n returned: %v\n", "ff0000", c)
+	}
+}
+
+func TestNearestColorInTable(t *testing.T) {
+	for _, test := range []struct {
+		name             string
+		table            []NamedColor
+		expectedName     string
+		expectedDistance float64
+	}{
+		{name: "should find nearest Material color", table: MaterialColors, expectedName: "cyan-900", expectedDistance: 8.5915},
+		{name: "should find nearest Tailwind color", table: TailwindColors, expectedName: "cyan-800", expectedDistance: 1.6957},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedNamedColor, returnedDistance := paletteCalculator.NearestColorInTable(&Color{Red, Green, Blue, Hex}, test.table)
+
+			if test.expectedName != returnedNamedColor.Name {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedName, returnedNamedColor.Name)
+			}
+
+			if test.expectedDistance != roundFloat(returnedDistance, 4) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedDistance, returnedDistance)
+			}
+		})
+	}
+}
+
+func TestLookupNamedColor(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		colorName     string
+		table         []NamedColor
+		expectedColor *Color
+		expectedOk    bool
+	}{
+		{name: "should look up Tailwind color ignoring case", colorName: " Slate-950", table: TailwindColors, expectedColor: &Color{2, 6, 23, "020617"}, expectedOk: true},
+		{name: "should look up Material accent color", colorName: "deep-purple-a200", table: MaterialColors, expectedColor: &Color{124, 77, 255, "7c4dff"}, expectedOk: true},
+		{name: "should look up CSS color", colorName: "rebeccapurple", table: CSSNamedColors, expectedColor: &Color{102, 51, 153, "663399"}, expectedOk: true},
+		{name: "should not find unknown name", colorName: "slate-1000", table: TailwindColors, expectedOk: false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedColor, returnedOk := paletteCalculator.LookupNamedColor(test.colorName, test.table)
+
+			if !reflect.DeepEqual(test.expectedColor, returnedColor) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedColor, returnedColor)
+			}
+
+			if test.expectedOk != returnedOk {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedOk, returnedOk)
+			}
+		})
+	}
+}
+
+func TestLoadColorTable(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		csv           string
+		expectedTable []NamedColor
+		expectedErr   error
+	}{
+		{
+			name:          "should load table skipping header",
+			csv:           "name,hex\nSample A,#C8102E\n\"Sample, B\", 1f6277\n",
+			expectedTable: []NamedColor{{"Sample A", Color{200, 16, 46, "c8102e"}}, {"Sample, B", Color{31, 98, 119, "1f6277"}}},
+		},
+		{
+			name:          "should load table without header",
+			csv:           "Sample A,#C8102E\n",
+			expectedTable: []NamedColor{{"Sample A", Color{200, 16, 46, "c8102e"}}},
+		},
+		{
+			name:        "error occurs for invalid hex",
+			csv:         "a,#fff\nb,zz\n",
+			expectedErr: fmt.Errorf("invalid color table line 2: %w", &ColorError{Syntax: "hex", Input: "zz", Err: errors.New("expected 3, 4, 6 or 8 hex digits")}),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			returnedTable, err := LoadColorTable(strings.NewReader(test.csv))
+
+			if !reflect.DeepEqual(test.expectedTable, returnedTable) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedTable, returnedTable)
+			}
+
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
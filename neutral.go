@@ -0,0 +1,20 @@
+package palettecalculator
+
+import "math"
+
+// CIELAB lightness of each neutral, from background (lightest) to text (darkest)
+var NeutralLightness = []float64{98, 95, 90, 80, 65, 50, 35, 20, 10}
+
+// Calculates neutrals tinted toward the dominant color's hue for backgrounds, borders and text.
+// Returns array of Color{} ordered lightest to darkest, one per NeutralLightness
+func (pc *PaletteCalculator) CalculateNeutralColorScheme(dc *Color) []Color {
+	var neutrals []Color
+
+	lch := pc.ConvertLABToLCH(pc.ConvertRGBToLAB(dc))
+	chroma := math.Min(lch.C, neutralChroma)
+	for _, lightness := range NeutralLightness {
+		neutrals = append(neutrals, *pc.ConvertLCHToRGB(&LCH{L: lightness, C: chroma, H: lch.H}))
+	}
+
+	return neutrals
+}
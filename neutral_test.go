@@ -0,0 +1,35 @@
+package palettecalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCalculateNeutralColorScheme(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		dominantColor *Color
+		expectedRGB   []Color
+	}{
+		{
+			name:          "should tint neutrals toward dominant hue",
+			dominantColor: &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+			expectedRGB:   []Color{{241, 251, 255, "f1fbff"}, {232, 242, 247, "e8f2f7"}, {218, 228, 232, "dae4e8"}, {190, 200, 204, "bec8cc"}, {150, 159, 163, "969fa3"}, {112, 120, 124, "70787c"}, {75, 84, 87, "4b5457"}, {42, 50, 53, "2a3235"}, {21, 29, 32, "151d20"}},
+		},
+		{
+			name:          "should return pure grays for achromatic dominant color",
+			dominantColor: &Color{Red: 128, Green: 128, Blue: 128, Hex: "808080"},
+			expectedRGB:   []Color{{249, 249, 249, "f9f9f9"}, {241, 241, 241, "f1f1f1"}, {226, 226, 226, "e2e2e2"}, {198, 198, 198, "c6c6c6"}, {158, 158, 158, "9e9e9e"}, {119, 119, 119, "777777"}, {82, 82, 82, "525252"}, {48, 48, 48, "303030"}, {27, 27, 27, "1b1b1b"}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedRGB := paletteCalculator.CalculateNeutralColorScheme(test.dominantColor)
+
+			if !reflect.DeepEqual(test.expectedRGB, returnedRGB) {
+				t.Errorf("expected: %v\n returned %v\n", test.expectedRGB, returnedRGB)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package palettecalculator
+
+import "fmt"
+
+// Representation of a set of colors, e.g. an extracted image palette or a generated scheme.
+// Weights is optional, when set it holds each color's share of the image, or another importance, by index
+type Palette struct {
+	Name    string    `json:"name,omitempty"`
+	Colors  []Color   `json:"colors"`
+	Weights []float64 `json:"weights,omitempty"`
+}
+
+// Weight of the color at index i, colors without a weight count as 1
+func (p *Palette) weight(i int) float64 {
+	if i < len(p.Weights) {
+		return p.Weights[i]
+	}
+
+	return 1
+}
+
+// Checks that weights, when given, match colors
+func (p *Palette) validate() error {
+	if len(p.Weights) > 0 && len(p.Weights) != len(p.Colors) {
+		return fmt.Errorf("palette has %d weights for %d colors", len(p.Weights), len(p.Colors))
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package palettecalculatortest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+)
+
+// Environment variable that makes Golden write the palettes it is given instead of comparing them
+const UpdateGoldenEnv = "PALETTECALC_UPDATE_GOLDEN"
+
+// How far palettes may differ and still be equal
+type Tolerance struct {
+	// Largest CIEDE2000 Delta E between colors at the same index, around 1 is the smallest difference people notice
+	DeltaE float64
+	// Largest difference between weights at the same index
+	Weight float64
+}
+
+// Tolerance of rounding differences between backends and platforms, invisible to the eye
+var DefaultTolerance = Tolerance{DeltaE: 1, Weight: .01}
+
+// Returns why returned differs from expected by more than tolerance, or an empty string when it doesn't. Colors are
+// compared by their channels, their hex is ignored
+func DiffPalettes(returned *palettecalculator.Palette, expected *palettecalculator.Palette, tolerance Tolerance) string {
+	if returned == nil || expected == nil {
+		if returned != expected {
+			return fmt.Sprintf("palette is %v, expected %v", returned, expected)
+		}
+		return ""
+	}
+	if len(returned.Colors) != len(expected.Colors) {
+		return fmt.Sprintf("palette has %d colors, expected %d", len(returned.Colors), len(expected.Colors))
+	}
+	if len(returned.Weights) != len(expected.Weights) {
+		return fmt.Sprintf("palette has %d weights, expected %d", len(returned.Weights), len(expected.Weights))
+	}
+
+	pc := new(palettecalculator.PaletteCalculator)
+	for i := range returned.Colors {
+		if d := pc.DistanceDeltaE(&returned.Colors[i], &expected.Colors[i], palettecalculator.CIEDE2000); d > tolerance.DeltaE {
+			return fmt.Sprintf("color %d is %v, expected %v: delta e %.2f", i, returned.Colors[i], expected.Colors[i], d)
+		}
+	}
+	for i := range returned.Weights {
+		if d := math.Abs(returned.Weights[i] - expected.Weights[i]); d > tolerance.Weight {
+			return fmt.Sprintf("weight %d is %v, expected %v", i, returned.Weights[i], expected.Weights[i])
+		}
+	}
+
+	return ""
+}
+
+// Fails t unless returned equals expected within tolerance
+func AssertPalette(t testing.TB, returned *palettecalculator.Palette, expected *palettecalculator.Palette, tolerance Tolerance) {
+	t.Helper()
+	if diff := DiffPalettes(returned, expected, tolerance); diff != "" {
+		t.Errorf("%s\nexpected: %v\n returned: %v\n", diff, expected, returned)
+	}
+}
+
+// Compares p with the JSON palette in the golden file at path, e.g. testdata/photo.golden.json, within
+// DefaultTolerance. Run the tests with PALETTECALC_UPDATE_GOLDEN=1 to write p to the file instead
+func Golden(t testing.TB, path string, p *palettecalculator.Palette) {
+	t.Helper()
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		data, err := json.MarshalIndent(p, "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden palette: %v, run with %s=1 to create it", err, UpdateGoldenEnv)
+	}
+	expected := new(palettecalculator.Palette)
+	if err := json.Unmarshal(data, expected); err != nil {
+		t.Fatalf("reading golden palette %s: %v", path, err)
+	}
+	AssertPalette(t, p, expected, DefaultTolerance)
+}
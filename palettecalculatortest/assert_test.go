@@ -0,0 +1,94 @@
+package palettecalculatortest
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+)
+
+func TestDiffPalettes(t *testing.T) {
+	red := palettecalculator.Color{Red: 255, Hex: "ff0000"}
+	nearRed := palettecalculator.Color{Red: 254, Green: 1}
+	blue := palettecalculator.Color{Blue: 255, Hex: "0000ff"}
+	expected := &palettecalculator.Palette{Colors: []palettecalculator.Color{red, blue}, Weights: []float64{.75, .25}}
+
+	for _, test := range []struct {
+		name     string
+		returned *palettecalculator.Palette
+		want     string
+	}{
+		{
+			name:     "within tolerance",
+			returned: &palettecalculator.Palette{Colors: []palettecalculator.Color{nearRed, blue}, Weights: []float64{.755, .245}},
+			want:     "",
+		},
+		{
+			name:     "different color",
+			returned: &palettecalculator.Palette{Colors: []palettecalculator.Color{blue, red}, Weights: []float64{.75, .25}},
+			want:     "color 0 is rgb(0, 0, 255), expected rgb(255, 0, 0): delta e 52.88",
+		},
+		{
+			name:     "different weight",
+			returned: &palettecalculator.Palette{Colors: []palettecalculator.Color{red, blue}, Weights: []float64{.5, .5}},
+			want:     "weight 0 is 0.5, expected 0.75",
+		},
+		{
+			name:     "different length",
+			returned: &palettecalculator.Palette{Colors: []palettecalculator.Color{red}, Weights: []float64{1}},
+			want:     "palette has 1 colors, expected 2",
+		},
+		{
+			name:     "nil palette",
+			returned: nil,
+			want:     fmt.Sprintf("palette is %v, expected %v", (*palettecalculator.Palette)(nil), expected),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := DiffPalettes(test.returned, expected, DefaultTolerance); diff != test.want {
+				t.Errorf("expected: %v\n returned: %v\n", test.want, diff)
+			}
+		})
+	}
+}
+
+// testing.TB recording whether it failed
+type recordingT struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.failed = true
+}
+
+func TestAssertPalette(t *testing.T) {
+	red := palettecalculator.Color{Red: 255}
+	blue := palettecalculator.Color{Blue: 255}
+
+	rt := &recordingT{TB: t}
+	AssertPalette(rt, &palettecalculator.Palette{Colors: []palettecalculator.Color{red}}, &palettecalculator.Palette{Colors: []palettecalculator.Color{blue}}, DefaultTolerance)
+	if !rt.failed {
+		t.Errorf("expected: %v\n returned: %v\n", true, rt.failed)
+	}
+}
+
+func TestGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "red.golden.json")
+	p := &palettecalculator.Palette{Colors: []palettecalculator.Color{{Red: 255, Hex: "ff0000"}}, Weights: []float64{1}}
+
+	t.Setenv(UpdateGoldenEnv, "1")
+	Golden(t, path, p)
+
+	t.Setenv(UpdateGoldenEnv, "")
+	Golden(t, path, p)
+
+	rt := &recordingT{TB: t}
+	Golden(rt, path, &palettecalculator.Palette{Colors: []palettecalculator.Color{{Blue: 255}}, Weights: []float64{1}})
+	if !rt.failed {
+		t.Errorf("expected: %v\n returned: %v\n", true, rt.failed)
+	}
+}
@@ -0,0 +1,113 @@
+//go:build !js
+
+// Package palettecalculatortest provides fakes, fixtures and assertions for testing code built on palettecalculator.
+//
+// A fake Vision API returns preloaded colors, so code calling the Vision backed methods runs without credentials:
+//
+//	pc, vision := palettecalculatortest.NewPaletteCalculator(red, blue)
+//	palette, err := pc.CalculatePaletteFromURI("gs://bucket/photo.jpg")
+//	// vision.Calls() == 1
+//
+// Fixtures are images with a known palette, and AssertPalette compares palettes within a perceptual tolerance.
+package palettecalculatortest
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	gax "github.com/googleapis/gax-go/v2"
+	pb "google.golang.org/genproto/googleapis/cloud/vision/v1"
+	colorpb "google.golang.org/genproto/googleapis/type/color"
+)
+
+// Vision API fake returning Colors as the dominant colors of every image, Colors[0] with the highest score
+type Calculator struct {
+	Colors []palettecalculator.Color
+	// Pixel fractions of Colors, nil splits the image evenly between them
+	Weights []float64
+	// Returned by every call instead of the colors when set
+	Err error
+
+	mu     sync.Mutex
+	images []*pb.Image
+}
+
+// Creates a calculator extracting colors from every image with a fake Vision API, returned to inspect its calls
+func NewPaletteCalculator(colors ...palettecalculator.Color) (*palettecalculator.PaletteCalculator, *Calculator) {
+	vision := &Calculator{Colors: colors}
+	pc := &palettecalculator.PaletteCalculator{
+		Calculator: vision,
+		Reader:     new(Reader),
+		Opener:     new(palettecalculator.FileOpener),
+		Context:    context.Background(),
+	}
+
+	return pc, vision
+}
+
+func (c *Calculator) DetectImageProperties(ctx context.Context, img *pb.Image, ictx *pb.ImageContext, opts ...gax.CallOption) (*pb.ImageProperties, error) {
+	c.mu.Lock()
+	c.images = append(c.images, img)
+	c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	infos := make([]*pb.ColorInfo, len(c.Colors))
+	for i, color := range c.Colors {
+		weight := 1 / float64(len(c.Colors))
+		if i < len(c.Weights) {
+			weight = c.Weights[i]
+		}
+		infos[i] = &pb.ColorInfo{
+			Color:         &colorpb.Color{Red: float32(color.Red), Green: float32(color.Green), Blue: float32(color.Blue)},
+			Score:         float32(len(c.Colors)-i) / float32(len(c.Colors)),
+			PixelFraction: float32(weight),
+		}
+	}
+
+	return &pb.ImageProperties{DominantColors: &pb.DominantColorsAnnotation{Colors: infos}}, nil
+}
+
+// Calls made so far
+func (c *Calculator) Calls() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.images)
+}
+
+// Images sent so far, in the order they were sent
+func (c *Calculator) Images() []*pb.Image {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*pb.Image(nil), c.images...)
+}
+
+// Fake of the Vision image wrappers keeping the contents read and the URI of each image, so Calculator.Images shows
+// what was sent
+type Reader struct {
+	// Returned by NewImageFromReader when set
+	Err error
+}
+
+func (r *Reader) NewImageFromReader(rd io.Reader) (*pb.Image, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	data, err := io.ReadAll(rd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Image{Content: data}, nil
+}
+
+func (r *Reader) NewImageFromURI(uri string) *pb.Image {
+	return &pb.Image{Source: &pb.ImageSource{ImageUri: uri}}
+}
@@ -0,0 +1,48 @@
+//go:build !js
+
+package palettecalculatortest
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+)
+
+func TestNewPaletteCalculator(t *testing.T) {
+	red, _ := palettecalculator.ParseHex("#ff0000")
+	blue, _ := palettecalculator.ParseHex("#0000ff")
+	pc, vision := NewPaletteCalculator(*red, *blue)
+	vision.Weights = []float64{.75, .25}
+
+	p, err := pc.CalculatePaletteFromURI("gs://bucket/photo.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	AssertPalette(t, p, &palettecalculator.Palette{Colors: []palettecalculator.Color{*red, *blue}, Weights: []float64{.75, .25}}, DefaultTolerance)
+
+	c, err := pc.CalculatePredominantColorFromReader(bytes.NewReader([]byte("jpeg")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(c, red) {
+		t.Errorf("expected: %v\n returned: %v\n", red, c)
+	}
+
+	images := vision.Images()
+	if vision.Calls() != 2 || images[0].Source.ImageUri != "gs://bucket/photo.jpg" || string(images[1].Content) != "jpeg" {
+		t.Errorf("expected: %v\n returned: %v\n", "the uri and the jpeg", images)
+	}
+}
+
+func TestCalculatorErr(t *testing.T) {
+	pc, vision := NewPaletteCalculator()
+	vision.Err = errors.New("quota exceeded")
+
+	_, err := pc.CalculatePaletteFromURI("gs://bucket/photo.jpg")
+	if !errors.Is(err, vision.Err) {
+		t.Errorf("expected: %v\n returned: %v\n", vision.Err, err)
+	}
+}
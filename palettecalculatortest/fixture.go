@@ -0,0 +1,72 @@
+package palettecalculatortest
+
+import (
+	"image"
+	"image/color"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+)
+
+// Image with a known palette
+type Fixture struct {
+	Name  string
+	Image image.Image
+	// Palette ExtractPalette returns for Image with as many colors as it has
+	Palette *palettecalculator.Palette
+}
+
+// Fixtures of solid, two, three and four color images, 100x100 pixels
+func Fixtures() []Fixture {
+	red := palettecalculator.MustColor(255, 0, 0)
+	blue := palettecalculator.MustColor(0, 0, 255)
+	green := palettecalculator.MustColor(0, 255, 0)
+	white := palettecalculator.MustColor(255, 255, 255)
+	black := palettecalculator.MustColor(0, 0, 0)
+
+	return []Fixture{
+		fixture("solid red", []palettecalculator.Color{red}, []float64{1}),
+		fixture("red and blue", []palettecalculator.Color{red, blue}, []float64{.75, .25}),
+		fixture("red, green and blue", []palettecalculator.Color{red, green, blue}, []float64{.5, .3, .2}),
+		fixture("black and white", []palettecalculator.Color{white, black}, []float64{.6, .4}),
+		fixture("primaries and white", []palettecalculator.Color{white, red, green, blue}, []float64{.4, .3, .2, .1}),
+	}
+}
+
+// Fixture named name
+func FixtureNamed(name string) (Fixture, bool) {
+	for _, f := range Fixtures() {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Fixture{}, false
+}
+
+// Image of width x height with a vertical stripe of each color as wide as its weight of the image, the last stripe
+// taking up what rounding leaves
+func StripedImage(width int, height int, colors []palettecalculator.Color, weights []float64) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	x := 0
+	for i, c := range colors {
+		end := width
+		if i < len(colors)-1 && i < len(weights) {
+			end = x + int(weights[i]*float64(width)+.5)
+		}
+		fill := color.RGBA{R: uint8(c.Red), G: uint8(c.Green), B: uint8(c.Blue), A: 255}
+		for ; x < end && x < width; x++ {
+			for y := 0; y < height; y++ {
+				img.SetRGBA(x, y, fill)
+			}
+		}
+	}
+
+	return img
+}
+
+func fixture(name string, colors []palettecalculator.Color, weights []float64) Fixture {
+	return Fixture{
+		Name:    name,
+		Image:   StripedImage(100, 100, colors, weights),
+		Palette: &palettecalculator.Palette{Colors: colors, Weights: weights},
+	}
+}
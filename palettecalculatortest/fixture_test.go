@@ -0,0 +1,29 @@
+package palettecalculatortest
+
+import (
+	"testing"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+)
+
+func TestFixtures(t *testing.T) {
+	pc := new(palettecalculator.PaletteCalculator)
+	for _, f := range Fixtures() {
+		t.Run(f.Name, func(t *testing.T) {
+			p, err := pc.ExtractPalette(f.Image, len(f.Palette.Colors))
+			if err != nil {
+				t.Fatal(err)
+			}
+			AssertPalette(t, p, f.Palette, DefaultTolerance)
+		})
+	}
+}
+
+func TestFixtureNamed(t *testing.T) {
+	if f, ok := FixtureNamed("red and blue"); !ok || len(f.Palette.Colors) != 2 {
+		t.Errorf("expected: %v\n returned: %v\n", "red and blue", f.Name)
+	}
+	if _, ok := FixtureNamed("missing"); ok {
+		t.Errorf("expected: %v\n returned: %v\n", false, ok)
+	}
+}
@@ -0,0 +1,38 @@
+package palettegrpc
+
+import (
+	palettecalculator "github.com/evancaplan/palettecalculator"
+)
+
+// Message of c
+func ToProtoColor(c *palettecalculator.Color) *Color {
+	return &Color{Red: c.Red, Green: c.Green, Blue: c.Blue, Hex: c.Hex}
+}
+
+// Color of the message c, a nil message is black
+func FromProtoColor(c *Color) palettecalculator.Color {
+	return palettecalculator.Color{Red: c.GetRed(), Green: c.GetGreen(), Blue: c.GetBlue(), Hex: c.GetHex()}
+}
+
+// Message of p
+func ToProtoPalette(p *palettecalculator.Palette) *Palette {
+	return &Palette{Name: p.Name, Colors: toProtoColors(p.Colors), Weights: p.Weights}
+}
+
+// Palette of the message p
+func FromProtoPalette(p *Palette) *palettecalculator.Palette {
+	palette := &palettecalculator.Palette{Name: p.GetName(), Weights: p.GetWeights()}
+	for _, c := range p.GetColors() {
+		palette.Colors = append(palette.Colors, FromProtoColor(c))
+	}
+
+	return palette
+}
+
+func toProtoColors(colors []palettecalculator.Color) []*Color {
+	var messages []*Color
+	for i := range colors {
+		messages = append(messages, ToProtoColor(&colors[i]))
+	}
+	return messages
+}
@@ -0,0 +1,38 @@
+package palettegrpc
+
+import (
+	"reflect"
+	"testing"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPaletteRoundTrip(t *testing.T) {
+	p := &palettecalculator.Palette{
+		Name:    "photo",
+		Colors:  []palettecalculator.Color{{Red: 24, Green: 98, Blue: 119, Hex: "186277"}, {Red: 255, Green: 255, Blue: 255, Hex: "ffffff"}},
+		Weights: []float64{.75, .25},
+	}
+
+	data, err := proto.Marshal(ToProtoPalette(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := new(Palette)
+	if err := proto.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	returned := FromProtoPalette(decoded)
+	if !reflect.DeepEqual(returned, p) {
+		t.Errorf("expected: %v\n returned: %v\n", p, returned)
+	}
+}
+
+func TestFromProtoColorNil(t *testing.T) {
+	returned := FromProtoColor(nil)
+	if !reflect.DeepEqual(returned, palettecalculator.Color{}) {
+		t.Errorf("expected: %v\n returned: %v\n", palettecalculator.Color{}, returned)
+	}
+}
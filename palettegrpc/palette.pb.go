@@ -0,0 +1,445 @@
+// Palette extraction and schemes over gRPC. Generate stubs for other languages with protoc, the Go client and
+// server are in this package.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: palettegrpc/palette.proto
+
+package palettegrpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// RGB color with channels from 0 to 255 and its hex without the leading #
+type Color struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Red           float64                `protobuf:"fixed64,1,opt,name=red,proto3" json:"red,omitempty"`
+	Green         float64                `protobuf:"fixed64,2,opt,name=green,proto3" json:"green,omitempty"`
+	Blue          float64                `protobuf:"fixed64,3,opt,name=blue,proto3" json:"blue,omitempty"`
+	Hex           string                 `protobuf:"bytes,4,opt,name=hex,proto3" json:"hex,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Color) Reset() {
+	*x = Color{}
+	mi := &file_palettegrpc_palette_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Color) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Color) ProtoMessage() {}
+
+func (x *Color) ProtoReflect() protoreflect.Message {
+	mi := &file_palettegrpc_palette_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Color.ProtoReflect.Descriptor instead.
+func (*Color) Descriptor() ([]byte, []int) {
+	return file_palettegrpc_palette_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Color) GetRed() float64 {
+	if x != nil {
+		return x.Red
+	}
+	return 0
+}
+
+func (x *Color) GetGreen() float64 {
+	if x != nil {
+		return x.Green
+	}
+	return 0
+}
+
+func (x *Color) GetBlue() float64 {
+	if x != nil {
+		return x.Blue
+	}
+	return 0
+}
+
+func (x *Color) GetHex() string {
+	if x != nil {
+		return x.Hex
+	}
+	return ""
+}
+
+// Set of colors, weights when set hold each color's share of the image by index
+type Palette struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Colors        []*Color               `protobuf:"bytes,2,rep,name=colors,proto3" json:"colors,omitempty"`
+	Weights       []float64              `protobuf:"fixed64,3,rep,packed,name=weights,proto3" json:"weights,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Palette) Reset() {
+	*x = Palette{}
+	mi := &file_palettegrpc_palette_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Palette) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Palette) ProtoMessage() {}
+
+func (x *Palette) ProtoReflect() protoreflect.Message {
+	mi := &file_palettegrpc_palette_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Palette.ProtoReflect.Descriptor instead.
+func (*Palette) Descriptor() ([]byte, []int) {
+	return file_palettegrpc_palette_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Palette) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Palette) GetColors() []*Color {
+	if x != nil {
+		return x.Colors
+	}
+	return nil
+}
+
+func (x *Palette) GetWeights() []float64 {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+type ExtractRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Source:
+	//
+	//	*ExtractRequest_Image
+	//	*ExtractRequest_Uri
+	Source isExtractRequest_Source `protobuf_oneof:"source"`
+	// Colors extracted by the local backend, 0 extracts 5
+	K             int32 `protobuf:"varint,3,opt,name=k,proto3" json:"k,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExtractRequest) Reset() {
+	*x = ExtractRequest{}
+	mi := &file_palettegrpc_palette_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtractRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtractRequest) ProtoMessage() {}
+
+func (x *ExtractRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_palettegrpc_palette_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtractRequest.ProtoReflect.Descriptor instead.
+func (*ExtractRequest) Descriptor() ([]byte, []int) {
+	return file_palettegrpc_palette_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExtractRequest) GetSource() isExtractRequest_Source {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *ExtractRequest) GetImage() []byte {
+	if x != nil {
+		if x, ok := x.Source.(*ExtractRequest_Image); ok {
+			return x.Image
+		}
+	}
+	return nil
+}
+
+func (x *ExtractRequest) GetUri() string {
+	if x != nil {
+		if x, ok := x.Source.(*ExtractRequest_Uri); ok {
+			return x.Uri
+		}
+	}
+	return ""
+}
+
+func (x *ExtractRequest) GetK() int32 {
+	if x != nil {
+		return x.K
+	}
+	return 0
+}
+
+type isExtractRequest_Source interface {
+	isExtractRequest_Source()
+}
+
+type ExtractRequest_Image struct {
+	// Encoded PNG, JPEG or GIF
+	Image []byte `protobuf:"bytes,1,opt,name=image,proto3,oneof"`
+}
+
+type ExtractRequest_Uri struct {
+	// http(s) or, with the vision backend, gs:// URI of an image
+	Uri string `protobuf:"bytes,2,opt,name=uri,proto3,oneof"`
+}
+
+func (*ExtractRequest_Image) isExtractRequest_Source() {}
+
+func (*ExtractRequest_Uri) isExtractRequest_Source() {}
+
+type SchemeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Color *Color                 `protobuf:"bytes,1,opt,name=color,proto3" json:"color,omitempty"`
+	// Scheme type, e.g. complimentary, triadic or analogous. Empty calculates complimentary
+	Type          string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchemeRequest) Reset() {
+	*x = SchemeRequest{}
+	mi := &file_palettegrpc_palette_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemeRequest) ProtoMessage() {}
+
+func (x *SchemeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_palettegrpc_palette_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemeRequest.ProtoReflect.Descriptor instead.
+func (*SchemeRequest) Descriptor() ([]byte, []int) {
+	return file_palettegrpc_palette_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SchemeRequest) GetColor() *Color {
+	if x != nil {
+		return x.Color
+	}
+	return nil
+}
+
+func (x *SchemeRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type Scheme struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Colors        []*Color               `protobuf:"bytes,2,rep,name=colors,proto3" json:"colors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Scheme) Reset() {
+	*x = Scheme{}
+	mi := &file_palettegrpc_palette_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Scheme) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Scheme) ProtoMessage() {}
+
+func (x *Scheme) ProtoReflect() protoreflect.Message {
+	mi := &file_palettegrpc_palette_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Scheme.ProtoReflect.Descriptor instead.
+func (*Scheme) Descriptor() ([]byte, []int) {
+	return file_palettegrpc_palette_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Scheme) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Scheme) GetColors() []*Color {
+	if x != nil {
+		return x.Colors
+	}
+	return nil
+}
+
+var File_palettegrpc_palette_proto protoreflect.FileDescriptor
+
+const file_palettegrpc_palette_proto_rawDesc = "" +
+	"\n" +
+	"\x19palettegrpc/palette.proto\x12\x14palettecalculator.v1\"U\n" +
+	"\x05Color\x12\x10\n" +
+	"\x03red\x18\x01 \x01(\x01R\x03red\x12\x14\n" +
+	"\x05green\x18\x02 \x01(\x01R\x05green\x12\x12\n" +
+	"\x04blue\x18\x03 \x01(\x01R\x04blue\x12\x10\n" +
+	"\x03hex\x18\x04 \x01(\tR\x03hex\"l\n" +
+	"\aPalette\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x123\n" +
+	"\x06colors\x18\x02 \x03(\v2\x1b.palettecalculator.v1.ColorR\x06colors\x12\x18\n" +
+	"\aweights\x18\x03 \x03(\x01R\aweights\"T\n" +
+	"\x0eExtractRequest\x12\x16\n" +
+	"\x05image\x18\x01 \x01(\fH\x00R\x05image\x12\x12\n" +
+	"\x03uri\x18\x02 \x01(\tH\x00R\x03uri\x12\f\n" +
+	"\x01k\x18\x03 \x01(\x05R\x01kB\b\n" +
+	"\x06source\"V\n" +
+	"\rSchemeRequest\x121\n" +
+	"\x05color\x18\x01 \x01(\v2\x1b.palettecalculator.v1.ColorR\x05color\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\"Q\n" +
+	"\x06Scheme\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x123\n" +
+	"\x06colors\x18\x02 \x03(\v2\x1b.palettecalculator.v1.ColorR\x06colors2\xb6\x01\n" +
+	"\x0ePaletteService\x12N\n" +
+	"\aExtract\x12$.palettecalculator.v1.ExtractRequest\x1a\x1d.palettecalculator.v1.Palette\x12T\n" +
+	"\x0fCalculateScheme\x12#.palettecalculator.v1.SchemeRequest\x1a\x1c.palettecalculator.v1.SchemeB5Z3github.com/evancaplan/palettecalculator/palettegrpcb\x06proto3"
+
+var (
+	file_palettegrpc_palette_proto_rawDescOnce sync.Once
+	file_palettegrpc_palette_proto_rawDescData []byte
+)
+
+func file_palettegrpc_palette_proto_rawDescGZIP() []byte {
+	file_palettegrpc_palette_proto_rawDescOnce.Do(func() {
+		file_palettegrpc_palette_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_palettegrpc_palette_proto_rawDesc), len(file_palettegrpc_palette_proto_rawDesc)))
+	})
+	return file_palettegrpc_palette_proto_rawDescData
+}
+
+var file_palettegrpc_palette_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_palettegrpc_palette_proto_goTypes = []any{
+	(*Color)(nil),          // 0: palettecalculator.v1.Color
+	(*Palette)(nil),        // 1: palettecalculator.v1.Palette
+	(*ExtractRequest)(nil), // 2: palettecalculator.v1.ExtractRequest
+	(*SchemeRequest)(nil),  // 3: palettecalculator.v1.SchemeRequest
+	(*Scheme)(nil),         // 4: palettecalculator.v1.Scheme
+}
+var file_palettegrpc_palette_proto_depIdxs = []int32{
+	0, // 0: palettecalculator.v1.Palette.colors:type_name -> palettecalculator.v1.Color
+	0, // 1: palettecalculator.v1.SchemeRequest.color:type_name -> palettecalculator.v1.Color
+	0, // 2: palettecalculator.v1.Scheme.colors:type_name -> palettecalculator.v1.Color
+	2, // 3: palettecalculator.v1.PaletteService.Extract:input_type -> palettecalculator.v1.ExtractRequest
+	3, // 4: palettecalculator.v1.PaletteService.CalculateScheme:input_type -> palettecalculator.v1.SchemeRequest
+	1, // 5: palettecalculator.v1.PaletteService.Extract:output_type -> palettecalculator.v1.Palette
+	4, // 6: palettecalculator.v1.PaletteService.CalculateScheme:output_type -> palettecalculator.v1.Scheme
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_palettegrpc_palette_proto_init() }
+func file_palettegrpc_palette_proto_init() {
+	if File_palettegrpc_palette_proto != nil {
+		return
+	}
+	file_palettegrpc_palette_proto_msgTypes[2].OneofWrappers = []any{
+		(*ExtractRequest_Image)(nil),
+		(*ExtractRequest_Uri)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_palettegrpc_palette_proto_rawDesc), len(file_palettegrpc_palette_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_palettegrpc_palette_proto_goTypes,
+		DependencyIndexes: file_palettegrpc_palette_proto_depIdxs,
+		MessageInfos:      file_palettegrpc_palette_proto_msgTypes,
+	}.Build()
+	File_palettegrpc_palette_proto = out.File
+	file_palettegrpc_palette_proto_goTypes = nil
+	file_palettegrpc_palette_proto_depIdxs = nil
+}
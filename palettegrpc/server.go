@@ -0,0 +1,221 @@
+// Package palettegrpc serves palette extraction and schemes as the gRPC service palettecalculator.v1.PaletteService
+// defined in palette.proto. palette.pb.go and palette_grpc.pb.go are generated from it, regenerate them with
+//
+//	protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative palettegrpc/palette.proto
+//
+// run from the repository root. Call the service from Go with NewPaletteServiceClient, or from any language with
+// stubs generated from palette.proto.
+package palettegrpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	// register the formats images can be sent in
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+)
+
+// Colors extracted by the local backend when a request does not set k
+const DefaultColors = 5
+
+// Largest request message accepted when a Server does not set MaxMessageSize, 16 MiB
+const DefaultMaxMessageSize = 16 << 20
+
+// Time allowed to fetch an image by URI when a Server does not set Client
+const DefaultFetchTimeout = 30 * time.Second
+
+// Server of PaletteService
+type Server struct {
+	UnimplementedPaletteServiceServer
+
+	// Extracts palettes with the Vision API, nil extracts them locally with ExtractPalette
+	Calculator *palettecalculator.PaletteCalculator
+	// Fetches images requested by URI for local extraction, nil uses a client with DefaultFetchTimeout
+	Client *http.Client
+	// Largest request message accepted in bytes, zero uses DefaultMaxMessageSize
+	MaxMessageSize int
+}
+
+// Creates a server extracting palettes with calculator, a nil calculator extracts them locally
+func NewServer(calculator *palettecalculator.PaletteCalculator) *Server {
+	return &Server{Calculator: calculator}
+}
+
+// gRPC server with s registered, accepting messages up to its maximum message size
+func (s *Server) GRPCServer(opts ...grpc.ServerOption) *grpc.Server {
+	g := grpc.NewServer(append([]grpc.ServerOption{grpc.MaxRecvMsgSize(s.maxMessageSize())}, opts...)...)
+	RegisterPaletteServiceServer(g, s)
+
+	return g
+}
+
+// Serves s on addr
+func ListenAndServe(addr string, s *Server) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	return s.GRPCServer().Serve(lis)
+}
+
+func (s *Server) Extract(ctx context.Context, req *ExtractRequest) (*Palette, error) {
+	if len(req.GetImage()) == 0 && req.GetUri() == "" {
+		return nil, status.Error(codes.InvalidArgument, "expected an image or uri")
+	}
+	k := DefaultColors
+	if req.GetK() != 0 {
+		if req.GetK() < 1 || req.GetK() > 256 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid k %d: expected 1 to 256 colors", req.GetK())
+		}
+		k = int(req.GetK())
+	}
+
+	p, err := s.extractPalette(ctx, req, k)
+	if err != nil {
+		return nil, err
+	}
+
+	return ToProtoPalette(p), nil
+}
+
+func (s *Server) extractPalette(ctx context.Context, req *ExtractRequest, k int) (*palettecalculator.Palette, error) {
+	if s.Calculator != nil {
+		var (
+			p   *palettecalculator.Palette
+			err error
+		)
+		pc := s.Calculator.WithContext(ctx)
+		if len(req.GetImage()) > 0 {
+			p, err = pc.CalculatePaletteFromReader(bytes.NewReader(req.GetImage()))
+		} else {
+			p, err = pc.CalculatePaletteFromURI(req.GetUri())
+		}
+		if err != nil {
+			return nil, extractionStatus(ctx, err)
+		}
+		return p, nil
+	}
+
+	data := req.GetImage()
+	if len(data) == 0 {
+		fetched, err := s.fetch(ctx, req.GetUri())
+		if err != nil {
+			return nil, err
+		}
+		data = fetched
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "decoding image: %v", err)
+	}
+	pc := new(palettecalculator.PaletteCalculator)
+	p, err := pc.ExtractPalette(img, k)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return p, nil
+}
+
+// Fetches the image at uri with the call's context, limited to the maximum message size
+func (s *Server) fetch(ctx context.Context, uri string) ([]byte, error) {
+	if !strings.HasPrefix(uri, "http://") && !strings.HasPrefix(uri, "https://") {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid uri %q: expected http or https", uri)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid uri %q: %v", uri, err)
+	}
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultFetchTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, contextStatus(ctx, codes.Unavailable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, status.Errorf(codes.NotFound, "fetching %s: %s", uri, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, int64(s.maxMessageSize())+1))
+	if err != nil {
+		return nil, contextStatus(ctx, codes.Unavailable, err)
+	}
+	if len(data) > s.maxMessageSize() {
+		return nil, status.Errorf(codes.ResourceExhausted, "image at %s is larger than %d bytes", uri, s.maxMessageSize())
+	}
+
+	return data, nil
+}
+
+func (s *Server) CalculateScheme(ctx context.Context, req *SchemeRequest) (*Scheme, error) {
+	scheme := palettecalculator.SchemeType(req.GetType())
+	if scheme == "" {
+		scheme = palettecalculator.Complimentary
+	}
+
+	if req.GetColor() == nil {
+		return nil, status.Error(codes.InvalidArgument, "expected a color")
+	}
+	color, err := palettecalculator.NewColor(req.GetColor().GetRed(), req.GetColor().GetGreen(), req.GetColor().GetBlue())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	pc := new(palettecalculator.PaletteCalculator)
+	colors, err := pc.WithContext(ctx).CalculateScheme(color, scheme)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &Scheme{Type: string(scheme), Colors: toProtoColors(colors)}, nil
+}
+
+func (s *Server) maxMessageSize() int {
+	if s.MaxMessageSize > 0 {
+		return s.MaxMessageSize
+	}
+	return DefaultMaxMessageSize
+}
+
+// Status of an extraction error by its cause. Failures that retrying won't fix map to ResourceExhausted,
+// InvalidArgument or FailedPrecondition, leaving Unavailable for the Vision API or image host being unreachable
+func extractionStatus(ctx context.Context, err error) error {
+	switch {
+	case ctx.Err() != nil:
+		return status.FromContextError(ctx.Err()).Err()
+	case errors.Is(err, palettecalculator.ErrVisionQuota):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, palettecalculator.ErrDecode), errors.Is(err, palettecalculator.ErrInvalidColor):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, palettecalculator.ErrNoDominantColors):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
+
+// Status of a transport error, the call's own status when its deadline passed or it was cancelled
+func contextStatus(ctx context.Context, code codes.Code, err error) error {
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,246 @@
+package palettegrpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	"github.com/evancaplan/palettecalculator/palettecalculatortest"
+)
+
+func newTestServer(t *testing.T, s *Server) PaletteServiceClient {
+	lis := bufconn.Listen(1 << 20)
+	g := s.GRPCServer()
+	go g.Serve(lis)
+	t.Cleanup(g.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return NewPaletteServiceClient(conn)
+}
+
+func testImage(t *testing.T) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 4; y++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+			if x == 0 {
+				img.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestServerExtract(t *testing.T) {
+	img := testImage(t)
+	imageServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(img)
+	}))
+	defer imageServer.Close()
+
+	twoColors := &palettecalculator.Palette{
+		Colors:  []palettecalculator.Color{{Red: 255, Hex: "ff0000"}, {Blue: 255, Hex: "0000ff"}},
+		Weights: []float64{.75, .25},
+	}
+
+	tests := []struct {
+		name            string
+		request         *ExtractRequest
+		maxMessageSize  int
+		expected        *palettecalculator.Palette
+		expectedCode    codes.Code
+		expectedMessage string
+	}{
+		{
+			name:     "image",
+			request:  &ExtractRequest{Source: &ExtractRequest_Image{Image: img}, K: 2},
+			expected: twoColors,
+		},
+		{
+			name:     "uri",
+			request:  &ExtractRequest{Source: &ExtractRequest_Uri{Uri: imageServer.URL + "/image.png"}, K: 2},
+			expected: twoColors,
+		},
+		{
+			name:            "no source",
+			request:         &ExtractRequest{},
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: "expected an image or uri",
+		},
+		{
+			name:            "invalid k",
+			request:         &ExtractRequest{Source: &ExtractRequest_Image{Image: img}, K: -1},
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: "invalid k -1: expected 1 to 256 colors",
+		},
+		{
+			name:            "not an image",
+			request:         &ExtractRequest{Source: &ExtractRequest_Image{Image: []byte("not a png")}},
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: "decoding image: image: unknown format",
+		},
+		{
+			name:            "message too large",
+			request:         &ExtractRequest{Source: &ExtractRequest_Image{Image: img}},
+			maxMessageSize:  10,
+			expectedCode:    codes.ResourceExhausted,
+			expectedMessage: fmt.Sprintf("grpc: received message larger than max (%d vs. 10)", proto.Size(&ExtractRequest{Source: &ExtractRequest_Image{Image: img}})),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServer(nil)
+			s.MaxMessageSize = tt.maxMessageSize
+			returned, err := newTestServer(t, s).Extract(context.Background(), tt.request)
+
+			st := status.Convert(err)
+			if st.Code() != tt.expectedCode || st.Message() != tt.expectedMessage {
+				t.Errorf("expected: %v %v\n returned: %v %v\n", tt.expectedCode, tt.expectedMessage, st.Code(), st.Message())
+			}
+			if tt.expected != nil && !reflect.DeepEqual(FromProtoPalette(returned), tt.expected) {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expected, returned)
+			}
+		})
+	}
+}
+
+func TestServerExtractErrorCodes(t *testing.T) {
+	uri := &ExtractRequest{Source: &ExtractRequest_Uri{Uri: "gs://bucket/photo.png"}}
+
+	tests := []struct {
+		name         string
+		request      *ExtractRequest
+		visionErr    error
+		expectedCode codes.Code
+	}{
+		{name: "quota", request: uri, visionErr: status.Error(codes.ResourceExhausted, "quota exceeded"), expectedCode: codes.ResourceExhausted},
+		{name: "no dominant colors", request: uri, expectedCode: codes.FailedPrecondition},
+		{name: "vision unreachable", request: uri, visionErr: errors.New("connection refused"), expectedCode: codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc, vision := palettecalculatortest.NewPaletteCalculator()
+			vision.Err = tt.visionErr
+			_, err := newTestServer(t, NewServer(pc)).Extract(context.Background(), tt.request)
+
+			if code := status.Code(err); code != tt.expectedCode {
+				t.Errorf("expected: %v\n returned: %v %v\n", tt.expectedCode, code, err)
+			}
+		})
+	}
+}
+
+func TestServerExtractUsesCallContext(t *testing.T) {
+	cancelled := make(chan struct{})
+	imageServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// hold the fetch open until the call's deadline cancels it
+		<-r.Context().Done()
+		close(cancelled)
+	}))
+	defer imageServer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := newTestServer(t, NewServer(nil)).Extract(ctx, &ExtractRequest{Source: &ExtractRequest_Uri{Uri: imageServer.URL}})
+
+	if code := status.Code(err); code != codes.DeadlineExceeded {
+		t.Errorf("expected: %v\n returned: %v\n", codes.DeadlineExceeded, code)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Error("expected the fetch to be cancelled with the call")
+	}
+}
+
+func TestServerCalculateScheme(t *testing.T) {
+	red := &Color{Red: 255, Hex: "ff0000"}
+
+	tests := []struct {
+		name            string
+		request         *SchemeRequest
+		expected        *palettecalculator.ReportScheme
+		expectedCode    codes.Code
+		expectedMessage string
+	}{
+		{
+			name:    "complimentary by default",
+			request: &SchemeRequest{Color: red},
+			expected: &palettecalculator.ReportScheme{
+				Scheme: palettecalculator.Complimentary,
+				Colors: []palettecalculator.Color{{Red: 255, Hex: "ff0000"}, {Green: 255, Blue: 255, Hex: "00ffff"}},
+			},
+		},
+		{
+			name:            "no color",
+			request:         &SchemeRequest{},
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: "expected a color",
+		},
+		{
+			name:            "color out of range",
+			request:         &SchemeRequest{Color: &Color{Red: 300}},
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: "invalid rgb color \"rgb(300, 0, 0)\": red 300 is out of range 0 to 255",
+		},
+		{
+			name:            "unsupported type",
+			request:         &SchemeRequest{Color: red, Type: "plaid"},
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: "unsupported scheme type: plaid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			returned, err := newTestServer(t, NewServer(nil)).CalculateScheme(context.Background(), tt.request)
+
+			st := status.Convert(err)
+			if st.Code() != tt.expectedCode || st.Message() != tt.expectedMessage {
+				t.Errorf("expected: %v %v\n returned: %v %v\n", tt.expectedCode, tt.expectedMessage, st.Code(), st.Message())
+			}
+			if tt.expected == nil {
+				return
+			}
+			scheme := &palettecalculator.ReportScheme{
+				Scheme: palettecalculator.SchemeType(returned.GetType()),
+				Colors: FromProtoPalette(&Palette{Colors: returned.GetColors()}).Colors,
+			}
+			if !reflect.DeepEqual(scheme, tt.expected) {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expected, scheme)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Package paletteotel traces palette calculations with OpenTelemetry.
+//
+//	pc.Tracer = paletteotel.NewTracer(otel.Tracer("palettecalculator"))
+//
+// Spans are started under the span in the calculator's context, set per request with PaletteCalculator.WithContext.
+package paletteotel
+
+import (
+	"context"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Name of the tracer NewTracerFromProvider creates
+const TracerName = "github.com/evancaplan/palettecalculator"
+
+type tracer struct {
+	tracer trace.Tracer
+}
+
+// Tracer of palette calculations starting spans with t
+func NewTracer(t trace.Tracer) palettecalculator.Tracer {
+	return &tracer{tracer: t}
+}
+
+// Tracer of palette calculations starting spans with the TracerName tracer of tp
+func NewTracerFromProvider(tp trace.TracerProvider) palettecalculator.Tracer {
+	return NewTracer(tp.Tracer(TracerName))
+}
+
+func (t *tracer) Start(ctx context.Context, name string) (context.Context, palettecalculator.Span) {
+	ctx, s := t.tracer.Start(ctx, name)
+	return ctx, &span{span: s}
+}
+
+type span struct {
+	span trace.Span
+}
+
+func (s *span) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
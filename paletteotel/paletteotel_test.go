@@ -0,0 +1,78 @@
+package paletteotel
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer recording the spans it starts, the embedded interfaces are never called
+type recordingTracer struct {
+	trace.Tracer
+	spans []*recordingSpan
+}
+
+type parentKey struct{}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	parent, _ := ctx.Value(parentKey{}).(string)
+	s := &recordingSpan{name: name, parent: parent}
+	t.spans = append(t.spans, s)
+
+	return context.WithValue(ctx, parentKey{}, name), s
+}
+
+type recordingSpan struct {
+	trace.Span
+	name   string
+	parent string
+	ended  bool
+	status codes.Code
+	errs   []error
+}
+
+func (s *recordingSpan) End(options ...trace.SpanEndOption) {
+	s.ended = true
+}
+
+func (s *recordingSpan) RecordError(err error, options ...trace.EventOption) {
+	s.errs = append(s.errs, err)
+}
+
+func (s *recordingSpan) SetStatus(code codes.Code, description string) {
+	s.status = code
+}
+
+func TestTracer(t *testing.T) {
+	rt := new(recordingTracer)
+	pc := &palettecalculator.PaletteCalculator{Tracer: NewTracer(rt)}
+	pc = pc.WithContext(context.WithValue(context.Background(), parentKey{}, "request"))
+
+	if _, err := pc.CalculateScheme(&palettecalculator.Color{Red: 255}, palettecalculator.Triadic); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pc.CalculateScheme(&palettecalculator.Color{Red: 255}, "unknown"); err == nil {
+		t.Fatal("expected an error for an unknown scheme")
+	}
+
+	if len(rt.spans) != 2 {
+		t.Fatalf("expected: %v\n returned: %v\n", 2, len(rt.spans))
+	}
+	for _, s := range rt.spans {
+		if s.name != "palettecalculator.CalculateScheme" || s.parent != "request" || !s.ended {
+			t.Errorf("expected: %v\n returned: %v\n", "an ended palettecalculator.CalculateScheme span under request", s)
+		}
+	}
+	if rt.spans[0].status != codes.Unset || len(rt.spans[0].errs) != 0 {
+		t.Errorf("expected: %v\n returned: %v %v\n", codes.Unset, rt.spans[0].status, rt.spans[0].errs)
+	}
+	expected := []error{errors.New("unsupported scheme type: unknown")}
+	if rt.spans[1].status != codes.Error || !reflect.DeepEqual(rt.spans[1].errs, expected) {
+		t.Errorf("expected: %v %v\n returned: %v %v\n", codes.Error, expected, rt.spans[1].status, rt.spans[1].errs)
+	}
+}
@@ -0,0 +1,18 @@
+package palettecalculator
+
+import "errors"
+
+// Pantone does not license its color libraries for redistribution, so no table ships with the package. Load the
+// sRGB approximations from your Pantone license, e.g. the Solid Coated guide, with LoadColorTable
+var ErrEmptyPantoneTable = errors.New("pantone table is empty, load one with LoadColorTable")
+
+// Finds the Pantone reference of table closest to c by CIEDE2000, e.g. a print equivalent of an extracted brand
+// color. Returns the reference and its distance from c, a Delta E above about 2 is a visible mismatch
+func (pc *PaletteCalculator) NearestPantone(c *Color, table []NamedColor) (*NamedColor, float64, error) {
+	if len(table) == 0 {
+		return nil, 0, ErrEmptyPantoneTable
+	}
+
+	nearest, distance := pc.nearestNamedColor(c, table)
+	return nearest, distance, nil
+}
@@ -0,0 +1,38 @@
+package palettecalculator
+
+import (
+	"testing"
+)
+
+func TestNearestPantone(t *testing.T) {
+	table := []NamedColor{{"Sample A", Color{200, 16, 46, "c8102e"}}, {"Sample B", Color{31, 98, 119, "1f6277"}}}
+
+	for _, test := range []struct {
+		name             string
+		table            []NamedColor
+		expectedName     string
+		expectedDistance float64
+		expectedErr      error
+	}{
+		{name: "should find nearest reference", table: table, expectedName: "Sample B", expectedDistance: .6568},
+		{name: "error occurs for empty table", table: nil, expectedErr: ErrEmptyPantoneTable},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedNamedColor, returnedDistance, err := paletteCalculator.NearestPantone(&Color{Red, Green, Blue, Hex}, test.table)
+
+			if returnedNamedColor != nil && test.expectedName != returnedNamedColor.Name {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedName, returnedNamedColor.Name)
+			}
+
+			if test.expectedDistance != roundFloat(returnedDistance, 4) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedDistance, returnedDistance)
+			}
+
+			if test.expectedErr != err {
+				t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package palettecalculator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Parses a hex color in #rgb, #rgba, #rrggbb or #rrggbbaa form, with or without the leading #. Alpha is discarded
+func ParseHex(s string) (*Color, error) {
+	c, _, err := ParseHexWithAlpha(s)
+	return c, err
+}
+
+// Parses a hex color like ParseHex, also returning its alpha in [0,1]. Colors without alpha are fully opaque
+func ParseHexWithAlpha(s string) (*Color, float64, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(s), "#")
+
+	// expand short forms so every digit pair is one channel
+	if len(hex) == 3 || len(hex) == 4 {
+		var expanded strings.Builder
+		for _, digit := range hex {
+			expanded.WriteRune(digit)
+			expanded.WriteRune(digit)
+		}
+		hex = expanded.String()
+	}
+	if len(hex) != 6 && len(hex) != 8 {
+		return nil, 0, &ColorError{Syntax: "hex", Input: s, Err: errors.New("expected 3, 4, 6 or 8 hex digits")}
+	}
+
+	var channels []float64
+	for i := 0; i < len(hex); i += 2 {
+		channel, err := strconv.ParseUint(hex[i:i+2], 16, 8)
+		if err != nil {
+			return nil, 0, &ColorError{Syntax: "hex", Input: s, Err: fmt.Errorf("%q is not a hex number", hex[i:i+2])}
+		}
+		channels = append(channels, float64(channel))
+	}
+
+	alpha := float64(1)
+	if len(channels) == 4 {
+		alpha = channels[3] / RGBMax
+	}
+
+	r, g, b := channels[RED], channels[GREEN], channels[BLUE]
+	return &Color{Red: r, Green: g, Blue: b, Hex: hexString(r, g, b)}, alpha, nil
+}
+
+// Parses a CSS color: hex, a named color, or rgb()/rgba()/hsl()/hsla()/oklch() in comma or space separated
+// syntax. Alpha is discarded
+func ParseCSS(s string) (*Color, error) {
+	return new(PaletteCalculator).ParseCSS(s)
+}
+
+// Parses a CSS color like ParseCSS, also returning its alpha in [0,1]
+func ParseCSSWithAlpha(s string) (*Color, float64, error) {
+	return new(PaletteCalculator).ParseCSSWithAlpha(s)
+}
+
+// Parses a CSS color like the ParseCSS function, rounding rgb() channels and hsl() and oklch() conversions under the
+// calculator's rounding policy
+func (pc *PaletteCalculator) ParseCSS(s string) (*Color, error) {
+	c, _, err := pc.ParseCSSWithAlpha(s)
+	return c, err
+}
+
+// Parses a CSS color like the ParseCSSWithAlpha function under the calculator's rounding policy
+func (pc *PaletteCalculator) ParseCSSWithAlpha(s string) (*Color, float64, error) {
+	css := strings.ToLower(strings.TrimSpace(s))
+
+	if strings.HasPrefix(css, "#") {
+		return ParseHexWithAlpha(css)
+	}
+	if css == "transparent" {
+		return &Color{Red: 0, Green: 0, Blue: 0, Hex: hexString(0, 0, 0)}, 0, nil
+	}
+	if c, ok := lookupNamedColor(css, CSSNamedColors); ok {
+		return c, 1, nil
+	}
+
+	open, close := strings.Index(css, "("), strings.LastIndex(css, ")")
+	if open < 0 || close != len(css)-1 {
+		return nil, 0, &ColorError{Syntax: "css", Input: s, Err: errors.New("unknown color name or syntax")}
+	}
+
+	fn := strings.TrimSpace(css[:open])
+	args, alpha, err := splitCSSArgs(css[open+1 : close])
+	if err != nil {
+		return nil, 0, &ColorError{Syntax: "css", Input: s, Err: err}
+	}
+	if len(args) != 3 {
+		return nil, 0, &ColorError{Syntax: "css", Input: s, Err: fmt.Errorf("expected 3 components, got %d", len(args))}
+	}
+
+	var c *Color
+	switch fn {
+	case "rgb", "rgba":
+		c, err = pc.parseCSSRGB(args)
+	case "hsl", "hsla":
+		c, err = pc.parseCSSHSL(args)
+	case "oklch":
+		c, err = pc.parseCSSOKLCH(args)
+	default:
+		err = fmt.Errorf("unsupported function %s()", fn)
+	}
+	if err != nil {
+		return nil, 0, &ColorError{Syntax: "css", Input: s, Err: err}
+	}
+
+	return c, alpha, nil
+}
+
+// Splits functional notation arguments into components and alpha, accepting "a, b, c, alpha" and "a b c / alpha"
+func splitCSSArgs(args string) ([]string, float64, error) {
+	alpha := float64(1)
+	alphaArg := ""
+
+	if slash := strings.Index(args, "/"); slash >= 0 {
+		alphaArg = strings.TrimSpace(args[slash+1:])
+		args = args[:slash]
+	}
+
+	components := strings.Fields(strings.ReplaceAll(args, ",", " "))
+	if alphaArg == "" && len(components) == 4 {
+		alphaArg, components = components[3], components[:3]
+	}
+
+	if alphaArg != "" {
+		a, err := parseCSSNumber(alphaArg, 1)
+		if err != nil {
+			return nil, 0, err
+		}
+		alpha = clampUnit(a)
+	}
+
+	return components, alpha, nil
+}
+
+func (pc *PaletteCalculator) parseCSSRGB(args []string) (*Color, error) {
+	var channels []float64
+	for _, arg := range args {
+		channel, err := parseCSSNumber(arg, RGBMax)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, clampChannel(pc.round(channel, 0)))
+	}
+
+	r, g, b := channels[RED], channels[GREEN], channels[BLUE]
+	return &Color{Red: r, Green: g, Blue: b, Hex: hexString(r, g, b)}, nil
+}
+
+func (pc *PaletteCalculator) parseCSSHSL(args []string) (*Color, error) {
+	hue, err := parseCSSHue(args[0])
+	if err != nil {
+		return nil, err
+	}
+	saturation, err := parseCSSNumber(strings.TrimSuffix(args[1], "%"), 100)
+	if err != nil {
+		return nil, err
+	}
+	luminosity, err := parseCSSNumber(strings.TrimSuffix(args[2], "%"), 100)
+	if err != nil {
+		return nil, err
+	}
+
+	return pc.ConvertHSLToRGB(&HSL{Hue: hue, Saturation: clampUnit(saturation / 100), Luminosity: clampUnit(luminosity / 100)}), nil
+}
+
+func (pc *PaletteCalculator) parseCSSOKLCH(args []string) (*Color, error) {
+	l, err := parseCSSNumber(args[0], 1)
+	if err != nil {
+		return nil, err
+	}
+	// 100% chroma is 0.4 per CSS Color 4
+	c, err := parseCSSNumber(args[1], .4)
+	if err != nil {
+		return nil, err
+	}
+	h, err := parseCSSHue(args[2])
+	if err != nil {
+		return nil, err
+	}
+
+	return pc.ConvertOKLCHToRGB(&OKLCH{L: clampUnit(l), C: c, H: h}), nil
+}
+
+// Parses a number, scaling percentages so 100% equals full
+func parseCSSNumber(s string, full float64) (float64, error) {
+	if strings.HasSuffix(s, "%") {
+		n, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+		if err != nil || !isFinite(n) {
+			return 0, fmt.Errorf("%q is not a percentage", s)
+		}
+		return n / 100 * full, nil
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || !isFinite(n) {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	return n, nil
+}
+
+// Parses a hue in degrees, accepting deg, rad, grad and turn units
+func parseCSSHue(s string) (float64, error) {
+	units := []struct {
+		suffix  string
+		degrees float64
+	}{{"deg", 1}, {"grad", .9}, {"rad", 180 / math.Pi}, {"turn", 360}}
+
+	scale := float64(1)
+	for _, unit := range units {
+		if strings.HasSuffix(s, unit.suffix) {
+			s, scale = strings.TrimSuffix(s, unit.suffix), unit.degrees
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || !isFinite(n) {
+		return 0, fmt.Errorf("%q is not a hue", s)
+	}
+	return math.Mod(math.Mod(n*scale, 360)+360, 360), nil
+}
+
+// Whether n is neither NaN nor infinite, which ParseFloat accepts as "nan" and "inf"
+func isFinite(n float64) bool {
+	return !math.IsNaN(n) && !math.IsInf(n, 0)
+}
@@ -0,0 +1,157 @@
+package palettecalculator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseHex(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		hex           string
+		expectedColor *Color
+		expectedErr   error
+	}{
+		{name: "should parse uppercase with prefix", hex: "#1A2B3C", expectedColor: &Color{26, 43, 60, "1a2b3c"}, expectedErr: nil},
+		{name: "should parse lowercase without prefix", hex: "186277", expectedColor: &Color{Red, Green, Blue, Hex}, expectedErr: nil},
+		{name: "should parse short form", hex: "#abc", expectedColor: &Color{170, 187, 204, "aabbcc"}, expectedErr: nil},
+		{name: "should parse and discard alpha", hex: "#18627780", expectedColor: &Color{Red, Green, Blue, Hex}, expectedErr: nil},
+		{
+			name:          "error occurs for wrong length",
+			hex:           "#12345",
+			expectedColor: nil,
+			expectedErr:   &ColorError{Syntax: "hex", Input: "#12345", Err: errors.New("expected 3, 4, 6 or 8 hex digits")},
+		},
+		{
+			name:          "error occurs for non hex digits",
+			hex:           "#12zz56",
+			expectedColor: nil,
+			expectedErr:   &ColorError{Syntax: "hex", Input: "#12zz56", Err: errors.New(`"zz" is not a hex number`)},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			returnedColor, err := ParseHex(test.hex)
+
+			if !reflect.DeepEqual(test.expectedColor, returnedColor) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedColor, returnedColor)
+			}
+
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestParseHexWithAlpha(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		hex           string
+		expectedAlpha float64
+	}{
+		{name: "should default to opaque", hex: "#186277", expectedAlpha: 1},
+		{name: "should parse long form alpha", hex: "#18627733", expectedAlpha: .2},
+		{name: "should parse short form alpha", hex: "#abc0", expectedAlpha: 0},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, returnedAlpha, err := ParseHexWithAlpha(test.hex)
+
+			if err != nil {
+				t.Errorf("expected error: %v returned error: %v", nil, err)
+			}
+
+			if test.expectedAlpha != returnedAlpha {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedAlpha, returnedAlpha)
+			}
+		})
+	}
+}
+
+func TestParseCSSWithAlpha(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		css           string
+		expectedColor *Color
+		expectedAlpha float64
+		expectedErr   error
+	}{
+		{name: "should parse comma separated rgb", css: "rgb(24, 98, 119)", expectedColor: &Color{Red, Green, Blue, Hex}, expectedAlpha: 1},
+		{name: "should parse legacy rgba", css: "rgba(24,98,119,0.5)", expectedColor: &Color{Red, Green, Blue, Hex}, expectedAlpha: .5},
+		{name: "should parse space separated rgb with alpha", css: "rgb(24 98 119 / 50%)", expectedColor: &Color{Red, Green, Blue, Hex}, expectedAlpha: .5},
+		{name: "should parse rgb percentages", css: "rgb(100% 100% 100%)", expectedColor: &Color{255, 255, 255, "ffffff"}, expectedAlpha: 1},
+		{name: "should parse comma separated hsl", css: "hsl(193, 66%, 28%)", expectedColor: &Color{Red, Green, Blue, Hex}, expectedAlpha: 1},
+		{name: "should parse hsla with hue unit", css: "hsla(193deg 66% 28% / .3)", expectedColor: &Color{Red, Green, Blue, Hex}, expectedAlpha: .3},
+		{name: "should parse hsl with turn hue", css: "hsl(0.536turn 66% 28%)", expectedColor: &Color{Red, Green, Blue, Hex}, expectedAlpha: 1},
+		{name: "should parse oklch", css: "oklch(0.4623 0.0765 221.56)", expectedColor: &Color{Red, Green, Blue, Hex}, expectedAlpha: 1},
+		{name: "should parse named color case insensitively", css: "RebeccaPurple", expectedColor: &Color{102, 51, 153, "663399"}, expectedAlpha: 1},
+		{name: "should parse transparent", css: "transparent", expectedColor: &Color{0, 0, 0, "000000"}, expectedAlpha: 0},
+		{name: "should parse hex", css: "#abc", expectedColor: &Color{170, 187, 204, "aabbcc"}, expectedAlpha: 1},
+		{
+			name:        "error occurs for missing components",
+			css:         "rgb(1,2)",
+			expectedErr: &ColorError{Syntax: "css", Input: "rgb(1,2)", Err: errors.New("expected 3 components, got 2")},
+		},
+		{
+			name:        "error occurs for unsupported function",
+			css:         "lab(1 2 3)",
+			expectedErr: &ColorError{Syntax: "css", Input: "lab(1 2 3)", Err: errors.New("unsupported function lab()")},
+		},
+		{
+			name:        "error occurs for invalid number",
+			css:         "rgb(a,b,c)",
+			expectedErr: &ColorError{Syntax: "css", Input: "rgb(a,b,c)", Err: errors.New(`"a" is not a number`)},
+		},
+		{
+			name:        "error occurs for nan channel",
+			css:         "rgb(nan,0,0)",
+			expectedErr: &ColorError{Syntax: "css", Input: "rgb(nan,0,0)", Err: errors.New(`"nan" is not a number`)},
+		},
+		{
+			name:        "error occurs for nan alpha",
+			css:         "rgba(1,2,3,nan)",
+			expectedErr: &ColorError{Syntax: "css", Input: "rgba(1,2,3,nan)", Err: errors.New(`"nan" is not a number`)},
+		},
+		{
+			name:        "error occurs for nan hue",
+			css:         "hsl(nan 50% 50%)",
+			expectedErr: &ColorError{Syntax: "css", Input: "hsl(nan 50% 50%)", Err: errors.New(`"nan" is not a hue`)},
+		},
+		{
+			name:        "error occurs for nan oklch lightness",
+			css:         "oklch(nan 0.1 0)",
+			expectedErr: &ColorError{Syntax: "css", Input: "oklch(nan 0.1 0)", Err: errors.New(`"nan" is not a number`)},
+		},
+		{
+			name:        "error occurs for infinite oklch chroma",
+			css:         "oklch(0.5 inf 0)",
+			expectedErr: &ColorError{Syntax: "css", Input: "oklch(0.5 inf 0)", Err: errors.New(`"inf" is not a number`)},
+		},
+		{
+			name:        "error occurs for infinite percentage",
+			css:         "rgb(inf% 0 0)",
+			expectedErr: &ColorError{Syntax: "css", Input: "rgb(inf% 0 0)", Err: errors.New(`"inf%" is not a percentage`)},
+		},
+		{
+			name:        "error occurs for unknown name",
+			css:         "nope",
+			expectedErr: &ColorError{Syntax: "css", Input: "nope", Err: errors.New("unknown color name or syntax")},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			returnedColor, returnedAlpha, err := ParseCSSWithAlpha(test.css)
+
+			if !reflect.DeepEqual(test.expectedColor, returnedColor) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedColor, returnedColor)
+			}
+
+			if test.expectedAlpha != returnedAlpha {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedAlpha, returnedAlpha)
+			}
+
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package pipeline
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+)
+
+// Records the inputs a Job finished so a rerun after a crash skips them
+type Journal interface {
+	// Whether an earlier run finished in, so the job skips it
+	Finished(ctx context.Context, in Input) (bool, error)
+	// Records the result of an input, called once per input the run extracted
+	Record(ctx context.Context, r Result) error
+}
+
+// Counts of a job run
+type JobSummary struct {
+	// Inputs of the run
+	Total int
+	// Inputs finished by an earlier run
+	Skipped int
+	// Inputs extracted by this run
+	Extracted int
+	// Inputs that failed in this run, retried by the next one
+	Failed int
+}
+
+// Resumable batch: inputs its Journal has finished are skipped and every result is recorded as it comes in.
+// Pipeline.Progress is called for skipped inputs too, so the done count reaches the total
+type Job struct {
+	Pipeline *Pipeline
+	Journal  Journal
+}
+
+// Extracts the inputs not finished by an earlier run, recording each result in the journal. Returns the error of
+// the journal, ctx when it is done first, or with Pipeline.FailFast the first failed input
+func (j *Job) Run(ctx context.Context, inputs []Input) (JobSummary, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	summary := JobSummary{Total: len(inputs)}
+	pr := j.Pipeline.progress(len(inputs))
+	var (
+		pending = make(chan Input)
+		fed     = make(chan struct{})
+		feedErr error
+	)
+	go func() {
+		defer close(fed)
+		defer close(pending)
+		for _, in := range inputs {
+			finished, err := j.Journal.Finished(ctx, in)
+			if err != nil {
+				feedErr = err
+				cancel()
+				return
+			}
+			if finished {
+				summary.Skipped++
+				pr.report(in, nil)
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case pending <- in:
+			}
+		}
+	}()
+
+	var firstErr error
+	for result := range j.Pipeline.run(ctx, pending, nil) {
+		if firstErr != nil {
+			continue
+		}
+		if err := j.Journal.Record(ctx, result); err != nil {
+			firstErr = err
+			cancel()
+			continue
+		}
+		pr.report(result.Input, result.Err)
+		if result.Err != nil {
+			summary.Failed++
+			if j.Pipeline.FailFast {
+				firstErr = result.Err
+			}
+			continue
+		}
+		summary.Extracted++
+	}
+	<-fed
+
+	switch {
+	case feedErr != nil:
+		return summary, feedErr
+	case firstErr != nil:
+		return summary, firstErr
+	case summary.Skipped+summary.Extracted+summary.Failed < summary.Total:
+		return summary, ctx.Err()
+	}
+
+	return summary, nil
+}
+
+// Key of an input in a journal, its ID or its source when it has none
+func (in Input) Key() string {
+	if in.ID != "" {
+		return in.ID
+	}
+	return in.Source
+}
+
+// Journal in a file of JSON lines, one per result. Failed inputs are recorded with their error and retried by the
+// next run. Records are written as they come in, so a crash loses at most the line being written
+type FileJournal struct {
+	mu       sync.Mutex
+	file     *os.File
+	finished map[string]*palettecalculator.Palette
+}
+
+// Line of a journal file
+type journalEntry struct {
+	Key     string                     `json:"key"`
+	Source  string                     `json:"source"`
+	Palette *palettecalculator.Palette `json:"palette,omitempty"`
+	Error   string                     `json:"error,omitempty"`
+}
+
+// Opens the journal at path, creating it when it does not exist and reading the inputs finished by earlier runs
+func OpenFileJournal(path string) (*FileJournal, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &FileJournal{file: file, finished: make(map[string]*palettecalculator.Palette)}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64<<10), 16<<20)
+	for scanner.Scan() {
+		var entry journalEntry
+		// a line cut short by a crash is skipped and its input extracted again
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		if entry.Error == "" && entry.Palette != nil {
+			j.finished[entry.Key] = entry.Palette
+		} else {
+			delete(j.finished, entry.Key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := j.endLine(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return j, nil
+}
+
+// Ends a last line cut short by a crash so the next record starts on its own line
+func (j *FileJournal) endLine() error {
+	info, err := j.file.Stat()
+	if err != nil || info.Size() == 0 {
+		return err
+	}
+	last := make([]byte, 1)
+	if _, err := j.file.ReadAt(last, info.Size()-1); err != nil {
+		return err
+	}
+	if last[0] != '\n' {
+		_, err = j.file.Write([]byte("\n"))
+	}
+
+	return err
+}
+
+func (j *FileJournal) Finished(ctx context.Context, in Input) (bool, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	_, ok := j.finished[in.Key()]
+	return ok, nil
+}
+
+func (j *FileJournal) Record(ctx context.Context, r Result) error {
+	entry := journalEntry{Key: r.Input.Key(), Source: r.Input.Source, Palette: r.Palette}
+	if r.Err != nil {
+		entry.Error = r.Err.Error()
+		entry.Palette = nil
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.file == nil {
+		return errors.New("journal is closed")
+	}
+	if _, err := j.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	if entry.Error == "" {
+		j.finished[entry.Key] = r.Palette
+	} else {
+		delete(j.finished, entry.Key)
+	}
+
+	return nil
+}
+
+// Palette of a finished input, recorded by this run or an earlier one
+func (j *FileJournal) Palette(in Input) (*palettecalculator.Palette, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	p, ok := j.finished[in.Key()]
+	return p, ok
+}
+
+func (j *FileJournal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+
+	return err
+}
@@ -0,0 +1,95 @@
+package pipeline
+
+import (
+	"context"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	"github.com/evancaplan/palettecalculator/serverless"
+)
+
+func TestJobResumes(t *testing.T) {
+	dir := t.TempDir()
+	red := writeImage(t, dir, "red.png", color.RGBA{R: 255, A: 255})
+	blue := writeImage(t, dir, "blue.png", color.RGBA{B: 255, A: 255})
+	late := filepath.Join(dir, "late.png")
+	inputs := []Input{{Source: red}, {Source: late}, {ID: "blue", Source: blue}}
+	path := filepath.Join(dir, "journal.jsonl")
+
+	tests := []struct {
+		name     string
+		before   func()
+		expected JobSummary
+	}{
+		{"first run", func() {}, JobSummary{Total: 3, Extracted: 2, Failed: 1}},
+		{"failed input retried", func() { writeImage(t, dir, "late.png", color.RGBA{G: 255, A: 255}) }, JobSummary{Total: 3, Skipped: 2, Extracted: 1}},
+		{"nothing left", func() {}, JobSummary{Total: 3, Skipped: 3}},
+	}
+
+	for _, test := range tests {
+		test.before()
+		journal, err := OpenFileJournal(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		job := &Job{Pipeline: &Pipeline{Backend: serverless.Backend{Local: true, K: 1}, Concurrency: 2}, Journal: journal}
+		summary, err := job.Run(context.Background(), inputs)
+		journal.Close()
+
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if summary != test.expected {
+			t.Errorf("%s expected: %v\n returned: %v\n", test.name, test.expected, summary)
+		}
+	}
+
+	journal, err := OpenFileJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer journal.Close()
+	for _, in := range inputs {
+		if _, ok := journal.Palette(in); !ok {
+			t.Errorf("expected a palette for %s", in.Key())
+		}
+	}
+}
+
+func TestFileJournalTruncatedLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	// the second record was cut short by a crash
+	data := `{"key":"a","source":"a.png","palette":{"colors":[{"red":255,"green":0,"blue":0,"hex":"ff0000"}]}}` + "\n" +
+		`{"key":"b","source":"b.png","palette":{"col`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	journal, err := OpenFileJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := journal.Record(context.Background(), Result{Input: Input{ID: "c"}, Palette: &palettecalculator.Palette{}}); err != nil {
+		t.Fatal(err)
+	}
+	journal.Close()
+
+	journal, err = OpenFileJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer journal.Close()
+	for key, expected := range map[string]bool{"a": true, "b": false, "c": true} {
+		if returned, _ := journal.Finished(context.Background(), Input{ID: key}); returned != expected {
+			t.Errorf("%s expected: %v\n returned: %v\n", key, expected, returned)
+		}
+	}
+	contents, _ := os.ReadFile(path)
+	if lines := strings.Split(strings.TrimSuffix(string(contents), "\n"), "\n"); len(lines) != 3 {
+		t.Errorf("expected: %v\n returned: %v\n", 3, lines)
+	}
+}
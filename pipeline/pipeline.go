@@ -0,0 +1,301 @@
+// Package pipeline extracts the palettes of a stream of images: inputs are sent on a channel and results, tagged
+// with their input, come out of another.
+//
+// A Pipeline runs Concurrency extractions at once. Each worker sends its result before taking the next input, so a
+// consumer reading results slowly slows the pipeline down rather than results piling up in memory. Batch runs a
+// slice of inputs through the same workers.
+//
+// MaxVisionCalls bounds the Vision API calls in flight independently of Concurrency, Timeout bounds each image and
+// FailFast stops a run at its first error instead of collecting every error in the results.
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	"github.com/evancaplan/palettecalculator/serverless"
+)
+
+// Image to extract
+type Input struct {
+	// Caller's tag of the input, e.g. an asset id, returned with its result
+	ID string
+	// File path, http(s) URL or, with the Vision backend, gs:// URI of the image
+	Source string
+}
+
+// Palette of an input or the error extracting it
+type Result struct {
+	Input   Input
+	Palette *palettecalculator.Palette
+	Err     error
+}
+
+// Extracts the palettes of inputs with a backend
+type Pipeline struct {
+	Backend serverless.Backend
+	// Opens the source of an input, nil opens files and fetches http(s) URLs. The Vision backend extracts other
+	// URIs such as gs:// itself
+	Open func(ctx context.Context, source string) (io.ReadCloser, error)
+	// Extractions run at once, zero runs one at a time
+	Concurrency int
+	// Results buffered before workers wait for them to be read, zero hands each result over directly
+	Buffer int
+	// Vision API calls in flight at once, zero allows one per worker
+	MaxVisionCalls int
+	// Longest an image may take to open and extract, zero waits as long as ctx. A local extraction past its
+	// timeout is abandoned and finishes in the background
+	Timeout time.Duration
+	// Stop at the first failed input: its result is sent, no further inputs are read and extractions still running
+	// are dropped. Otherwise errors are returned in the results and the run carries on
+	FailFast bool
+	// Reports each input as it is done, nil reports nothing
+	Progress ProgressFunc
+
+	visionOnce sync.Once
+	vision     chan struct{}
+}
+
+// Extracts the palette of every input until inputs is closed or ctx is done, then closes the returned channel.
+// Results come out in the order their extractions finish. When ctx is done the pipeline stops reading inputs, so
+// senders should select on ctx too
+func (p *Pipeline) Run(ctx context.Context, inputs <-chan Input) <-chan Result {
+	return p.run(ctx, inputs, p.progress(0))
+}
+
+// Runs the workers, reporting each result sent to pr unless it is nil
+func (p *Pipeline) run(ctx context.Context, inputs <-chan Input, pr *progress) <-chan Result {
+	results := make(chan Result, maxInt(p.Buffer, 0))
+	ctx, cancel := context.WithCancel(ctx)
+
+	var wg sync.WaitGroup
+	for i := 0; i < maxInt(p.Concurrency, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				var (
+					in Input
+					ok bool
+				)
+				select {
+				case <-ctx.Done():
+					return
+				case in, ok = <-inputs:
+					if !ok {
+						return
+					}
+				}
+
+				palette, err := p.Extract(ctx, in)
+				if err != nil && ctx.Err() != nil {
+					// cancelled by the caller or a fail fast error of another input
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case results <- Result{Input: in, Palette: palette, Err: err}:
+				}
+				pr.report(in, err)
+				if err != nil && p.FailFast {
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		cancel()
+		close(results)
+	}()
+
+	return results
+}
+
+// Extracts the palettes of inputs, returning their results in the order of inputs. With FailFast the run stops at
+// the first failure and its error is returned with the results finished by then, inputs left unextracted have
+// neither a palette nor an error. Otherwise the error is ctx's when it is done before every input is extracted
+func (p *Pipeline) Batch(ctx context.Context, inputs []Input) ([]Result, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// inputs are tagged with their index so results can be put back in order
+	indexed := make(chan Input)
+	go func() {
+		defer close(indexed)
+		for i, in := range inputs {
+			select {
+			case <-ctx.Done():
+				return
+			case indexed <- Input{ID: strconv.Itoa(i), Source: in.Source}:
+			}
+		}
+	}()
+
+	results := make([]Result, len(inputs))
+	for i := range inputs {
+		results[i].Input = inputs[i]
+	}
+	done := 0
+	var firstErr error
+	pr := p.progress(len(inputs))
+	for result := range p.run(ctx, indexed, nil) {
+		i, _ := strconv.Atoi(result.Input.ID)
+		results[i].Palette, results[i].Err = result.Palette, result.Err
+		pr.report(inputs[i], result.Err)
+		done++
+		if result.Err != nil && p.FailFast && firstErr == nil {
+			firstErr = result.Err
+		}
+	}
+	if firstErr != nil {
+		return results, firstErr
+	}
+	if done < len(inputs) {
+		return results, ctx.Err()
+	}
+
+	return results, nil
+}
+
+// Extracts the palette of a single input within Timeout
+func (p *Pipeline) Extract(ctx context.Context, in Input) (*palettecalculator.Palette, error) {
+	if in.Source == "" {
+		return nil, fmt.Errorf("input %q has no source", in.ID)
+	}
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+
+	type outcome struct {
+		palette *palettecalculator.Palette
+		err     error
+	}
+	// local extractions don't watch ctx, so the extraction is waited on alongside it
+	done := make(chan outcome, 1)
+	go func() {
+		palette, err := p.extract(ctx, in)
+		done <- outcome{palette, err}
+	}()
+	select {
+	case o := <-done:
+		return o.palette, o.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (p *Pipeline) extract(ctx context.Context, in Input) (*palettecalculator.Palette, error) {
+	if p.Backend.Local {
+		r, err := p.open(ctx, in.Source)
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return p.Backend.ExtractReader(ctx, r)
+	}
+
+	if p.Open == nil && isURI(in.Source) && !isHTTP(in.Source) {
+		if err := p.acquireVision(ctx); err != nil {
+			return nil, err
+		}
+		defer p.releaseVision()
+		return p.Backend.ExtractURI(ctx, in.Source)
+	}
+
+	r, err := p.open(ctx, in.Source)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	if err := p.acquireVision(ctx); err != nil {
+		return nil, err
+	}
+	defer p.releaseVision()
+
+	return p.Backend.ExtractReader(ctx, r)
+}
+
+func (p *Pipeline) open(ctx context.Context, source string) (io.ReadCloser, error) {
+	if p.Open != nil {
+		return p.Open(ctx, source)
+	}
+	return openSource(ctx, source)
+}
+
+// Waits for a Vision call slot when MaxVisionCalls is set
+func (p *Pipeline) acquireVision(ctx context.Context) error {
+	p.visionOnce.Do(func() {
+		if p.MaxVisionCalls > 0 {
+			p.vision = make(chan struct{}, p.MaxVisionCalls)
+		}
+	})
+	if p.vision == nil {
+		return nil
+	}
+
+	select {
+	case p.vision <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Pipeline) releaseVision() {
+	if p.vision != nil {
+		<-p.vision
+	}
+}
+
+// Opens a file or fetches an http(s) URL
+func openSource(ctx context.Context, source string) (io.ReadCloser, error) {
+	if !isURI(source) {
+		return os.Open(source)
+	}
+	if !isHTTP(source) {
+		return nil, fmt.Errorf("cannot open %q: expected a file or http(s) url, set Pipeline.Open for other schemes", source)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("fetching %s: %s", source, resp.Status)
+	}
+
+	return resp.Body, nil
+}
+
+func isURI(source string) bool {
+	return strings.Contains(source, "://")
+}
+
+func isHTTP(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
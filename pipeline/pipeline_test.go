@@ -0,0 +1,240 @@
+package pipeline
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	"github.com/evancaplan/palettecalculator/palettecalculatortest"
+	"github.com/evancaplan/palettecalculator/serverless"
+	gax "github.com/googleapis/gax-go/v2"
+	pb "google.golang.org/genproto/googleapis/cloud/vision/v1"
+	colorpb "google.golang.org/genproto/googleapis/type/color"
+)
+
+// Writes a 2x2 image of c to dir and returns its path
+func writeImage(t *testing.T, dir string, name string, c color.RGBA) string {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	for x := 0; x < 2; x++ {
+		for y := 0; y < 2; y++ {
+			img.Set(x, y, c)
+		}
+	}
+	path := filepath.Join(dir, name)
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestPipelineRun(t *testing.T) {
+	dir := t.TempDir()
+	red := writeImage(t, dir, "red.png", color.RGBA{R: 255, A: 255})
+	blue := writeImage(t, dir, "blue.png", color.RGBA{B: 255, A: 255})
+
+	inputs := make(chan Input)
+	go func() {
+		defer close(inputs)
+		for _, in := range []Input{{"red", red}, {"blue", blue}, {"missing", filepath.Join(dir, "missing.png")}, {"empty", ""}} {
+			inputs <- in
+		}
+	}()
+
+	p := &Pipeline{Backend: serverless.Backend{Local: true, K: 1}, Concurrency: 3, Buffer: 1}
+	returned := make(map[string]string)
+	for result := range p.Run(context.Background(), inputs) {
+		if result.Err != nil {
+			returned[result.Input.ID] = result.Err.Error()
+			continue
+		}
+		returned[result.Input.ID] = result.Palette.Colors[0].Hex
+	}
+
+	expected := map[string]string{"red": "ff0000", "blue": "0000ff", "missing": "no such file or directory", "empty": `input "empty" has no source`}
+	if len(returned) != len(expected) {
+		t.Errorf("expected: %v\n returned: %v\n", expected, returned)
+	}
+	for id, want := range expected {
+		if !strings.Contains(returned[id], want) {
+			t.Errorf("%s expected: %v\n returned: %v\n", id, want, returned[id])
+		}
+	}
+}
+
+func TestPipelineBackpressure(t *testing.T) {
+	red := writeImage(t, t.TempDir(), "red.png", color.RGBA{R: 255, A: 255})
+	inputs := make(chan Input)
+	p := &Pipeline{Backend: serverless.Backend{Local: true, K: 1}}
+	results := p.Run(context.Background(), inputs)
+
+	inputs <- Input{ID: "1", Source: red}
+	// the worker holds the unread result of 1, so 2 is not taken
+	select {
+	case inputs <- Input{ID: "2", Source: red}:
+		t.Fatal("expected the pipeline to wait for its result to be read")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if result := <-results; result.Input.ID != "1" || result.Err != nil {
+		t.Errorf("expected: %v\n returned: %v\n", "1", result)
+	}
+	inputs <- Input{ID: "2", Source: red}
+	close(inputs)
+
+	var ids []string
+	for result := range results {
+		ids = append(ids, result.Input.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"2"}) {
+		t.Errorf("expected: %v\n returned: %v\n", []string{"2"}, ids)
+	}
+}
+
+func TestPipelineCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pipeline{Backend: serverless.Backend{Local: true}, Concurrency: 2}
+	// inputs is never closed, cancelling ctx ends the run
+	results := p.Run(ctx, make(chan Input))
+	cancel()
+
+	select {
+	case _, ok := <-results:
+		if ok {
+			t.Errorf("expected results to be closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected results to be closed once ctx is done")
+	}
+}
+
+func TestPipelineExtractURI(t *testing.T) {
+	p := &Pipeline{Backend: serverless.Backend{Local: true}}
+
+	// a local backend can only read files and http(s) URLs without Open
+	_, err := p.Extract(context.Background(), Input{ID: "gs", Source: "gs://bucket/photo.png"})
+	if err == nil || !strings.Contains(err.Error(), "set Pipeline.Open for other schemes") {
+		t.Errorf("expected: %v\n returned: %v\n", "set Pipeline.Open for other schemes", err)
+	}
+}
+
+// Vision API recording the most calls it had in flight at once
+type fakeVision struct {
+	mu       sync.Mutex
+	inFlight int
+	max      int
+}
+
+func (f *fakeVision) DetectImageProperties(ctx context.Context, img *pb.Image, ictx *pb.ImageContext, opts ...gax.CallOption) (*pb.ImageProperties, error) {
+	f.mu.Lock()
+	f.inFlight++
+	if f.inFlight > f.max {
+		f.max = f.inFlight
+	}
+	f.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	f.mu.Lock()
+	f.inFlight--
+	f.mu.Unlock()
+
+	return &pb.ImageProperties{DominantColors: &pb.DominantColorsAnnotation{Colors: []*pb.ColorInfo{
+		{Color: &colorpb.Color{Red: 255}, Score: 1, PixelFraction: 1},
+	}}}, nil
+}
+
+func TestPipelineMaxVisionCalls(t *testing.T) {
+	vision := new(fakeVision)
+	p := &Pipeline{Concurrency: 8, MaxVisionCalls: 2}
+	p.Backend.NewCalculator = func() (*palettecalculator.PaletteCalculator, error) {
+		return &palettecalculator.PaletteCalculator{Calculator: vision, Reader: new(palettecalculatortest.Reader)}, nil
+	}
+
+	inputs := make([]Input, 16)
+	for i := range inputs {
+		inputs[i] = Input{ID: strconv.Itoa(i), Source: "gs://bucket/" + strconv.Itoa(i) + ".png"}
+	}
+	results, err := p.Batch(context.Background(), inputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, result := range results {
+		if result.Input != inputs[i] || result.Err != nil || len(result.Palette.Colors) != 1 {
+			t.Errorf("expected: %v\n returned: %v\n", inputs[i], result)
+		}
+	}
+	if vision.max != 2 {
+		t.Errorf("expected: %v\n returned: %v\n", 2, vision.max)
+	}
+}
+
+func TestPipelineTimeout(t *testing.T) {
+	p := &Pipeline{Backend: serverless.Backend{Local: true}, Timeout: 10 * time.Millisecond}
+	p.Open = func(ctx context.Context, source string) (io.ReadCloser, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	results, err := p.Batch(context.Background(), []Input{{ID: "slow", Source: "slow.png"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Err != context.DeadlineExceeded {
+		t.Errorf("expected: %v\n returned: %v\n", context.DeadlineExceeded, results[0].Err)
+	}
+}
+
+func TestPipelineBatchFailFast(t *testing.T) {
+	dir := t.TempDir()
+	red := writeImage(t, dir, "red.png", color.RGBA{R: 255, A: 255})
+	inputs := []Input{{"red", red}, {"missing", filepath.Join(dir, "missing.png")}, {"after", red}}
+
+	tests := []struct {
+		name        string
+		failFast    bool
+		expectedErr bool
+		// inputs with a palette
+		expected []bool
+	}{
+		{"collect errors", false, false, []bool{true, false, true}},
+		{"fail fast", true, true, []bool{true, false, false}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &Pipeline{Backend: serverless.Backend{Local: true, K: 1}, FailFast: test.failFast}
+			results, err := p.Batch(context.Background(), inputs)
+			if (err != nil) != test.expectedErr {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedErr, err)
+			}
+			var returned []bool
+			for _, result := range results {
+				returned = append(returned, result.Palette != nil)
+			}
+			if !reflect.DeepEqual(returned, test.expected) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expected, returned)
+			}
+			if results[1].Err == nil {
+				t.Errorf("expected an error for %s", inputs[1].Source)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package pipeline
+
+import "sync"
+
+// Called after each input with the inputs done so far, the total or zero when it is unknown, and the input with
+// its error. Calls are made one at a time
+type ProgressFunc func(done int, total int, in Input, err error)
+
+// Progress of a run of total inputs, nil when p has no ProgressFunc
+func (p *Pipeline) progress(total int) *progress {
+	if p.Progress == nil {
+		return nil
+	}
+	return &progress{f: p.Progress, total: total}
+}
+
+// Counts the inputs done and reports each to a ProgressFunc
+type progress struct {
+	mu    sync.Mutex
+	f     ProgressFunc
+	done  int
+	total int
+}
+
+func (pr *progress) report(in Input, err error) {
+	if pr == nil {
+		return
+	}
+
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	pr.done++
+	pr.f(pr.done, pr.total, in, err)
+}
@@ -0,0 +1,84 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	"github.com/evancaplan/palettecalculator/serverless"
+)
+
+// Progress calls recorded as "done/total key error"
+type progressRecorder struct {
+	calls []string
+}
+
+func (r *progressRecorder) record(done int, total int, in Input, err error) {
+	r.calls = append(r.calls, fmt.Sprintf("%d/%d %s %v", done, total, in.Key(), err != nil))
+}
+
+func TestProgress(t *testing.T) {
+	dir := t.TempDir()
+	red := writeImage(t, dir, "red.png", color.RGBA{R: 255, A: 255})
+	inputs := []Input{{ID: "red", Source: red}, {ID: "missing", Source: filepath.Join(dir, "missing.png")}}
+
+	tests := []struct {
+		name     string
+		run      func(p *Pipeline) error
+		expected []string
+	}{
+		{
+			name: "batch",
+			run: func(p *Pipeline) error {
+				_, err := p.Batch(context.Background(), inputs)
+				return err
+			},
+			expected: []string{"1/2 red false", "2/2 missing true"},
+		},
+		{
+			name: "run has no total",
+			run: func(p *Pipeline) error {
+				ch := make(chan Input, len(inputs))
+				for _, in := range inputs {
+					ch <- in
+				}
+				close(ch)
+				for range p.Run(context.Background(), ch) {
+				}
+				return nil
+			},
+			expected: []string{"1/0 red false", "2/0 missing true"},
+		},
+		{
+			name: "job reports skipped inputs",
+			run: func(p *Pipeline) error {
+				journal, err := OpenFileJournal(filepath.Join(dir, "journal.jsonl"))
+				if err != nil {
+					return err
+				}
+				defer journal.Close()
+				journal.Record(context.Background(), Result{Input: inputs[0], Palette: new(palettecalculator.Palette)})
+				_, err = (&Job{Pipeline: p, Journal: journal}).Run(context.Background(), inputs)
+				return err
+			},
+			expected: []string{"1/2 red false", "2/2 missing true"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := new(progressRecorder)
+			p := &Pipeline{Backend: serverless.Backend{Local: true, K: 1}, Progress: recorder.record}
+			if err := test.run(p); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(recorder.calls, test.expected) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expected, recorder.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package palettecalculator
+
+import (
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"strings"
+)
+
+// Images are box-filtered to fit within this many pixels per side before hashing, ThumbHash's own limit
+const placeholderMaxSize = 100
+
+// BlurHash components used by EncodePlaceholders
+const (
+	DefaultBlurHashXComponents = 4
+	DefaultBlurHashYComponents = 3
+)
+
+const blurHashCharacters = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// Tiny placeholder hashes of an image to show while the full image loads
+type Placeholders struct {
+	BlurHash  string `json:"blur-hash"`
+	ThumbHash []byte `json:"thumb-hash"`
+}
+
+// Straight alpha RGBA pixels with channels in [0,255]
+type placeholderPixels struct {
+	width  int
+	height int
+	rgba   []float64
+}
+
+// Calculates both placeholder hashes from a single downscale of the image, BlurHash with the default components
+func (pc *PaletteCalculator) EncodePlaceholders(img image.Image) (*Placeholders, error) {
+	pixels := pc.placeholderPixels(img)
+
+	blurHash, err := pc.encodeBlurHash(pixels, DefaultBlurHashXComponents, DefaultBlurHashYComponents)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Placeholders{BlurHash: blurHash, ThumbHash: pc.encodeThumbHash(pixels)}, nil
+}
+
+// Calculates the BlurHash of an image with 1-9 components on each axis, more components keep more detail
+func (pc *PaletteCalculator) EncodeBlurHash(img image.Image, xComponents int, yComponents int) (string, error) {
+	return pc.encodeBlurHash(pc.placeholderPixels(img), xComponents, yComponents)
+}
+
+// Calculates the ThumbHash of an image, which unlike BlurHash keeps the aspect ratio and alpha
+func (pc *PaletteCalculator) EncodeThumbHash(img image.Image) []byte {
+	return pc.encodeThumbHash(pc.placeholderPixels(img))
+}
+
+// Box filters the image to fit within placeholderMaxSize, un-premultiplying alpha
+func (pc *PaletteCalculator) placeholderPixels(img image.Image) *placeholderPixels {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	scale := math.Max(1, float64(maxInt(w, h))/placeholderMaxSize)
+	pw, ph := maxInt(1, int(math.Round(float64(w)/scale))), maxInt(1, int(math.Round(float64(h)/scale)))
+
+	sums := make([]float64, pw*ph*4)
+	counts := make([]float64, pw*ph)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			i := minInt(pw-1, x*pw/w) + minInt(ph-1, y*ph/h)*pw
+			r, g, b, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			sums[i*4] += float64(r)
+			sums[i*4+1] += float64(g)
+			sums[i*4+2] += float64(b)
+			sums[i*4+3] += float64(a)
+			counts[i]++
+		}
+	}
+
+	pixels := &placeholderPixels{width: pw, height: ph, rgba: make([]float64, pw*ph*4)}
+	for i := range counts {
+		if counts[i] == 0 || sums[i*4+3] == 0 {
+			continue
+		}
+		// premultiplied 16 bit sums to straight 8 bit
+		for ch := 0; ch < 3; ch++ {
+			pixels.rgba[i*4+ch] = sums[i*4+ch] / sums[i*4+3] * RGBMax
+		}
+		pixels.rgba[i*4+3] = sums[i*4+3] / counts[i] / 0xffff * RGBMax
+	}
+
+	return pixels
+}
+
+func (pc *PaletteCalculator) encodeBlurHash(pixels *placeholderPixels, xComponents int, yComponents int) (string, error) {
+	if xComponents < 1 || xComponents > 9 || yComponents < 1 || yComponents > 9 {
+		return "", fmt.Errorf("invalid blurhash components %dx%d: expected 1 to 9 on each axis", xComponents, yComponents)
+	}
+
+	w, h := pixels.width, pixels.height
+	var factors [][3]float64
+	for cy := 0; cy < yComponents; cy++ {
+		for cx := 0; cx < xComponents; cx++ {
+			normalisation := float64(2)
+			if cx == 0 && cy == 0 {
+				normalisation = 1
+			}
+
+			var factor [3]float64
+			for y := 0; y < h; y++ {
+				for x := 0; x < w; x++ {
+					basis := normalisation * math.Cos(math.Pi*float64(cx*x)/float64(w)) * math.Cos(math.Pi*float64(cy*y)/float64(h))
+					for ch := range factor {
+						factor[ch] += basis * linearize(pixels.rgba[(x+y*w)*4+ch]/RGBMax)
+					}
+				}
+			}
+			for ch := range factor {
+				factor[ch] /= float64(w * h)
+			}
+			factors = append(factors, factor)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(pc.encodeBase83((xComponents-1)+(yComponents-1)*9, 1))
+
+	maximum := float64(1)
+	if len(factors) > 1 {
+		actualMaximum := float64(0)
+		for _, factor := range factors[1:] {
+			for _, v := range factor {
+				actualMaximum = math.Max(actualMaximum, math.Abs(v))
+			}
+		}
+		quantisedMaximum := int(math.Max(0, math.Min(82, math.Floor(actualMaximum*166-.5))))
+		maximum = float64(quantisedMaximum+1) / 166
+		sb.WriteString(pc.encodeBase83(quantisedMaximum, 1))
+	} else {
+		sb.WriteString(pc.encodeBase83(0, 1))
+	}
+
+	dc := factors[0]
+	sb.WriteString(pc.encodeBase83(pc.blurHashSRGB(dc[RED])<<16+pc.blurHashSRGB(dc[GREEN])<<8+pc.blurHashSRGB(dc[BLUE]), 4))
+
+	for _, factor := range factors[1:] {
+		var quantised [3]int
+		for ch, v := range factor {
+			signed := math.Copysign(math.Sqrt(math.Abs(v/maximum)), v)
+			quantised[ch] = int(math.Max(0, math.Min(18, math.Floor(signed*9+9.5))))
+		}
+		sb.WriteString(pc.encodeBase83(quantised[RED]*19*19+quantised[GREEN]*19+quantised[BLUE], 2))
+	}
+
+	return sb.String(), nil
+}
+
+func (pc *PaletteCalculator) blurHashSRGB(linear float64) int {
+	return int(delinearize(clampUnit(linear))*RGBMax + .5)
+}
+
+func (pc *PaletteCalculator) encodeBase83(n int, length int) string {
+	var sb strings.Builder
+	for i := 1; i <= length; i++ {
+		digit := n / int(math.Pow(83, float64(length-i))) % 83
+		sb.WriteByte(blurHashCharacters[digit])
+	}
+
+	return sb.String()
+}
+
+func (pc *PaletteCalculator) encodeThumbHash(pixels *placeholderPixels) []byte {
+	w, h := pixels.width, pixels.height
+	n := w * h
+
+	// average color, weighted by alpha
+	var avgR, avgG, avgB, avgA float64
+	for i := 0; i < n; i++ {
+		alpha := pixels.rgba[i*4+3] / RGBMax
+		avgR += alpha / RGBMax * pixels.rgba[i*4]
+		avgG += alpha / RGBMax * pixels.rgba[i*4+1]
+		avgB += alpha / RGBMax * pixels.rgba[i*4+2]
+		avgA += alpha
+	}
+	if avgA > 0 {
+		avgR, avgG, avgB = avgR/avgA, avgG/avgA, avgB/avgA
+	}
+
+	hasAlpha := avgA < float64(n)
+	lLimit := 7
+	if hasAlpha {
+		// fewer luminance bits leave room for alpha
+		lLimit = 5
+	}
+	lx := maxInt(1, int(math.Round(float64(lLimit*w)/float64(maxInt(w, h)))))
+	ly := maxInt(1, int(math.Round(float64(lLimit*h)/float64(maxInt(w, h)))))
+
+	// LPQA: luminance, yellow-blue, red-green and alpha, composited over the average color
+	l, p, q, a := make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+	for i := 0; i < n; i++ {
+		alpha := pixels.rgba[i*4+3] / RGBMax
+		r := avgR*(1-alpha) + alpha/RGBMax*pixels.rgba[i*4]
+		g := avgG*(1-alpha) + alpha/RGBMax*pixels.rgba[i*4+1]
+		b := avgB*(1-alpha) + alpha/RGBMax*pixels.rgba[i*4+2]
+		l[i] = (r + g + b) / 3
+		p[i] = (r+g)/2 - b
+		q[i] = r - g
+		a[i] = alpha
+	}
+
+	lDC, lAC, lScale := pc.thumbHashChannel(l, w, h, maxInt(3, lx), maxInt(3, ly))
+	pDC, pAC, pScale := pc.thumbHashChannel(p, w, h, 3, 3)
+	qDC, qAC, qScale := pc.thumbHashChannel(q, w, h, 3, 3)
+
+	round := func(v float64) int { return int(math.Round(v)) }
+	alphaBit, landscapeBit, header16Size := 0, 0, lx
+	if hasAlpha {
+		alphaBit = 1
+	}
+	if w > h {
+		landscapeBit, header16Size = 1, ly
+	}
+	header24 := round(63*lDC) | round(31.5+31.5*pDC)<<6 | round(31.5+31.5*qDC)<<12 | round(31*lScale)<<18 | alphaBit<<23
+	header16 := header16Size | round(63*pScale)<<3 | round(63*qScale)<<9 | landscapeBit<<15
+	hash := []byte{byte(header24), byte(header24 >> 8), byte(header24 >> 16), byte(header16), byte(header16 >> 8)}
+
+	acs := [][]float64{lAC, pAC, qAC}
+	if hasAlpha {
+		aDC, aAC, aScale := pc.thumbHashChannel(a, w, h, 5, 5)
+		hash = append(hash, byte(round(15*aDC)|round(15*aScale)<<4))
+		acs = append(acs, aAC)
+	}
+
+	// varying factors packed two to a byte, low nibble first
+	index := 0
+	acStart := len(hash)
+	for _, ac := range acs {
+		for _, f := range ac {
+			if acStart+index>>1 == len(hash) {
+				hash = append(hash, 0)
+			}
+			hash[acStart+index>>1] |= byte(round(15*f) << ((index & 1) << 2))
+			index++
+		}
+	}
+
+	return hash
+}
+
+// DCT of a channel into its constant term, the varying terms normalized to [0,1] and their scale
+func (pc *PaletteCalculator) thumbHashChannel(channel []float64, w int, h int, nx int, ny int) (float64, []float64, float64) {
+	var dc, scale float64
+	var ac []float64
+	fx := make([]float64, w)
+
+	for cy := 0; cy < ny; cy++ {
+		for cx := 0; cx*ny < nx*(ny-cy); cx++ {
+			for x := 0; x < w; x++ {
+				fx[x] = math.Cos(math.Pi / float64(w) * float64(cx) * (float64(x) + .5))
+			}
+
+			f := float64(0)
+			for y := 0; y < h; y++ {
+				fy := math.Cos(math.Pi / float64(h) * float64(cy) * (float64(y) + .5))
+				for x := 0; x < w; x++ {
+					f += channel[x+y*w] * fx[x] * fy
+				}
+			}
+			f /= float64(w * h)
+
+			if cx > 0 || cy > 0 {
+				ac = append(ac, f)
+				scale = math.Max(scale, math.Abs(f))
+			} else {
+				dc = f
+			}
+		}
+	}
+
+	if scale > 0 {
+		for i := range ac {
+			ac[i] = .5 + .5/scale*ac[i]
+		}
+	}
+
+	return dc, ac, scale
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
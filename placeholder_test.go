@@ -0,0 +1,100 @@
+package palettecalculator
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	imagecolor "image/color"
+	"reflect"
+	"testing"
+)
+
+func solidImage(w int, h int, c imagecolor.Color) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+// red to the right, green downwards, larger than placeholderMaxSize so it is downscaled
+func gradientImage() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 300, 200))
+	for x := 0; x < 300; x++ {
+		for y := 0; y < 200; y++ {
+			img.Set(x, y, imagecolor.RGBA{R: uint8(x * 255 / 299), G: uint8(y * 255 / 199), B: 119, A: 255})
+		}
+	}
+	return img
+}
+
+func TestEncodeBlurHash(t *testing.T) {
+	for _, test := range []struct {
+		name             string
+		img              image.Image
+		xComponents      int
+		yComponents      int
+		expectedBlurHash string
+		expectedErr      error
+	}{
+		{name: "should encode solid image", img: solidImage(8, 6, imagecolor.RGBA{R: 255, A: 255}), xComponents: 4, yComponents: 3, expectedBlurHash: "LsTI:j]9fQ]9|csUfQsUfQfQfQfQ"},
+		{name: "should encode downscaled gradient", img: gradientImage(), xComponents: 4, yComponents: 3, expectedBlurHash: "L$HVCX2Y$5Sghpazjtf7gcfQfQfQ"},
+		{name: "should encode average color only", img: gradientImage(), xComponents: 1, yComponents: 1, expectedBlurHash: "00HVCX"},
+		{
+			name:        "error occurs for too many components",
+			img:         gradientImage(),
+			xComponents: 10,
+			yComponents: 1,
+			expectedErr: errors.New("invalid blurhash components 10x1: expected 1 to 9 on each axis"),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedBlurHash, err := paletteCalculator.EncodeBlurHash(test.img, test.xComponents, test.yComponents)
+
+			if test.expectedBlurHash != returnedBlurHash {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedBlurHash, returnedBlurHash)
+			}
+
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestEncodeThumbHash(t *testing.T) {
+	translucent := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 4; y++ {
+			alpha := uint8(255)
+			if x < 2 {
+				alpha = 0
+			}
+			translucent.Set(x, y, imagecolor.NRGBA{R: Red, G: Green, B: Blue, A: alpha})
+		}
+	}
+
+	for _, test := range []struct {
+		name              string
+		img               image.Image
+		expectedThumbHash string
+	}{
+		{name: "should encode solid image", img: solidImage(8, 6, imagecolor.RGBA{R: 255, A: 255}), expectedThumbHash: "d5fb0305805b9b3f7887787478d877826a7fa6f667"},
+		{name: "should encode downscaled gradient", img: gradientImage(), expectedThumbHash: "5ff809359a8087877078878788788777807007f888"},
+		{name: "should encode alpha", img: translucent, expectedThumbHash: "1466810500586b2089788708288078898cf9707b8888887878"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedThumbHash := fmt.Sprintf("%x", paletteCalculator.EncodeThumbHash(test.img))
+
+			if test.expectedThumbHash != returnedThumbHash {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedThumbHash, returnedThumbHash)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package palettecalculator
+
+import (
+	"bytes"
+	"sync"
+)
+
+// Buffers larger than this are left for the garbage collector rather than pooled, so one huge image does not pin
+// its memory for the life of the process
+const maxPooledBuffer = 16 << 20
+
+var bufferPool = sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}
+
+var histogramPool = sync.Pool{New: func() interface{} { return make(map[[3]uint8]int) }}
+
+// Buffer from the pool, empty and ready to use. Return it with putBuffer once nothing refers to its bytes
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBuffer {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+// Empty color counts from the pool. Return them with putHistogram once counted
+func getHistogram() map[[3]uint8]int {
+	return histogramPool.Get().(map[[3]uint8]int)
+}
+
+func putHistogram(counts map[[3]uint8]int) {
+	for rgb := range counts {
+		delete(counts, rgb)
+	}
+	histogramPool.Put(counts)
+}
@@ -0,0 +1,91 @@
+package palettecalculator
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestPutBuffer(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		size     int
+		expected bool
+	}{
+		{
+			name:     "should pool small buffers",
+			size:     1 << 10,
+			expected: true,
+		},
+		{
+			name:     "should drop buffers over the limit",
+			size:     maxPooledBuffer + 1,
+			expected: false,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.NewBuffer(make([]byte, test.size))
+			putBuffer(buf)
+
+			// the pool may drop anything it holds, so only a buffer that is kept has to come back empty
+			returned := getBuffer()
+			if returned == buf && (!test.expected || returned.Len() != 0) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expected, returned.Len())
+			}
+		})
+	}
+}
+
+// Shares one calculator between goroutines, run with -race
+func TestConcurrentUse(t *testing.T) {
+	pc := new(PaletteCalculator)
+	img := backgroundTestImage()
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, img); err != nil {
+		t.Fatal(err)
+	}
+	extraction := pc.Extract().FromImage(img).TopColors(2).ExcludeBackground().Scheme(Triadic)
+
+	run := func(ctx context.Context) (*ExtractionResult, *ExtractionResult, *Palette, error) {
+		fromImage, err := extraction.Run(ctx)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		fromReader, err := pc.WithContext(ctx).Extract().FromReader(bytes.NewReader(encoded.Bytes())).Local().TopColors(2).Run(ctx)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		palette, err := pc.ExtractPalette(img, 3)
+		return fromImage, fromReader, palette, err
+	}
+
+	expectedImage, expectedReader, expectedPalette, err := run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				ctx, cancel := context.WithCancel(context.Background())
+				fromImage, fromReader, palette, err := run(ctx)
+				cancel()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if !reflect.DeepEqual(fromImage, expectedImage) || !reflect.DeepEqual(fromReader, expectedReader) || !reflect.DeepEqual(palette, expectedPalette) {
+					t.Errorf("expected: %v %v %v\n returned: %v %v %v\n", expectedImage, expectedReader, expectedPalette, fromImage, fromReader, palette)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
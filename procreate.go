@@ -0,0 +1,81 @@
+package palettecalculator
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+)
+
+// Most swatches a Procreate palette holds
+const maxProcreateSwatches = 30
+
+// Procreate palette, a .swatches file is a zip holding a Swatches.json array of these
+type procreatePalette struct {
+	Name     string            `json:"name"`
+	Swatches []procreateSwatch `json:"swatches"`
+}
+
+// Procreate swatch, HSB components in [0,1]
+type procreateSwatch struct {
+	Hue        float64 `json:"hue"`
+	Saturation float64 `json:"saturation"`
+	Brightness float64 `json:"brightness"`
+	Alpha      float64 `json:"alpha"`
+	ColorSpace int     `json:"colorSpace"`
+}
+
+// Writes the palette as a Procreate .swatches file. Procreate palettes hold at most 30 colors and an unnamed
+// palette is named "Palette"
+func (pc *PaletteCalculator) WriteProcreateSwatches(w io.Writer, p *Palette) error {
+	if len(p.Colors) > maxProcreateSwatches {
+		return errors.New("procreate palettes hold at most 30 colors")
+	}
+
+	palette := procreatePalette{Name: p.Name, Swatches: []procreateSwatch{}}
+	if palette.Name == "" {
+		palette.Name = "Palette"
+	}
+	for i := range p.Colors {
+		h, s, b := pc.hsb(p.Colors[i].Clamp())
+		palette.Swatches = append(palette.Swatches, procreateSwatch{Hue: h, Saturation: s, Brightness: b, Alpha: 1})
+	}
+
+	archive := zip.NewWriter(w)
+	file, err := archive.Create("Swatches.json")
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(file).Encode([]procreatePalette{palette}); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+// Hue, saturation and brightness of the color, each in [0,1]
+func (pc *PaletteCalculator) hsb(c *Color) (float64, float64, float64) {
+	r, g, b := c.Red/255, c.Green/255, c.Blue/255
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+	delta := max - min
+
+	hue, saturation := float64(0), float64(0)
+	if max > 0 {
+		saturation = delta / max
+	}
+	if delta > 0 {
+		switch max {
+		case r:
+			hue = math.Mod((g-b)/delta+6, 6)
+		case g:
+			hue = (b-r)/delta + 2
+		default:
+			hue = (r-g)/delta + 4
+		}
+		hue /= 6
+	}
+
+	return roundFloat(hue, 4), roundFloat(saturation, 4), roundFloat(max, 4)
+}
@@ -0,0 +1,50 @@
+package palettecalculator
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestWriteProcreateSwatches(t *testing.T) {
+	palette := &Palette{Colors: []Color{{24, 98, 119, "186277"}, {255, 255, 255, "ffffff"}}}
+	expected := `[{"name":"Palette","swatches":[` +
+		`{"hue":0.5368,"saturation":0.7983,"brightness":0.4667,"alpha":1,"colorSpace":0},` +
+		`{"hue":0,"saturation":0,"brightness":1,"alpha":1,"colorSpace":0}]}]` + "\n"
+	paletteCalculator := new(PaletteCalculator)
+	var buf bytes.Buffer
+
+	err := paletteCalculator.WriteProcreateSwatches(&buf, palette)
+
+	if err != nil {
+		t.Errorf("expected error: %v returned error: %v", nil, err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("expected error: %v returned error: %v", nil, err)
+	}
+	if len(archive.File) != 1 || archive.File[0].Name != "Swatches.json" {
+		t.Fatalf("expected archive holding Swatches.json, returned: %v", archive.File)
+	}
+	file, _ := archive.File[0].Open()
+	returned, _ := io.ReadAll(file)
+	if expected != string(returned) {
+		t.Errorf("expected: %v\n returned: %v\n", expected, string(returned))
+	}
+}
+
+func TestWriteProcreateSwatchesWithTooManyColors(t *testing.T) {
+	palette := &Palette{Colors: make([]Color, 31)}
+	expectedErr := errors.New("procreate palettes hold at most 30 colors")
+	paletteCalculator := new(PaletteCalculator)
+	var buf bytes.Buffer
+
+	err := paletteCalculator.WriteProcreateSwatches(&buf, palette)
+
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Errorf("expected error: %v returned error: %v", expectedErr, err)
+	}
+}
@@ -0,0 +1,177 @@
+package palettecalculator
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"sort"
+)
+
+// Longest side of the pixel grid sampled when quantizing, larger images are sampled with a stride
+const quantizeSampleSize = 512
+
+// Passes of k-means refinement after median cut
+const quantizeRefinements = 4
+
+// Pixels of one RGB color and how often they were sampled
+type quantizeBin struct {
+	rgb   [3]uint8
+	count int
+}
+
+// Calculates a palette of at most k colors, up to 256, for encoding img as a GIF or PNG8, ready for
+// gif.Options or image.NewPaletted. Colors are found by median cut refined with k-means. Seeds, e.g. the
+// dominant colors from Vision, are kept as they are and count toward k. Transparent pixels are ignored
+func (pc *PaletteCalculator) QuantizeImage(img image.Image, k int, seeds ...Color) (color.Palette, error) {
+	if k < 1 || k > 256 {
+		return nil, errors.New("palette size must be between 1 and 256")
+	}
+	if len(seeds) > k {
+		return nil, errors.New("more seeds than palette colors")
+	}
+
+	bins := pc.quantizeHistogram(img)
+	if len(bins) == 0 && len(seeds) == 0 {
+		return nil, errors.New("image has no opaque pixels")
+	}
+
+	var centroids [][3]float64
+	for i := range seeds {
+		c := seeds[i].Clamp()
+		centroids = append(centroids, [3]float64{c.Red, c.Green, c.Blue})
+	}
+	if len(bins) <= k-len(seeds) {
+		// few enough colors to keep every one exactly
+		for _, bin := range bins {
+			centroids = append(centroids, [3]float64{float64(bin.rgb[RED]), float64(bin.rgb[GREEN]), float64(bin.rgb[BLUE])})
+		}
+	} else {
+		centroids = append(centroids, pc.medianCut(bins, k-len(seeds))...)
+		pc.refineCentroids(bins, centroids, len(seeds))
+	}
+
+	var palette color.Palette
+	for _, c := range centroids {
+		palette = append(palette, toNRGBA(&Color{Red: c[RED], Green: c[GREEN], Blue: c[BLUE]}))
+	}
+
+	return palette, nil
+}
+
+// Counts the distinct opaque colors of a sample of img's pixels
+func (pc *PaletteCalculator) quantizeHistogram(img image.Image) []quantizeBin {
+	bounds := img.Bounds()
+	stride := maxInt(1, (maxInt(bounds.Dx(), bounds.Dy())+quantizeSampleSize-1)/quantizeSampleSize)
+
+	counts := getHistogram()
+	defer putHistogram(counts)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += stride {
+		for x := bounds.Min.X; x < bounds.Max.X; x += stride {
+			px := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if px.A == 0 {
+				continue
+			}
+			counts[[3]uint8{px.R, px.G, px.B}]++
+		}
+	}
+
+	var bins []quantizeBin
+	for rgb, count := range counts {
+		bins = append(bins, quantizeBin{rgb: rgb, count: count})
+	}
+	// map order is random, sort so the palette is deterministic
+	sort.Slice(bins, func(i, j int) bool {
+		a, b := bins[i].rgb, bins[j].rgb
+		return int(a[RED])<<16|int(a[GREEN])<<8|int(a[BLUE]) < int(b[RED])<<16|int(b[GREEN])<<8|int(b[BLUE])
+	})
+
+	return bins
+}
+
+// Splits the bins into k boxes, each time cutting the box with the widest channel range at its weighted median,
+// and returns the weighted mean of each box
+func (pc *PaletteCalculator) medianCut(bins []quantizeBin, k int) [][3]float64 {
+	boxes := [][]quantizeBin{bins}
+
+	for len(boxes) < k {
+		widest, channel, spread := -1, 0, 0
+		for i, box := range boxes {
+			if len(box) < 2 {
+				continue
+			}
+			for ch := 0; ch < 3; ch++ {
+				lo, hi := 255, 0
+				for _, bin := range box {
+					lo, hi = minInt(lo, int(bin.rgb[ch])), maxInt(hi, int(bin.rgb[ch]))
+				}
+				if hi-lo > spread {
+					widest, channel, spread = i, ch, hi-lo
+				}
+			}
+		}
+		if widest < 0 {
+			break
+		}
+
+		box := boxes[widest]
+		sort.SliceStable(box, func(i, j int) bool { return box[i].rgb[channel] < box[j].rgb[channel] })
+		total := 0
+		for _, bin := range box {
+			total += bin.count
+		}
+		cut, seen := 1, 0
+		for i, bin := range box[:len(box)-1] {
+			seen += bin.count
+			cut = i + 1
+			if 2*seen >= total {
+				break
+			}
+		}
+
+		boxes = append(boxes, box[cut:])
+		boxes[widest] = box[:cut]
+	}
+
+	var centroids [][3]float64
+	for _, box := range boxes {
+		centroids = append(centroids, pc.binMean(box))
+	}
+
+	return centroids
+}
+
+// Moves every centroid from fixed on to the weighted mean of the bins nearest it, seeds before fixed stay put
+func (pc *PaletteCalculator) refineCentroids(bins []quantizeBin, centroids [][3]float64, fixed int) {
+	for pass := 0; pass < quantizeRefinements; pass++ {
+		members := make([][]quantizeBin, len(centroids))
+		for _, bin := range bins {
+			nearest, best := 0, float64(-1)
+			for i, c := range centroids {
+				dr, dg, db := float64(bin.rgb[RED])-c[RED], float64(bin.rgb[GREEN])-c[GREEN], float64(bin.rgb[BLUE])-c[BLUE]
+				if d := dr*dr + dg*dg + db*db; best < 0 || d < best {
+					nearest, best = i, d
+				}
+			}
+			members[nearest] = append(members[nearest], bin)
+		}
+
+		for i := fixed; i < len(centroids); i++ {
+			if len(members[i]) > 0 {
+				centroids[i] = pc.binMean(members[i])
+			}
+		}
+	}
+}
+
+func (pc *PaletteCalculator) binMean(bins []quantizeBin) [3]float64 {
+	var sum [3]float64
+	total := 0
+	for _, bin := range bins {
+		for ch := 0; ch < 3; ch++ {
+			sum[ch] += float64(bin.rgb[ch]) * float64(bin.count)
+		}
+		total += bin.count
+	}
+
+	return [3]float64{sum[RED] / float64(total), sum[GREEN] / float64(total), sum[BLUE] / float64(total)}
+}
@@ -0,0 +1,73 @@
+package palettecalculator
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestQuantizeImage(t *testing.T) {
+	// left half two close blues, right half two close oranges
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for x, c := range []color.NRGBA{{20, 90, 110, 255}, {28, 106, 128, 255}, {230, 120, 30, 255}, {240, 130, 40, 255}} {
+		img.SetNRGBA(x, 0, c)
+		img.SetNRGBA(x, 1, c)
+	}
+	img.SetNRGBA(0, 1, color.NRGBA{255, 255, 255, 0})
+	paletteCalculator := new(PaletteCalculator)
+	tests := []struct {
+		name     string
+		k        int
+		seeds    []Color
+		expected color.Palette
+	}{
+		{
+			name: "should keep colors exactly when they fit", k: 4,
+			expected: color.Palette{color.NRGBA{20, 90, 110, 255}, color.NRGBA{28, 106, 128, 255}, color.NRGBA{230, 120, 30, 255}, color.NRGBA{240, 130, 40, 255}},
+		},
+		{
+			name: "should merge close colors", k: 2,
+			expected: color.Palette{color.NRGBA{25, 101, 122, 255}, color.NRGBA{235, 125, 35, 255}},
+		},
+		{
+			name: "should keep seeds", k: 2, seeds: []Color{{24, 98, 119, "186277"}},
+			expected: color.Palette{color.NRGBA{24, 98, 119, 255}, color.NRGBA{235, 125, 35, 255}},
+		},
+	}
+
+	for _, test := range tests {
+		returned, err := paletteCalculator.QuantizeImage(img, test.k, test.seeds...)
+
+		if err != nil {
+			t.Errorf("%s expected error: %v returned error: %v", test.name, nil, err)
+		}
+		if !reflect.DeepEqual(test.expected, returned) {
+			t.Errorf("%s expected: %v\n returned: %v\n", test.name, test.expected, returned)
+		}
+	}
+}
+
+func TestQuantizeImageWithInvalidInput(t *testing.T) {
+	paletteCalculator := new(PaletteCalculator)
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	tests := []struct {
+		k           int
+		seeds       []Color
+		expectedErr error
+	}{
+		{0, nil, errors.New("palette size must be between 1 and 256")},
+		{257, nil, errors.New("palette size must be between 1 and 256")},
+		{1, []Color{{}, {}}, errors.New("more seeds than palette colors")},
+		{4, nil, errors.New("image has no opaque pixels")},
+	}
+
+	for _, test := range tests {
+		_, err := paletteCalculator.QuantizeImage(img, test.k, test.seeds...)
+
+		if !reflect.DeepEqual(test.expectedErr, err) {
+			t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
+		}
+	}
+}
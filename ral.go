@@ -0,0 +1,19 @@
+package palettecalculator
+
+import "errors"
+
+// sRGB approximations of RAL Classic colors differ between sources and the official values are licensed by RAL,
+// so no table ships with the package. Load the table your suppliers work from with LoadColorTable, naming each
+// row by its code, e.g. "RAL 5015"
+var ErrEmptyRALTable = errors.New("ral table is empty, load one with LoadColorTable")
+
+// Finds the RAL Classic color of table closest to c by CIEDE2000, e.g. a paint or powder coat equivalent of an
+// extracted color. Returns the RAL color and its distance from c, a Delta E above about 2 is a visible mismatch
+func (pc *PaletteCalculator) NearestRAL(c *Color, table []NamedColor) (*NamedColor, float64, error) {
+	if len(table) == 0 {
+		return nil, 0, ErrEmptyRALTable
+	}
+
+	nearest, distance := pc.nearestNamedColor(c, table)
+	return nearest, distance, nil
+}
@@ -0,0 +1,39 @@
+package palettecalculator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNearestRAL(t *testing.T) {
+	table, _ := LoadColorTable(strings.NewReader("code,hex\nRAL Sample 1,#C8102E\nRAL Sample 2,#1f6277\n"))
+
+	for _, test := range []struct {
+		name             string
+		table            []NamedColor
+		expectedName     string
+		expectedDistance float64
+		expectedErr      error
+	}{
+		{name: "should find nearest RAL color", table: table, expectedName: "RAL Sample 2", expectedDistance: .6568},
+		{name: "error occurs for empty table", table: nil, expectedErr: ErrEmptyRALTable},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedNamedColor, returnedDistance, err := paletteCalculator.NearestRAL(&Color{Red, Green, Blue, Hex}, test.table)
+
+			if returnedNamedColor != nil && test.expectedName != returnedNamedColor.Name {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedName, returnedNamedColor.Name)
+			}
+
+			if test.expectedDistance != roundFloat(returnedDistance, 4) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedDistance, returnedDistance)
+			}
+
+			if test.expectedErr != err {
+				t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
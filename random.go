@@ -0,0 +1,121 @@
+package palettecalculator
+
+import (
+	"errors"
+	"hash/fnv"
+	"math"
+	"math/rand"
+)
+
+// Default number of candidates drawn per color before giving up on the Delta E constraint
+const defaultMaxAttempts = 1000
+
+// Constraints for a random color. Hues run from MinHue to MaxHue and wrap past 360, e.g. 330 to 30 are reds.
+// Zero hue bounds allow every hue, equal nonzero bounds pin it, and zero maximum saturation or luminosity defaults to 1
+type RandomColorOptions struct {
+	Seed          int64
+	MinHue        float64
+	MaxHue        float64
+	MinSaturation float64
+	MaxSaturation float64
+	MinLuminosity float64
+	MaxLuminosity float64
+}
+
+// Constraints for random palette generation. Hue, saturation and luminosity bounds behave as in RandomColorOptions,
+// zero MaxAttempts defaults to defaultMaxAttempts
+type RandomPaletteOptions struct {
+	Seed          int64
+	MinHue        float64
+	MaxHue        float64
+	MinSaturation float64
+	MaxSaturation float64
+	MinLuminosity float64
+	MaxLuminosity float64
+	MinDeltaE     float64
+	MaxAttempts   int
+}
+
+// Derives a generator seed from a string, e.g. a user name for placeholder avatars
+func SeedFromString(s string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+
+	return int64(h.Sum64())
+}
+
+// Generates a random color within the hue, saturation and luminosity ranges. The same options always produce the
+// same color, e.g. seeding with SeedFromString gives each user a stable placeholder color that fits the theme
+func (pc *PaletteCalculator) RandomColor(opts RandomColorOptions) *Color {
+	return pc.randomColor(rand.New(rand.NewSource(opts.Seed)), opts)
+}
+
+// Generates k random colors within the hue, saturation and luminosity ranges, each at least MinDeltaE from the others.
+// The same options always produce the same palette
+func (pc *PaletteCalculator) GenerateRandomPalette(k int, opts RandomPaletteOptions) ([]Color, error) {
+	if opts.MaxAttempts == 0 {
+		opts.MaxAttempts = defaultMaxAttempts
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+
+	var colors []Color
+	for len(colors) < k {
+		c, ok := pc.randomDistinctColor(rng, colors, opts)
+		if !ok {
+			return nil, errors.New("unable to generate palette satisfying minimum delta e")
+		}
+		colors = append(colors, *c)
+	}
+
+	return colors, nil
+}
+
+func (pc *PaletteCalculator) randomDistinctColor(rng *rand.Rand, colors []Color, opts RandomPaletteOptions) (*Color, bool) {
+	colorOpts := RandomColorOptions{
+		MinHue:        opts.MinHue,
+		MaxHue:        opts.MaxHue,
+		MinSaturation: opts.MinSaturation,
+		MaxSaturation: opts.MaxSaturation,
+		MinLuminosity: opts.MinLuminosity,
+		MaxLuminosity: opts.MaxLuminosity,
+	}
+
+	for attempt := 0; attempt < opts.MaxAttempts; attempt++ {
+		c := pc.randomColor(rng, colorOpts)
+
+		distinct := true
+		for i := range colors {
+			if pc.deltaE76(c, &colors[i]) < opts.MinDeltaE {
+				distinct = false
+				break
+			}
+		}
+		if distinct {
+			return c, true
+		}
+	}
+
+	return nil, false
+}
+
+func (pc *PaletteCalculator) randomColor(rng *rand.Rand, opts RandomColorOptions) *Color {
+	if opts.MaxSaturation == 0 {
+		opts.MaxSaturation = 1
+	}
+	if opts.MaxLuminosity == 0 {
+		opts.MaxLuminosity = 1
+	}
+
+	hueSpan := math.Mod(opts.MaxHue-opts.MinHue+360, 360)
+	if hueSpan == 0 && (opts.MinHue != opts.MaxHue || opts.MinHue == 0) {
+		// unset bounds, or bounds a full turn apart such as 0 to 360
+		hueSpan = 360
+	}
+
+	return pc.ConvertHSLToRGB(&HSL{
+		Hue:        math.Mod(pc.round(opts.MinHue+rng.Float64()*hueSpan, 0), 360),
+		Saturation: pc.round(opts.MinSaturation+rng.Float64()*(opts.MaxSaturation-opts.MinSaturation), 2),
+		Luminosity: pc.round(opts.MinLuminosity+rng.Float64()*(opts.MaxLuminosity-opts.MinLuminosity), 2),
+	})
+}
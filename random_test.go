@@ -0,0 +1,108 @@
+package palettecalculator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGenerateRandomPalette(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		k           int
+		opts        RandomPaletteOptions
+		expectedRGB []Color
+		expectedErr error
+	}{
+		{
+			name:        "should generate palette within constraints",
+			k:           3,
+			opts:        RandomPaletteOptions{Seed: 42, MinSaturation: .5, MinLuminosity: .4, MaxLuminosity: .6, MinDeltaE: 20},
+			expectedRGB: []Color{{68, 197, 99, "44c563"}, {156, 186, 59, "9cba3b"}, {190, 38, 207, "be26cf"}},
+			expectedErr: nil,
+		},
+		{
+			name:        "should generate palette seeded by string",
+			k:           3,
+			opts:        RandomPaletteOptions{Seed: SeedFromString("evan"), MinDeltaE: 20},
+			expectedRGB: []Color{{245, 230, 245, "f5e6f5"}, {238, 245, 209, "eef5d1"}, {72, 56, 20, "483814"}},
+			expectedErr: nil,
+		},
+		{
+			name:        "error occurs when delta e cannot be satisfied",
+			k:           3,
+			opts:        RandomPaletteOptions{Seed: 1, MaxSaturation: .01, MinLuminosity: .5, MaxLuminosity: .51, MinDeltaE: 50},
+			expectedRGB: nil,
+			expectedErr: errors.New("unable to generate palette satisfying minimum delta e"),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedRGB, err := paletteCalculator.GenerateRandomPalette(test.k, test.opts)
+
+			if !reflect.DeepEqual(test.expectedRGB, returnedRGB) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedRGB, returnedRGB)
+			}
+
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestGenerateRandomPaletteIsDeterministic(t *testing.T) {
+	opts := RandomPaletteOptions{Seed: 7, MinDeltaE: 10}
+	paletteCalculator := new(PaletteCalculator)
+
+	first, _ := paletteCalculator.GenerateRandomPalette(5, opts)
+	second, _ := paletteCalculator.GenerateRandomPalette(5, opts)
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("expected: %v\n returned: %v\n", first, second)
+	}
+
+	for i := range first {
+		for j := i + 1; j < len(first); j++ {
+			if d := paletteCalculator.deltaE76(&first[i], &first[j]); d < opts.MinDeltaE {
+				t.Errorf("delta e between %v and %v is %f, below %f", first[i], first[j], d, opts.MinDeltaE)
+			}
+		}
+	}
+}
+
+func TestRandomColor(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		opts          RandomColorOptions
+		expectedColor *Color
+	}{
+		{name: "should generate color from seed", opts: RandomColorOptions{Seed: 42}, expectedColor: &Color{146, 160, 149, "92a095"}},
+		{
+			name:          "should generate color in hue range wrapping past 360",
+			opts:          RandomColorOptions{Seed: SeedFromString("evan"), MinHue: 330, MaxHue: 30, MinSaturation: .6, MinLuminosity: .4, MaxLuminosity: .6},
+			expectedColor: &Color{231, 124, 70, "e77c46"},
+		},
+		{
+			name:          "should generate muted color in hue range",
+			opts:          RandomColorOptions{Seed: 7, MinHue: 180, MaxHue: 240, MaxSaturation: .3},
+			expectedColor: &Color{57, 58, 66, "393a42"},
+		},
+		{
+			name:          "should pin hue to equal bounds",
+			opts:          RandomColorOptions{Seed: 7, MinHue: 120, MaxHue: 120, MinSaturation: 1, MinLuminosity: .5, MaxLuminosity: .5},
+			expectedColor: &Color{0, 255, 0, "00ff00"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedColor := paletteCalculator.RandomColor(test.opts)
+
+			if !reflect.DeepEqual(test.expectedColor, returnedColor) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedColor, returnedColor)
+			}
+		})
+	}
+}
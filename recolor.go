@@ -0,0 +1,93 @@
+package palettecalculator
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"math"
+)
+
+// Shifts the colors of img towards a target palette by Reinhard color transfer in LAB. The image's a and b channels
+// are rescaled to the weighted mean and spread of the palette's, lightness is moved to the palette's mean lightness
+// but keeps the image's own contrast. Alpha is kept
+func (pc *PaletteCalculator) RecolorImage(img image.Image, target *Palette) (image.Image, error) {
+	if len(target.Colors) == 0 {
+		return nil, errors.New("palette has no colors")
+	}
+
+	bounds := img.Bounds()
+	labs := make([]*LAB, 0, bounds.Dx()*bounds.Dy())
+	alphas := make([]uint8, 0, bounds.Dx()*bounds.Dy())
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			px := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			labs = append(labs, pc.ConvertRGBToLAB(&Color{Red: float64(px.R), Green: float64(px.G), Blue: float64(px.B)}))
+			alphas = append(alphas, px.A)
+		}
+	}
+
+	var weights []float64
+	for i := range labs {
+		weights = append(weights, float64(alphas[i]))
+	}
+	sourceMean, sourceSpread := pc.labStatistics(labs, weights)
+
+	var targetLabs []*LAB
+	var targetWeights []float64
+	for i := range target.Colors {
+		targetLabs = append(targetLabs, pc.ConvertRGBToLAB(&target.Colors[i]))
+		targetWeights = append(targetWeights, target.weight(i))
+	}
+	targetMean, targetSpread := pc.labStatistics(targetLabs, targetWeights)
+
+	scale := func(source float64, target float64) float64 {
+		if source == 0 {
+			return 0
+		}
+		return target / source
+	}
+	scaleA, scaleB := scale(sourceSpread.A, targetSpread.A), scale(sourceSpread.B, targetSpread.B)
+
+	out := image.NewNRGBA(bounds)
+	i := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			lab := labs[i]
+			c := pc.ConvertLABToRGB(&LAB{
+				L: math.Max(0, math.Min(100, lab.L-sourceMean.L+targetMean.L)),
+				A: (lab.A-sourceMean.A)*scaleA + targetMean.A,
+				B: (lab.B-sourceMean.B)*scaleB + targetMean.B,
+			})
+			out.SetNRGBA(x, y, color.NRGBA{R: uint8(c.Red), G: uint8(c.Green), B: uint8(c.Blue), A: alphas[i]})
+			i++
+		}
+	}
+
+	return out, nil
+}
+
+// Weighted mean and standard deviation of each LAB channel
+func (pc *PaletteCalculator) labStatistics(labs []*LAB, weights []float64) (*LAB, *LAB) {
+	mean, spread := &LAB{}, &LAB{}
+
+	total := float64(0)
+	for i, lab := range labs {
+		mean.L += lab.L * weights[i]
+		mean.A += lab.A * weights[i]
+		mean.B += lab.B * weights[i]
+		total += weights[i]
+	}
+	if total == 0 {
+		return mean, spread
+	}
+	mean.L, mean.A, mean.B = mean.L/total, mean.A/total, mean.B/total
+
+	for i, lab := range labs {
+		spread.L += (lab.L - mean.L) * (lab.L - mean.L) * weights[i]
+		spread.A += (lab.A - mean.A) * (lab.A - mean.A) * weights[i]
+		spread.B += (lab.B - mean.B) * (lab.B - mean.B) * weights[i]
+	}
+	spread.L, spread.A, spread.B = math.Sqrt(spread.L/total), math.Sqrt(spread.A/total), math.Sqrt(spread.B/total)
+
+	return mean, spread
+}
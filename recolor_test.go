@@ -0,0 +1,57 @@
+package palettecalculator
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestRecolorImage(t *testing.T) {
+	for _, test := range []struct {
+		name           string
+		target         *Palette
+		expectedPixels []uint8
+		expectedErr    error
+	}{
+		{
+			name:           "should shift colors towards weighted palette",
+			target:         &Palette{Colors: []Color{{Red, Green, Blue, Hex}, {119, 45, 24, "772d18"}}, Weights: []float64{3, 1}},
+			expectedPixels: []uint8{0, 13, 46, 255, 138, 185, 212, 255, 67, 96, 70, 255, 132, 25, 29, 128},
+		},
+		{
+			name:           "should tint towards single color keeping contrast",
+			target:         &Palette{Colors: []Color{{Red, Green, Blue, Hex}}},
+			expectedPixels: []uint8{0, 22, 38, 255, 130, 194, 217, 255, 31, 102, 123, 255, 0, 80, 101, 128},
+		},
+		{
+			name:        "error occurs for empty palette",
+			target:      &Palette{},
+			expectedErr: errors.New("palette has no colors"),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			// dark gray, off white, olive and translucent red
+			img := image.NewNRGBA(image.Rect(0, 0, 4, 1))
+			img.Set(0, 0, color.NRGBA{R: 40, G: 40, B: 40, A: 255})
+			img.Set(1, 0, color.NRGBA{R: 220, G: 220, B: 210, A: 255})
+			img.Set(2, 0, color.NRGBA{R: 120, G: 130, B: 90, A: 255})
+			img.Set(3, 0, color.NRGBA{R: 200, G: 30, B: 60, A: 128})
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedImg, err := paletteCalculator.RecolorImage(img, test.target)
+
+			if test.expectedPixels != nil {
+				nrgba, ok := returnedImg.(*image.NRGBA)
+				if !ok || !reflect.DeepEqual(test.expectedPixels, nrgba.Pix) {
+					t.Errorf("expected: %v\n returned: %v\n", test.expectedPixels, returnedImg)
+				}
+			}
+
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package palettecalculator
+
+import (
+	"html/template"
+	"io"
+)
+
+// Pairwise WCAG contrast ratios of a palette and the pairs falling short of the minimum ratio
+type ContrastReport struct {
+	Colors  []Color           `json:"colors"`
+	Ratios  [][]float64       `json:"ratios"`
+	Minimum float64           `json:"minimum"`
+	Failing []ContrastFailure `json:"failing"`
+}
+
+// Pair of palette indices whose contrast falls short of the report's minimum
+type ContrastFailure struct {
+	Foreground int     `json:"foreground"`
+	Background int     `json:"background"`
+	Ratio      float64 `json:"ratio"`
+}
+
+var contrastReportTemplate = template.Must(template.New("contrast").Funcs(template.FuncMap{
+	"css":     func(hex string) template.CSS { return template.CSS("#" + hex) },
+	"failing": func(r *ContrastReport, i int, j int) bool { return i != j && r.Ratios[i][j] < r.Minimum },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Contrast report</title>
+<style>
+table { border-collapse: collapse; font-family: sans-serif; }
+th, td { border: 1px solid #ccc; padding: 6px 10px; text-align: center; }
+td.fail { background: #fdd; color: #900; font-weight: bold; }
+</style>
+</head>
+<body>
+<table>
+<tr><th></th>{{range .Colors}}<th style="background: {{css .Hex}}">#{{.Hex}}</th>{{end}}</tr>
+{{$r := .}}{{range $i, $c := .Colors}}<tr><th style="background: {{css $c.Hex}}">#{{$c.Hex}}</th>{{range $j, $ratio := index $r.Ratios $i}}<td{{if failing $r $i $j}} class="fail"{{end}}>{{$ratio}}</td>{{end}}</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// Calculates the contrast ratio of every pair of palette colors, flagging pairs below level for the text size
+func (pc *PaletteCalculator) ContrastMatrix(p *Palette, level ContrastLevel, size TextSize) *ContrastReport {
+	required := pc.requiredContrast(level, size)
+	report := &ContrastReport{Colors: p.Colors, Minimum: required, Failing: []ContrastFailure{}}
+
+	for i := range p.Colors {
+		var row []float64
+		for j := range p.Colors {
+			ratio := roundFloat(pc.ContrastRatio(&p.Colors[i], &p.Colors[j]), 2)
+			row = append(row, ratio)
+
+			// contrast is symmetric, flag each pair once
+			if j > i && ratio < required {
+				report.Failing = append(report.Failing, ContrastFailure{Foreground: i, Background: j, Ratio: ratio})
+			}
+		}
+		report.Ratios = append(report.Ratios, row)
+	}
+
+	return report
+}
+
+// Writes the report as an HTML table, failing pairs highlighted
+func (r *ContrastReport) WriteHTML(w io.Writer) error {
+	return contrastReportTemplate.Execute(w, r)
+}
+
+// Schemes shown in a palette report, built from the palette's first color
+var reportSchemes = []SchemeType{Complimentary, SplitComplimentary, Triadic, Tetradic, DoubleSplitComplimentary, Analogous, Monochromatic}
+
+// Palette, its scheme variants and contrast matrix, the data rendered by a palette report template
+type PaletteReport struct {
+	Palette  *Palette        `json:"palette"`
+	Schemes  []ReportScheme  `json:"schemes"`
+	Contrast *ContrastReport `json:"contrast"`
+}
+
+// Scheme variant of a palette report
+type ReportScheme struct {
+	Scheme SchemeType `json:"scheme"`
+	Colors []Color    `json:"colors"`
+}
+
+// Functions available to palette report templates: hex and css format a Color as #rrggbb, failing reports whether
+// a contrast ratio falls short
+var PaletteReportFuncs = template.FuncMap{
+	"hex":     func(c Color) string { return cssHex(&c) },
+	"css":     func(c Color) template.CSS { return template.CSS(cssHex(&c)) },
+	"failing": func(r *ContrastReport, i int, j int) bool { return i != j && r.Ratios[i][j] < r.Minimum },
+}
+
+const paletteReportHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{with .Palette.Name}}{{.}}{{else}}Palette{{end}} report</title>
+<style>
+{{block "style" .}}body { font-family: sans-serif; margin: 2em; color: #222; }
+.swatches { display: flex; flex-wrap: wrap; gap: 8px; margin-bottom: 2em; }
+.swatch { width: 96px; height: 96px; border-radius: 4px; display: flex; align-items: flex-end; padding: 6px; box-sizing: border-box; font-family: monospace; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 6px 10px; text-align: center; }
+td.fail { background: #fdd; color: #900; font-weight: bold; }
+{{end}}
+</style>
+</head>
+<body>
+<h1>{{with .Palette.Name}}{{.}}{{else}}Palette{{end}}</h1>
+{{block "swatches" .}}<div class="swatches">
+{{range .Palette.Colors}}<div class="swatch" style="background: {{css .}}; color: {{if .IsDark}}#fff{{else}}#000{{end}}">{{hex .}}</div>
+{{end}}</div>
+{{end}}
+{{block "schemes" .}}{{range .Schemes}}<h2>{{.Scheme}}</h2>
+<div class="swatches">
+{{range .Colors}}<div class="swatch" style="background: {{css .}}; color: {{if .IsDark}}#fff{{else}}#000{{end}}">{{hex .}}</div>
+{{end}}</div>
+{{end}}{{end}}
+{{block "contrast" .Contrast}}<h2>Contrast</h2>
+<table>
+<tr><th></th>{{range .Colors}}<th style="background: {{css .}}">{{hex .}}</th>{{end}}</tr>
+{{$r := .}}{{range $i, $c := .Colors}}<tr><th style="background: {{css $c}}">{{hex $c}}</th>{{range $j, $ratio := index $r.Ratios $i}}<td{{if failing $r $i $j}} class="fail"{{end}}>{{$ratio}}</td>{{end}}</tr>
+{{end}}</table>
+{{end}}
+</body>
+</html>
+`
+
+var defaultPaletteReportTemplate = NewPaletteReportTemplate()
+
+// Parses a fresh copy of the default palette report template. Theme it by overriding its "style" block, or replace
+// any of its "swatches", "schemes" and "contrast" blocks:
+//
+//	tmpl := template.Must(NewPaletteReportTemplate().Parse(`{{define "style"}}...{{end}}`))
+func NewPaletteReportTemplate() *template.Template {
+	return template.Must(template.New("palette").Funcs(PaletteReportFuncs).Parse(paletteReportHTML))
+}
+
+// Collects a palette report: schemes of the palette's first color and the contrast matrix checked against level
+func (pc *PaletteCalculator) NewPaletteReport(p *Palette, level ContrastLevel, size TextSize) (*PaletteReport, error) {
+	report := &PaletteReport{Palette: p, Contrast: pc.ContrastMatrix(p, level, size)}
+
+	if len(p.Colors) > 0 {
+		for _, scheme := range reportSchemes {
+			colors, err := pc.CalculateScheme(&p.Colors[0], scheme)
+			if err != nil {
+				return nil, err
+			}
+			report.Schemes = append(report.Schemes, ReportScheme{Scheme: scheme, Colors: colors})
+		}
+	}
+
+	return report, nil
+}
+
+// Writes the report as a single self-contained HTML file using the default template
+func (r *PaletteReport) WriteHTML(w io.Writer) error {
+	return r.WriteHTMLTemplate(w, defaultPaletteReportTemplate)
+}
+
+// Writes the report with a custom template, e.g. a themed NewPaletteReportTemplate. Templates
+// parsed from scratch need PaletteReportFuncs
+func (r *PaletteReport) WriteHTMLTemplate(w io.Writer, tmpl *template.Template) error {
+	return tmpl.Execute(w, r)
+}
@@ -0,0 +1,111 @@
+package palettecalculator
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestContrastMatrix(t *testing.T) {
+	palette := &Palette{Colors: []Color{{Red, Green, Blue, Hex}, {255, 255, 255, "ffffff"}, {119, 45, 24, "772d18"}}}
+	expectedReport := &ContrastReport{
+		Colors:  palette.Colors,
+		Ratios:  [][]float64{{1, 6.88, 1.4}, {6.88, 1, 9.66}, {1.4, 9.66, 1}},
+		Minimum: AANormalText,
+		Failing: []ContrastFailure{{Foreground: 0, Background: 2, Ratio: 1.4}},
+	}
+	paletteCalculator := new(PaletteCalculator)
+
+	returnedReport := paletteCalculator.ContrastMatrix(palette, AA, NormalText)
+
+	if !reflect.DeepEqual(expectedReport, returnedReport) {
+		t.Errorf("expected: %+v\n returned: %+v\n", expectedReport, returnedReport)
+	}
+
+}
+
+func TestContrastReportJSON(t *testing.T) {
+	palette := &Palette{Colors: []Color{{Red, Green, Blue, Hex}, {255, 255, 255, "ffffff"}}}
+	expectedJSON := `{"colors":[{"red":24,"green":98,"blue":119,"hex":"186277"},{"red":255,"green":255,"blue":255,"hex":"ffffff"}],"ratios":[[1,6.88],[6.88,1]],"minimum":7,"failing":[{"foreground":0,"background":1,"ratio":6.88}]}`
+	paletteCalculator := new(PaletteCalculator)
+
+	returnedJSON, err := json.Marshal(paletteCalculator.ContrastMatrix(palette, AAA, NormalText))
+
+	if err != nil {
+		t.Errorf("expected error: %v returned error: %v", nil, err)
+	}
+
+	if expectedJSON != string(returnedJSON) {
+		t.Errorf("expected: %s\n returned: %s\n", expectedJSON, returnedJSON)
+	}
+
+}
+
+func TestContrastReportWriteHTML(t *testing.T) {
+	palette := &Palette{Colors: []Color{{Red, Green, Blue, Hex}, {255, 255, 255, "ffffff"}, {119, 45, 24, "772d18"}}}
+	paletteCalculator := new(PaletteCalculator)
+	var buf bytes.Buffer
+
+	err := paletteCalculator.ContrastMatrix(palette, AA, NormalText).WriteHTML(&buf)
+
+	if err != nil {
+		t.Errorf("expected error: %v returned error: %v", nil, err)
+	}
+
+	for _, expected := range []string{`<th style="background: #186277">#186277</th>`, `<td class="fail">1.4</td>`, `<td>9.66</td>`} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("expected html to contain: %s\n returned: %s\n", expected, buf.String())
+		}
+	}
+
+}
+
+func TestPaletteReportWriteHTML(t *testing.T) {
+	palette := &Palette{Name: "photo", Colors: []Color{{Red, Green, Blue, Hex}, {255, 255, 255, "ffffff"}}}
+	paletteCalculator := new(PaletteCalculator)
+	var buf bytes.Buffer
+
+	report, err := paletteCalculator.NewPaletteReport(palette, AA, NormalText)
+	if err != nil {
+		t.Fatalf("expected error: %v returned error: %v", nil, err)
+	}
+	err = report.WriteHTML(&buf)
+
+	if err != nil {
+		t.Errorf("expected error: %v returned error: %v", nil, err)
+	}
+	if len(report.Schemes) != len(reportSchemes) {
+		t.Errorf("expected schemes: %d\n returned: %d\n", len(reportSchemes), len(report.Schemes))
+	}
+	for _, expected := range []string{
+		"<title>photo report</title>",
+		`<div class="swatch" style="background: #186277; color: #fff">#186277</div>`,
+		"<h2>triadic</h2>",
+		`<th style="background: #ffffff">#ffffff</th>`,
+		`<td>6.88</td>`,
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("expected html to contain: %s\n returned: %s\n", expected, buf.String())
+		}
+	}
+}
+
+func TestPaletteReportWriteHTMLTemplate(t *testing.T) {
+	palette := &Palette{Colors: []Color{{Red, Green, Blue, Hex}}}
+	paletteCalculator := new(PaletteCalculator)
+	themed := template.Must(NewPaletteReportTemplate().Parse(`{{define "style"}}body { background: #111; }{{end}}`))
+	var buf bytes.Buffer
+
+	report, _ := paletteCalculator.NewPaletteReport(palette, AA, NormalText)
+	err := report.WriteHTMLTemplate(&buf, themed)
+
+	if err != nil {
+		t.Errorf("expected error: %v returned error: %v", nil, err)
+	}
+	if !strings.Contains(buf.String(), "<style>\nbody { background: #111; }\n</style>") || strings.Contains(buf.String(), "font-family: sans-serif") {
+		t.Errorf("expected themed style\n returned: %s\n", buf.String())
+	}
+}
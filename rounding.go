@@ -0,0 +1,46 @@
+package palettecalculator
+
+import (
+	"math"
+)
+
+// How color math rounds its intermediate and final values
+type RoundingMode int
+
+const (
+	// Rounds half away from zero, the default
+	RoundHalfAwayFromZero RoundingMode = iota
+	// Rounds half to even, banker's rounding
+	RoundHalfEven
+	// Keeps full precision, for exact pipelines
+	RoundNone
+)
+
+// Rounding applied by conversions and scheme generation. Decimals, when greater than zero, replaces the built in
+// precision of fractional values (saturation, luminosity and the intermediates behind them); hue and rgb channels
+// always round to whole numbers unless the mode is RoundNone. The zero value keeps the built in behaviour
+type RoundingPolicy struct {
+	Mode     RoundingMode
+	Decimals int
+}
+
+// Rounds x to places decimals under the calculator's rounding policy
+func (pc *PaletteCalculator) round(x float64, places int) float64 {
+	var policy RoundingPolicy
+	if pc != nil {
+		policy = pc.Rounding
+	}
+	if places > 0 && policy.Decimals > 0 {
+		places = policy.Decimals
+	}
+
+	switch policy.Mode {
+	case RoundNone:
+		return x
+	case RoundHalfEven:
+		scale := math.Pow(10, float64(places))
+		return math.RoundToEven(x*scale) / scale
+	default:
+		return roundFloat(x, places)
+	}
+}
@@ -0,0 +1,137 @@
+package palettecalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRound(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		policy   RoundingPolicy
+		value    float64
+		places   int
+		expected float64
+	}{
+		{
+			name:     "should round half away from zero by default",
+			value:    2.5,
+			places:   0,
+			expected: 3,
+		},
+		{
+			name:     "should round half to even",
+			policy:   RoundingPolicy{Mode: RoundHalfEven},
+			value:    2.5,
+			places:   0,
+			expected: 2,
+		},
+		{
+			name:     "should round half to even at decimals",
+			policy:   RoundingPolicy{Mode: RoundHalfEven},
+			value:    .125,
+			places:   2,
+			expected: .12,
+		},
+		{
+			name:     "should not round",
+			policy:   RoundingPolicy{Mode: RoundNone},
+			value:    .123456,
+			places:   2,
+			expected: .123456,
+		},
+		{
+			name:     "should override fractional precision",
+			policy:   RoundingPolicy{Decimals: 4},
+			value:    .123456,
+			places:   2,
+			expected: .1235,
+		},
+		{
+			name:     "should keep whole numbers whole",
+			policy:   RoundingPolicy{Decimals: 4},
+			value:    193.263,
+			places:   0,
+			expected: 193,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := &PaletteCalculator{Rounding: test.policy}
+
+			returned := paletteCalculator.round(test.value, test.places)
+
+			if returned != test.expected {
+				t.Errorf("expected: %v\n returned: %v\n", test.expected, returned)
+			}
+		})
+	}
+}
+
+func TestConvertRGBToHSLWithRoundingPolicy(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		policy   RoundingPolicy
+		expected *HSL
+	}{
+		{
+			name:     "should round with the default policy",
+			expected: &HSL{Hue: 193, Saturation: .66, Luminosity: .28},
+		},
+		{
+			name:     "should keep full precision",
+			policy:   RoundingPolicy{Mode: RoundNone},
+			expected: &HSL{Hue: 193.26315789473688, Saturation: 0.6643356643356644, Luminosity: 0.2803921568627451},
+		},
+		{
+			name:     "should round to fixed decimals",
+			policy:   RoundingPolicy{Decimals: 4},
+			expected: &HSL{Hue: 193, Saturation: .6643, Luminosity: .2804},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := &PaletteCalculator{Rounding: test.policy}
+
+			returned := paletteCalculator.ConvertRGBToHSL(&Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex})
+
+			if !reflect.DeepEqual(returned, test.expected) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expected, returned)
+			}
+		})
+	}
+}
+
+func TestRoundNoneKeepsChannels(t *testing.T) {
+	paletteCalculator := &PaletteCalculator{Rounding: RoundingPolicy{Mode: RoundNone}}
+	parsed, err := paletteCalculator.ParseCSS("rgb(10.5, 20.25, 30%)")
+	if err != nil {
+		t.Fatalf("expected no error, returned: %v", err)
+	}
+
+	for _, test := range []struct {
+		name          string
+		returnedColor *Color
+		expectedColor *Color
+	}{
+		{
+			name:          "should clamp without rounding",
+			returnedColor: paletteCalculator.Clamp(&Color{-3, 260.4, 99.6, ""}),
+			expectedColor: &Color{0, 255, 99.6, "00ff63"},
+		},
+		{
+			name:          "should convert to gray without rounding",
+			returnedColor: paletteCalculator.Grayscale(&Color{Red, Green, Blue, Hex}, AverageGrayscale),
+			expectedColor: &Color{241. / 3, 241. / 3, 241. / 3, "505050"},
+		},
+		{
+			name:          "should parse rgb() without rounding",
+			returnedColor: parsed,
+			expectedColor: &Color{10.5, 20.25, 76.5, "0a144c"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if !reflect.DeepEqual(test.expectedColor, test.returnedColor) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedColor, test.returnedColor)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package palettecalculator
+
+import "fmt"
+
+// Kind of color harmony a scheme is built from
+type SchemeType string
+
+const (
+	Complimentary            SchemeType = "complimentary"
+	SplitComplimentary       SchemeType = "split-complimentary"
+	Triadic                  SchemeType = "triadic"
+	Tetradic                 SchemeType = "tetradic"
+	DoubleSplitComplimentary SchemeType = "double-split-complimentary"
+	Analogous                SchemeType = "analogous"
+	Monochromatic            SchemeType = "monochromatic"
+	Unstructured             SchemeType = "unstructured"
+)
+
+// Hue offsets (in degrees) from the dominant color each scheme is built from
+var schemeOffsets = map[SchemeType][]float64{
+	Complimentary:      {0, 180},
+	SplitComplimentary: {0, 150, 210},
+	Triadic:            {0, 120, 240},
+	Tetradic:           {0, 60, 180, 240},
+}
+
+// Calculates the given scheme based on dominant color, dispatching to the matching Calculate*ColorScheme method
+func (pc *PaletteCalculator) CalculateScheme(dc *Color, scheme SchemeType, opts ...SchemeOption) (colors []Color, err error) {
+	pc, span := pc.startSpan("palettecalculator.CalculateScheme")
+	defer func() { span.End(err) }()
+
+	switch scheme {
+	case Complimentary:
+		colors = pc.CalculateComplimentaryColorScheme(dc, opts...)
+	case SplitComplimentary:
+		colors = pc.CalculateSplitComplimentaryColorScheme(dc, opts...)
+	case Triadic:
+		colors = pc.CalculateTriadicColorScheme(dc, opts...)
+	case Tetradic:
+		colors = pc.CalculateTetradicColorScheme(dc, opts...)
+	case DoubleSplitComplimentary:
+		colors = pc.CalculateDoubleSplitComplimentaryColorScheme(dc, opts...)
+	case Analogous:
+		colors = pc.CalculateAnalogousColorScheme(dc, opts...)
+	case Monochromatic:
+		colors = pc.CalculateMonochromaticColorScheme(dc, opts...)
+	default:
+		return nil, fmt.Errorf("unsupported scheme type: %s", scheme)
+	}
+
+	if cfg := newSchemeConfig(opts); cfg.colorBlindSafe != nil {
+		if err := pc.CheckColorBlindSafe(colors, cfg.colorBlindSafe.MinDeltaE); err != nil {
+			return nil, err
+		}
+	}
+
+	return colors, nil
+}
+
+// Scheme color along with its HSL and OKLCH representations
+type SchemeColor struct {
+	Color Color `json:"color"`
+	HSL   HSL   `json:"hsl"`
+	OKLCH OKLCH `json:"oklch"`
+}
+
+// Calculates the given scheme like CalculateScheme, returning each color in HSL and OKLCH as well as RGB
+func (pc *PaletteCalculator) CalculateSchemeColors(dc *Color, scheme SchemeType, opts ...SchemeOption) ([]SchemeColor, error) {
+	colors, err := pc.CalculateScheme(dc, scheme, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	schemeColors := make([]SchemeColor, 0, len(colors))
+	for i := range colors {
+		schemeColors = append(schemeColors, SchemeColor{
+			Color: colors[i],
+			HSL:   *pc.ConvertRGBToHSL(&colors[i]),
+			OKLCH: *pc.ConvertOKLABToOKLCH(pc.ConvertRGBToOKLAB(&colors[i])),
+		})
+	}
+
+	return schemeColors, nil
+}
+
+// Color space scheme hues are rotated in
+type HueRotation int
+
+const (
+	// Rotate hue in HSL, the default
+	HSLRotation HueRotation = iota
+	// Rotate hue in OKLCH so generated colors keep the dominant color's perceived lightness and chroma
+	OKLCHRotation
+)
+
+// Option changing how a scheme generates its colors, either a HueRotation or a Transform
+type SchemeOption interface {
+	applySchemeOption(cfg *schemeConfig)
+}
+
+type schemeConfig struct {
+	rotation       HueRotation
+	transforms     []Transform
+	colorBlindSafe *ColorBlindSafe
+}
+
+func newSchemeConfig(opts []SchemeOption) *schemeConfig {
+	cfg := new(schemeConfig)
+	for _, opt := range opts {
+		opt.applySchemeOption(cfg)
+	}
+
+	return cfg
+}
+
+func (r HueRotation) applySchemeOption(cfg *schemeConfig) {
+	cfg.rotation = r
+}
+
+func (t Transform) applySchemeOption(cfg *schemeConfig) {
+	cfg.transforms = append(cfg.transforms, t)
+}
+
+func (c ColorBlindSafe) applySchemeOption(cfg *schemeConfig) {
+	cfg.colorBlindSafe = &c
+}
+
+// Applies options that act on the scheme as a whole once all of its colors are generated
+func (pc *PaletteCalculator) constrainScheme(colors []Color, opts ...SchemeOption) []Color {
+	cfg := newSchemeConfig(opts)
+	if cfg.colorBlindSafe != nil {
+		return pc.makeColorBlindSafe(colors, cfg.colorBlindSafe.MinDeltaE)
+	}
+
+	return colors
+}
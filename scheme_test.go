@@ -0,0 +1,116 @@
+package palettecalculator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCalculateColorSchemeWithOKLCHRotation(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		opts        []SchemeOption
+		expectedRGB []Color
+	}{
+		{
+			name:        "should rotate hue in OKLCH",
+			opts:        []SchemeOption{OKLCHRotation},
+			expectedRGB: []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {118, 71, 101, "764765"}, {98, 90, 34, "625a22"}},
+		},
+		{
+			name:        "should apply transforms after OKLCH rotation",
+			opts:        []SchemeOption{OKLCHRotation, Transform{LuminosityShift: .1}},
+			expectedRGB: []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {131, 79, 112, "834f70"}, {110, 102, 38, "6e6626"}},
+		},
+		{
+			name:        "should rotate hue in HSL when requested explicitly",
+			opts:        []SchemeOption{HSLRotation},
+			expectedRGB: []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {119, 24, 96, "771860"}, {96, 119, 24, "607718"}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dominantColor := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedRGB := paletteCalculator.CalculateTriadicColorScheme(dominantColor, test.opts...)
+
+			if !reflect.DeepEqual(test.expectedRGB, returnedRGB) {
+				t.Errorf("expected: %v\n returned %v\n", test.expectedRGB, returnedRGB)
+			}
+		})
+	}
+}
+
+func TestCalculateComplimentaryColorSchemeWithOKLCHRotation(t *testing.T) {
+	dominantColor := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+	expectedRGB := []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {125, 73, 54, "7d4936"}}
+	paletteCalculator := new(PaletteCalculator)
+
+	returnedRGB := paletteCalculator.CalculateComplimentaryColorScheme(dominantColor, OKLCHRotation)
+
+	if !reflect.DeepEqual(expectedRGB, returnedRGB) {
+		t.Errorf("expected: %v\n returned %v\n", expectedRGB, returnedRGB)
+	}
+
+}
+
+func TestCalculateScheme(t *testing.T) {
+	dominantColor := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+	paletteCalculator := new(PaletteCalculator)
+
+	for _, test := range []struct {
+		name        string
+		scheme      SchemeType
+		expectedRGB []Color
+		expectedErr error
+	}{
+		{name: "should dispatch complimentary", scheme: Complimentary, expectedRGB: paletteCalculator.CalculateComplimentaryColorScheme(dominantColor)},
+		{name: "should dispatch split complimentary", scheme: SplitComplimentary, expectedRGB: paletteCalculator.CalculateSplitComplimentaryColorScheme(dominantColor)},
+		{name: "should dispatch triadic", scheme: Triadic, expectedRGB: paletteCalculator.CalculateTriadicColorScheme(dominantColor)},
+		{name: "should dispatch tetradic", scheme: Tetradic, expectedRGB: paletteCalculator.CalculateTetradicColorScheme(dominantColor)},
+		{name: "should dispatch double split complimentary", scheme: DoubleSplitComplimentary, expectedRGB: paletteCalculator.CalculateDoubleSplitComplimentaryColorScheme(dominantColor)},
+		{name: "should dispatch analogous", scheme: Analogous, expectedRGB: paletteCalculator.CalculateAnalogousColorScheme(dominantColor)},
+		{name: "should dispatch monochromatic", scheme: Monochromatic, expectedRGB: paletteCalculator.CalculateMonochromaticColorScheme(dominantColor)},
+		{name: "error occurs for unsupported scheme", scheme: SchemeType("bogus"), expectedRGB: nil, expectedErr: errors.New("unsupported scheme type: bogus")},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			returnedRGB, err := paletteCalculator.CalculateScheme(dominantColor, test.scheme)
+
+			if !reflect.DeepEqual(test.expectedRGB, returnedRGB) {
+				t.Errorf("expected: %v\n returned %v\n", test.expectedRGB, returnedRGB)
+			}
+
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestCalculateSchemeColors(t *testing.T) {
+	dominantColor := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+	paletteCalculator := new(PaletteCalculator)
+
+	returned, err := paletteCalculator.CalculateSchemeColors(dominantColor, Complimentary)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedRGB := paletteCalculator.CalculateComplimentaryColorScheme(dominantColor)
+	expectedHSL := []HSL{{Hue: hue, Saturation: saturation, Luminosity: luminosity}, {Hue: 13, Saturation: saturation, Luminosity: luminosity}}
+	expectedOKLCH := []OKLCH{{L: .462, C: .077, H: 221.557}, {L: .4, C: .109, H: 36.456}}
+	if len(returned) != len(expectedRGB) {
+		t.Fatalf("expected: %v\n returned: %v\n", expectedRGB, returned)
+	}
+	for i := range returned {
+		oklch := OKLCH{L: roundFloat(returned[i].OKLCH.L, 3), C: roundFloat(returned[i].OKLCH.C, 3), H: roundFloat(returned[i].OKLCH.H, 3)}
+		if !reflect.DeepEqual(returned[i].Color, expectedRGB[i]) || !reflect.DeepEqual(returned[i].HSL, expectedHSL[i]) || oklch != expectedOKLCH[i] {
+			t.Errorf("expected: %v %v %v\n returned: %v %v %v\n", expectedRGB[i], expectedHSL[i], expectedOKLCH[i], returned[i].Color, returned[i].HSL, oklch)
+		}
+	}
+
+	expectedErr := errors.New("unsupported scheme type: bogus")
+	if _, err := paletteCalculator.CalculateSchemeColors(dominantColor, SchemeType("bogus")); !reflect.DeepEqual(err, expectedErr) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedErr, err)
+	}
+}
@@ -0,0 +1,290 @@
+package palettecalculator
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Writes the palette as a YAML design token file with colors as "#rrggbb" strings, e.g.
+//
+//	name: photo
+//	colors:
+//	  - "#186277"
+//	weights:
+//	  - 1
+func (pc *PaletteCalculator) WriteYAML(w io.Writer, p *Palette) error {
+	var sb strings.Builder
+
+	if p.Name != "" {
+		sb.WriteString(fmt.Sprintf("name: %s\n", quoteConfigValue(p.Name)))
+	}
+	if len(p.Colors) == 0 {
+		sb.WriteString("colors: []\n")
+	} else {
+		sb.WriteString("colors:\n")
+	}
+	for i := range p.Colors {
+		sb.WriteString(fmt.Sprintf("  - %s\n", quoteConfigValue(cssHex(&p.Colors[i]))))
+	}
+	if len(p.Weights) > 0 {
+		sb.WriteString("weights:\n")
+	}
+	for _, weight := range p.Weights {
+		sb.WriteString(fmt.Sprintf("  - %s\n", strconv.FormatFloat(weight, 'g', -1, 64)))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// Writes the palette as a TOML design token file with colors as "#rrggbb" strings, e.g.
+//
+//	name = "photo"
+//	colors = ["#186277"]
+//	weights = [1]
+func (pc *PaletteCalculator) WriteTOML(w io.Writer, p *Palette) error {
+	var sb strings.Builder
+
+	if p.Name != "" {
+		sb.WriteString(fmt.Sprintf("name = %s\n", quoteConfigValue(p.Name)))
+	}
+	var colors []string
+	for i := range p.Colors {
+		colors = append(colors, quoteConfigValue(cssHex(&p.Colors[i])))
+	}
+	sb.WriteString(fmt.Sprintf("colors = [%s]\n", strings.Join(colors, ", ")))
+	if len(p.Weights) > 0 {
+		var weights []string
+		for _, weight := range p.Weights {
+			weights = append(weights, strconv.FormatFloat(weight, 'g', -1, 64))
+		}
+		sb.WriteString(fmt.Sprintf("weights = [%s]\n", strings.Join(weights, ", ")))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// Reads a palette written by WriteYAML. Colors may be any CSS color and lists may be block or flow style, other
+// YAML features such as anchors and nested maps are not supported
+func LoadYAMLPalette(r io.Reader) (*Palette, error) {
+	values := make(map[string][]string)
+	var name, listKey string
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := stripConfigComment(scanner.Text())
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			if listKey == "" {
+				return nil, fmt.Errorf("invalid yaml line %d: list item outside of colors or weights", line)
+			}
+			values[listKey] = append(values[listKey], unquoteConfigValue(strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))))
+			continue
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok || text != strings.TrimLeft(text, " \t") {
+			return nil, fmt.Errorf("invalid yaml line %d: expected key: value", line)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		listKey = ""
+
+		switch {
+		case key == "name":
+			name = unquoteConfigValue(value)
+		case key != "colors" && key != "weights":
+			return nil, fmt.Errorf("invalid yaml line %d: unknown key %q", line, key)
+		case value == "":
+			listKey = key
+		case strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]"):
+			values[key] = splitConfigList(value[1 : len(value)-1])
+		default:
+			return nil, fmt.Errorf("invalid yaml line %d: %s must be a list", line, key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("invalid yaml: %w", err)
+	}
+
+	return newConfigPalette(name, values["colors"], values["weights"])
+}
+
+// Reads a palette written by WriteTOML. Colors may be any CSS color and arrays may span lines, other TOML
+// features such as tables are not supported
+func LoadTOMLPalette(r io.Reader) (*Palette, error) {
+	values := make(map[string][]string)
+	var name, key, pending string
+	start := 0
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(stripConfigComment(scanner.Text()))
+		if text == "" {
+			continue
+		}
+
+		// continue an array left open on a previous line
+		if pending != "" {
+			pending += " " + text
+			if strings.HasSuffix(pending, "]") {
+				values[key] = splitConfigList(pending[1 : len(pending)-1])
+				pending = ""
+			}
+			continue
+		}
+
+		k, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid toml line %d: expected key = value", line)
+		}
+		key, value = strings.TrimSpace(k), strings.TrimSpace(value)
+
+		switch {
+		case key == "name":
+			name = unquoteConfigValue(value)
+		case key != "colors" && key != "weights":
+			return nil, fmt.Errorf("invalid toml line %d: unknown key %q", line, key)
+		case !strings.HasPrefix(value, "["):
+			return nil, fmt.Errorf("invalid toml line %d: %s must be an array", line, key)
+		case strings.HasSuffix(value, "]"):
+			values[key] = splitConfigList(value[1 : len(value)-1])
+		default:
+			pending, start = value, line
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("invalid toml: %w", err)
+	}
+	if pending != "" {
+		return nil, fmt.Errorf("invalid toml line %d: unterminated array", start)
+	}
+
+	return newConfigPalette(name, values["colors"], values["weights"])
+}
+
+func newConfigPalette(name string, colors []string, weights []string) (*Palette, error) {
+	p := &Palette{Name: name}
+
+	for _, s := range colors {
+		c, err := ParseCSS(unquoteConfigValue(s))
+		if err != nil {
+			return nil, err
+		}
+		p.Colors = append(p.Colors, *c)
+	}
+	for _, s := range weights {
+		weight, err := strconv.ParseFloat(unquoteConfigValue(s), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight %q", s)
+		}
+		p.Weights = append(p.Weights, weight)
+	}
+
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Cuts a # comment from the line, ignoring # inside quotes and # not preceded by whitespace
+func stripConfigComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+
+	return line
+}
+
+// Splits the inside of a [a, b] list on commas outside quotes and parentheses, e.g. "rgb(1, 2, 3)" stays whole
+func splitConfigList(list string) []string {
+	var items []string
+	var quote rune
+	depth, start := 0, 0
+
+	for i, r := range list {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == ',' && depth == 0:
+			items = append(items, list[start:i])
+			start = i + 1
+		}
+	}
+	items = append(items, list[start:])
+
+	var trimmed []string
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			trimmed = append(trimmed, item)
+		}
+	}
+
+	return trimmed
+}
+
+// Double quotes a value as a YAML or TOML string. Quotes and backslashes are escaped and control characters written
+// as \uXXXX, the escape both formats share. Other characters are kept as is, invalid UTF-8 becomes U+FFFD
+func quoteConfigValue(value string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range value {
+		switch {
+		case r == '"' || r == '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case unicode.IsControl(r):
+			fmt.Fprintf(&sb, `\u%04X`, r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+
+	return sb.String()
+}
+
+// Removes double or single quotes around a value, double quoted values may use escapes
+func unquoteConfigValue(value string) string {
+	if len(value) < 2 {
+		return value
+	}
+	switch {
+	case value[0] == '"' && value[len(value)-1] == '"':
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			return unquoted
+		}
+		return value[1 : len(value)-1]
+	case value[0] == '\'' && value[len(value)-1] == '\'':
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+
+	return value
+}
@@ -0,0 +1,122 @@
+package palettecalculator
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var serializedPalette = &Palette{Name: "photo", Colors: []Color{{24, 98, 119, "186277"}, {14, 60, 73, "0e3c49"}}, Weights: []float64{.75, .25}}
+
+func TestWriteYAML(t *testing.T) {
+	tests := []struct {
+		palette  *Palette
+		expected string
+	}{
+		{serializedPalette, "name: \"photo\"\ncolors:\n  - \"#186277\"\n  - \"#0e3c49\"\nweights:\n  - 0.75\n  - 0.25\n"},
+		{&Palette{}, "colors: []\n"},
+		{&Palette{Name: "a\x01\"b\\\né"}, "name: " + `"a\u0001\"b\\\u000Aé"` + "\ncolors: []\n"},
+	}
+	paletteCalculator := new(PaletteCalculator)
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+
+		err := paletteCalculator.WriteYAML(&buf, test.palette)
+
+		if err != nil {
+			t.Errorf("expected error: %v returned error: %v", nil, err)
+		}
+		if test.expected != buf.String() {
+			t.Errorf("expected: %v\n returned: %v\n", test.expected, buf.String())
+		}
+	}
+}
+
+func TestWriteTOML(t *testing.T) {
+	tests := []struct {
+		palette  *Palette
+		expected string
+	}{
+		{serializedPalette, "name = \"photo\"\ncolors = [\"#186277\", \"#0e3c49\"]\nweights = [0.75, 0.25]\n"},
+		{&Palette{}, "colors = []\n"},
+		{&Palette{Name: "a\x01\"b\\\né"}, "name = " + `"a\u0001\"b\\\u000Aé"` + "\ncolors = []\n"},
+	}
+	paletteCalculator := new(PaletteCalculator)
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+
+		err := paletteCalculator.WriteTOML(&buf, test.palette)
+
+		if err != nil {
+			t.Errorf("expected error: %v returned error: %v", nil, err)
+		}
+		if test.expected != buf.String() {
+			t.Errorf("expected: %v\n returned: %v\n", test.expected, buf.String())
+		}
+	}
+}
+
+func TestLoadYAMLPalette(t *testing.T) {
+	tests := []struct {
+		name            string
+		yaml            string
+		expectedPalette *Palette
+		expectedErr     error
+	}{
+		{"should read written palette", "name: \"photo\"\ncolors:\n  - \"#186277\"\n  - \"#0e3c49\"\nweights:\n  - 0.75\n  - 0.25\n", serializedPalette, nil},
+		{
+			"should read flow lists and comments",
+			"---\n# brand colors\nname: photo # extracted\ncolors: ['#186277', \"rgb(14, 60, 73)\"]\nweights: [0.75, 0.25]\n",
+			serializedPalette,
+			nil,
+		},
+		{"should fail on unknown key", "name: photo\nshades:\n  - 50\n", nil, errors.New(`invalid yaml line 2: unknown key "shades"`)},
+		{"should fail on orphan item", "- \"#186277\"\n", nil, errors.New("invalid yaml line 1: list item outside of colors or weights")},
+		{"should fail on invalid color", "colors:\n  - notacolor\n", nil, &ColorError{Syntax: "css", Input: "notacolor", Err: errors.New("unknown color name or syntax")}},
+	}
+
+	for _, test := range tests {
+		returned, err := LoadYAMLPalette(strings.NewReader(test.yaml))
+
+		if !reflect.DeepEqual(test.expectedErr, err) {
+			t.Errorf("%s expected error: %v returned error: %v", test.name, test.expectedErr, err)
+		}
+		if !reflect.DeepEqual(test.expectedPalette, returned) {
+			t.Errorf("%s expected: %v\n returned: %v\n", test.name, test.expectedPalette, returned)
+		}
+	}
+}
+
+func TestLoadTOMLPalette(t *testing.T) {
+	tests := []struct {
+		name            string
+		toml            string
+		expectedPalette *Palette
+		expectedErr     error
+	}{
+		{"should read written palette", "name = \"photo\"\ncolors = [\"#186277\", \"#0e3c49\"]\nweights = [0.75, 0.25]\n", serializedPalette, nil},
+		{
+			"should read multiline arrays and comments",
+			"# brand colors\nname = 'photo'\ncolors = [\n  \"#186277\", # primary\n  \"rgb(14, 60, 73)\",\n]\nweights = [0.75, 0.25]\n",
+			serializedPalette,
+			nil,
+		},
+		{"should fail on unterminated array", "colors = [\n  \"#186277\",\n", nil, errors.New("invalid toml line 1: unterminated array")},
+		{"should fail on mismatched weights", "colors = [\"#186277\"]\nweights = [0.5, 0.5]\n", nil, errors.New("palette has 2 weights for 1 colors")},
+	}
+
+	for _, test := range tests {
+		returned, err := LoadTOMLPalette(strings.NewReader(test.toml))
+
+		if !reflect.DeepEqual(test.expectedErr, err) {
+			t.Errorf("%s expected error: %v returned error: %v", test.name, test.expectedErr, err)
+		}
+		if !reflect.DeepEqual(test.expectedPalette, returned) {
+			t.Errorf("%s expected: %v\n returned: %v\n", test.name, test.expectedPalette, returned)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Clients tracked before buckets that have refilled are dropped
+const maxRateClients = 10000
+
+// Token buckets of the clients of a Server, by remote IP
+type rateLimiter struct {
+	mu      sync.Mutex
+	clients map[string]*bucket
+	now     func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Takes a token from the bucket of client, refilled at rate tokens a second up to burst. When the bucket is empty it
+// returns false and how long until the next token
+func (l *rateLimiter) allow(client string, rate float64, burst int) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if l.now != nil {
+		now = l.now()
+	}
+	if l.clients == nil {
+		l.clients = map[string]*bucket{}
+	}
+	size := float64(maxInt(burst, 1))
+	if len(l.clients) >= maxRateClients {
+		l.drop(now, rate, size)
+	}
+
+	b, ok := l.clients[client]
+	if !ok {
+		b = &bucket{tokens: size, last: now}
+		l.clients[client] = b
+	}
+	b.tokens += now.Sub(b.last).Seconds() * rate
+	if b.tokens > size {
+		b.tokens = size
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	}
+	b.tokens--
+
+	return true, 0
+}
+
+// Drops the buckets that have refilled, a new bucket for their client starts full anyway
+func (l *rateLimiter) drop(now time.Time, rate float64, size float64) {
+	for client, b := range l.clients {
+		if b.tokens+now.Sub(b.last).Seconds()*rate >= size {
+			delete(l.clients, client)
+		}
+	}
+}
+
+// Remote IP of the request, the limit is per client rather than per connection
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
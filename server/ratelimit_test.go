@@ -0,0 +1,66 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := &rateLimiter{now: func() time.Time { return now }}
+
+	tests := []struct {
+		name      string
+		client    string
+		advance   time.Duration
+		wantOK    bool
+		wantRetry time.Duration
+	}{
+		{name: "first of burst", client: "a", wantOK: true},
+		{name: "second of burst", client: "a", wantOK: true},
+		{name: "over burst", client: "a", wantOK: false, wantRetry: 500 * time.Millisecond},
+		{name: "other client", client: "b", wantOK: true},
+		{name: "refilled", client: "a", advance: 500 * time.Millisecond, wantOK: true},
+		{name: "empty again", client: "a", advance: 250 * time.Millisecond, wantOK: false, wantRetry: 250 * time.Millisecond},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			now = now.Add(test.advance)
+			ok, retry := l.allow(test.client, 2, 2)
+			if ok != test.wantOK || retry != test.wantRetry {
+				t.Errorf("expected: %v %v\n returned: %v %v\n", test.wantOK, test.wantRetry, ok, retry)
+			}
+		})
+	}
+}
+
+func TestServerRateLimit(t *testing.T) {
+	observer := new(recordingObserver)
+	s := New(nil)
+	s.Metrics = observer
+	s.RateLimit = 1
+
+	var statuses []int
+	for _, addr := range []string{"192.0.2.1:1234", "192.0.2.1:5678", "192.0.2.2:1234"} {
+		req := httptest.NewRequest(http.MethodGet, "/scheme?color=e3c49a", nil)
+		req.RemoteAddr = addr
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		statuses = append(statuses, rec.Code)
+		if rec.Code == http.StatusTooManyRequests && rec.Header().Get("Retry-After") != "1" {
+			t.Errorf("expected: %v\n returned: %v\n", "1", rec.Header().Get("Retry-After"))
+		}
+	}
+
+	// the second request comes from the same client on another connection
+	if expected := []int{http.StatusOK, http.StatusTooManyRequests, http.StatusOK}; !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("expected: %v\n returned: %v\n", expected, statuses)
+	}
+	if expected := []string{"rate_limited"}; !reflect.DeepEqual(observer.errors, expected) {
+		t.Errorf("expected: %v\n returned: %v\n", expected, observer.errors)
+	}
+}
@@ -0,0 +1,482 @@
+// Package server serves palette extraction, color schemes and swatch previews as a JSON REST API.
+// Set Cache to reuse palettes of repeated images, Metrics to monitor extractions and RateLimit to limit each client.
+//
+// Endpoints:
+//
+//	POST /palette      extract the palette of a multipart "image" upload, a raw image body or a "url"
+//	GET  /scheme       calculate the scheme "type" of "color"
+//	GET  /swatch.png   render "colors" as a PNG swatch strip or grid
+//
+// Errors are returned with a 4xx or 5xx status and a body of {"error": "..."}.
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	// register the formats images can be uploaded in
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	"github.com/evancaplan/palettecalculator/cache"
+	"github.com/evancaplan/palettecalculator/metrics"
+)
+
+// Colors extracted by the local backend when a request does not set k
+const DefaultColors = 5
+
+// Largest image accepted when a Server does not set MaxImageSize, 10 MiB
+const DefaultMaxImageSize = 10 << 20
+
+// Time allowed to fetch an image posted by URL when a Server does not set Client
+const DefaultFetchTimeout = 30 * time.Second
+
+// Largest width or height of a rendered swatch
+const MaxSwatchSize = 4096
+
+// HTTP handler of the palette REST API
+type Server struct {
+	// Extracts palettes with the Vision API, nil extracts them locally with ExtractPalette
+	Calculator *palettecalculator.PaletteCalculator
+	// Fetches images posted by URL for local extraction, nil uses a client with DefaultFetchTimeout
+	Client *http.Client
+	// Largest image accepted in bytes, zero uses DefaultMaxImageSize
+	MaxImageSize int64
+	// Palettes of uploads by their contents and of URLs by URL, nil extracts every request
+	Cache cache.Cache
+	// Receives extractions, cache lookups and errors, nil reports nothing
+	Metrics metrics.Observer
+	// Requests a second each client IP is allowed, zero does not limit requests. Clients over the limit are answered
+	// with 429 Too Many Requests
+	RateLimit float64
+	// Requests a client may make at once before RateLimit applies, zero allows one
+	RateBurst int
+
+	mux     *http.ServeMux
+	limiter rateLimiter
+}
+
+// Error response body
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Error surfaced to the client with its HTTP status
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+// Creates a server extracting palettes with calculator, a nil calculator extracts them locally
+func New(calculator *palettecalculator.PaletteCalculator) *Server {
+	s := &Server{Calculator: calculator, mux: http.NewServeMux()}
+	s.mux.HandleFunc("/palette", s.method(http.MethodPost, s.palette))
+	s.mux.HandleFunc("/scheme", s.method(http.MethodGet, s.scheme))
+	s.mux.HandleFunc("/swatch.png", s.method(http.MethodGet, s.swatch))
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Restricts handler to method and writes the error it returns
+func (s *Server) method(method string, handler func(w http.ResponseWriter, r *http.Request) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		if ok, retry := s.allow(r); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int((retry+time.Second-1)/time.Second)))
+			err = &statusError{http.StatusTooManyRequests, errors.New("rate limit exceeded, retry later")}
+		} else if r.Method != method && !(method == http.MethodGet && r.Method == http.MethodHead) {
+			w.Header().Set("Allow", method)
+			err = &statusError{http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed, expected %s", r.Method, method)}
+		} else {
+			err = handler(w, r)
+		}
+		if err == nil {
+			return
+		}
+
+		if s.Metrics != nil {
+			s.Metrics.ObserveError(errorType(err))
+		}
+		writeError(w, err)
+	}
+}
+
+// Whether the client of r is within RateLimit, and otherwise how long until it is
+func (s *Server) allow(r *http.Request) (bool, time.Duration) {
+	if s.RateLimit <= 0 {
+		return true, 0
+	}
+	return s.limiter.allow(clientIP(r), s.RateLimit, s.RateBurst)
+}
+
+// Extracts the palette of the posted image
+func (s *Server) palette(w http.ResponseWriter, r *http.Request) error {
+	r.Body = http.MaxBytesReader(w, r.Body, s.maxImageSize())
+
+	var (
+		upload io.ReadCloser
+		url    string
+		k      = r.URL.Query().Get("k")
+	)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch {
+	case mediaType == "multipart/form-data":
+		if err := r.ParseMultipartForm(s.maxImageSize()); err != nil {
+			return requestError(err)
+		}
+		if k == "" {
+			k = r.FormValue("k")
+		}
+		url = r.FormValue("url")
+		if file, _, err := r.FormFile("image"); err == nil {
+			upload = file
+		}
+	case mediaType == "application/json":
+		var body struct {
+			URL string `json:"url"`
+			K   int    `json:"k"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return requestError(err)
+		}
+		url = body.URL
+		if k == "" && body.K != 0 {
+			k = strconv.Itoa(body.K)
+		}
+	case strings.HasPrefix(mediaType, "image/"):
+		// read the body up front so an oversized image is reported as such rather than as a decoding error
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			return requestError(err)
+		}
+		upload = io.NopCloser(bytes.NewReader(data))
+	default:
+		if k == "" {
+			k = r.FormValue("k")
+		}
+		url = r.FormValue("url")
+	}
+	if upload != nil {
+		defer upload.Close()
+	}
+	if upload == nil && url == "" {
+		return &statusError{http.StatusBadRequest, errors.New("expected an image upload or url")}
+	}
+
+	colors := DefaultColors
+	if k != "" {
+		n, err := strconv.Atoi(k)
+		if err != nil || n < 1 || n > 256 {
+			return &statusError{http.StatusBadRequest, fmt.Errorf("invalid k %q: expected 1 to 256 colors", k)}
+		}
+		colors = n
+	}
+
+	p, err := s.cachedExtract(r.Context(), upload, url, colors)
+	if err != nil {
+		return err
+	}
+
+	return writeJSON(w, p)
+}
+
+// Extracts the palette of the upload r or url, from the cache when it has it
+func (s *Server) cachedExtract(ctx context.Context, r io.Reader, url string, k int) (*palettecalculator.Palette, error) {
+	if s.Cache == nil {
+		return s.observedExtract(ctx, r, url, k)
+	}
+
+	key := fmt.Sprintf("%s:%d:%s", s.backend(), k, url)
+	if r != nil {
+		// uploads are keyed by their contents, which are already limited to the maximum image size
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, requestError(err)
+		}
+		key = fmt.Sprintf("%s:%d:sha256:%x", s.backend(), k, sha256.Sum256(data))
+		r = bytes.NewReader(data)
+	}
+
+	p, ok := s.Cache.Get(key)
+	if s.Metrics != nil {
+		s.Metrics.ObserveCacheLookup(ok)
+	}
+	if ok {
+		return p, nil
+	}
+	p, err := s.observedExtract(ctx, r, url, k)
+	if err != nil {
+		return nil, err
+	}
+	// a palette that can't be cached is still returned
+	s.Cache.Set(key, p)
+
+	return p, nil
+}
+
+func (s *Server) observedExtract(ctx context.Context, r io.Reader, url string, k int) (*palettecalculator.Palette, error) {
+	if s.Metrics == nil {
+		return s.extract(ctx, r, url, k)
+	}
+
+	start := time.Now()
+	p, err := s.extract(ctx, r, url, k)
+	s.Metrics.ObserveExtraction(s.backend(), time.Since(start))
+
+	return p, err
+}
+
+// Extracts with the Vision API under ctx, the request's context, or locally
+func (s *Server) extract(ctx context.Context, r io.Reader, url string, k int) (*palettecalculator.Palette, error) {
+	if s.Calculator != nil {
+		var (
+			p   *palettecalculator.Palette
+			err error
+		)
+		calculator := s.Calculator.WithContext(ctx)
+		if r != nil {
+			p, err = calculator.CalculatePaletteFromReader(r)
+		} else {
+			p, err = calculator.CalculatePaletteFromURI(url)
+		}
+		if errors.Is(err, palettecalculator.ErrVisionQuota) {
+			return nil, &statusError{http.StatusServiceUnavailable, err}
+		}
+		if err != nil {
+			return nil, &statusError{http.StatusBadGateway, err}
+		}
+		return p, nil
+	}
+
+	if r == nil {
+		data, err := s.fetch(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(data)
+	}
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, requestError(fmt.Errorf("decoding image: %w", err))
+	}
+	pc := new(palettecalculator.PaletteCalculator)
+	p, err := pc.ExtractPalette(img, k)
+	if err != nil {
+		return nil, &statusError{http.StatusUnprocessableEntity, err}
+	}
+
+	return p, nil
+}
+
+// Fetches the image at url with ctx, the request's context, limited to the maximum image size
+func (s *Server) fetch(ctx context.Context, url string) ([]byte, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return nil, &statusError{http.StatusBadRequest, fmt.Errorf("invalid url %q: expected http or https", url)}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, &statusError{http.StatusBadRequest, fmt.Errorf("invalid url %q: %w", url, err)}
+	}
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultFetchTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, &statusError{http.StatusBadGateway, err}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &statusError{http.StatusBadGateway, fmt.Errorf("fetching %s: %s", url, resp.Status)}
+	}
+	tooLarge := &statusError{http.StatusRequestEntityTooLarge, fmt.Errorf("image at %s is larger than %d bytes", url, s.maxImageSize())}
+	if resp.ContentLength > s.maxImageSize() {
+		return nil, tooLarge
+	}
+
+	// read a byte past the limit so images sent without a Content-Length are reported as too large rather than
+	// truncated
+	data, err := io.ReadAll(io.LimitReader(resp.Body, s.maxImageSize()+1))
+	if err != nil {
+		return nil, &statusError{http.StatusBadGateway, err}
+	}
+	if int64(len(data)) > s.maxImageSize() {
+		return nil, tooLarge
+	}
+
+	return data, nil
+}
+
+// Calculates the scheme of type, complimentary by default, from color
+func (s *Server) scheme(w http.ResponseWriter, r *http.Request) error {
+	query := r.URL.Query()
+	c, err := parseColor(query.Get("color"))
+	if err != nil {
+		return err
+	}
+	schemeType := palettecalculator.Complimentary
+	if t := query.Get("type"); t != "" {
+		schemeType = palettecalculator.SchemeType(t)
+	}
+
+	pc := new(palettecalculator.PaletteCalculator)
+	colors, err := pc.CalculateScheme(c, schemeType)
+	if err != nil {
+		return requestError(err)
+	}
+
+	return writeJSON(w, palettecalculator.ReportScheme{Scheme: schemeType, Colors: colors})
+}
+
+// Renders the comma separated colors as a PNG, 600x120 unless width and height are set
+func (s *Server) swatch(w http.ResponseWriter, r *http.Request) error {
+	query := r.URL.Query()
+	if query.Get("colors") == "" {
+		return &statusError{http.StatusBadRequest, errors.New("expected comma separated colors")}
+	}
+
+	p := new(palettecalculator.Palette)
+	for _, value := range strings.Split(query.Get("colors"), ",") {
+		c, err := parseColor(value)
+		if err != nil {
+			return err
+		}
+		p.Colors = append(p.Colors, *c)
+	}
+
+	width, err := intParam(query.Get("width"), "width", 600, 1, MaxSwatchSize)
+	if err != nil {
+		return err
+	}
+	height, err := intParam(query.Get("height"), "height", 120, 1, MaxSwatchSize)
+	if err != nil {
+		return err
+	}
+	columns, err := intParam(query.Get("columns"), "columns", 0, 0, len(p.Colors))
+	if err != nil {
+		return err
+	}
+	labels := query.Get("labels") == "true" || query.Get("labels") == "1"
+
+	w.Header().Set("Content-Type", "image/png")
+	return p.RenderPNG(w, width, height, palettecalculator.SwatchLayout{Columns: columns, Labels: labels})
+}
+
+// Backend label of extractions in cache keys and metrics
+func (s *Server) backend() string {
+	if s.Calculator != nil {
+		return "vision"
+	}
+	return "local"
+}
+
+func (s *Server) maxImageSize() int64 {
+	if s.MaxImageSize > 0 {
+		return s.MaxImageSize
+	}
+	return DefaultMaxImageSize
+}
+
+// Parses a CSS color, accepting hex without the leading # since it has to be escaped in a query
+func parseColor(value string) (*palettecalculator.Color, error) {
+	if value == "" {
+		return nil, &statusError{http.StatusBadRequest, errors.New("expected a color")}
+	}
+	if c, err := palettecalculator.ParseHex(value); err == nil {
+		return c, nil
+	}
+	c, err := palettecalculator.ParseCSS(value)
+	if err != nil {
+		return nil, requestError(err)
+	}
+
+	return c, nil
+}
+
+func intParam(value string, name string, fallback int, min int, max int) (int, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min || n > max {
+		return 0, &statusError{http.StatusBadRequest, fmt.Errorf("invalid %s %q: expected %d to %d", name, value, min, max)}
+	}
+
+	return n, nil
+}
+
+// Wraps err as a bad request, or a too large request when the body went over its limit
+func requestError(err error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return &statusError{http.StatusRequestEntityTooLarge, fmt.Errorf("image is larger than %d bytes", tooLarge.Limit)}
+	}
+	return &statusError{http.StatusBadRequest, err}
+}
+
+// Type of a request error in metrics, by its status
+func errorType(err error) string {
+	var se *statusError
+	if !errors.As(err, &se) {
+		return "internal"
+	}
+
+	switch se.status {
+	case http.StatusBadRequest:
+		return "bad_request"
+	case http.StatusMethodNotAllowed:
+		return "method_not_allowed"
+	case http.StatusRequestEntityTooLarge:
+		return "too_large"
+	case http.StatusTooManyRequests:
+		return "rate_limited"
+	case http.StatusUnprocessableEntity:
+		return "unprocessable"
+	case http.StatusBadGateway:
+		// the vision API or the server of a posted url
+		return "upstream"
+	case http.StatusServiceUnavailable:
+		return "vision_quota"
+	}
+
+	return "internal"
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) error {
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var se *statusError
+	if errors.As(err, &se) {
+		status = se.status
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{Error: err.Error()})
+}
@@ -0,0 +1,411 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	"github.com/evancaplan/palettecalculator/cache"
+)
+
+func testImage(t *testing.T) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 4; y++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+			if x == 0 {
+				img.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func multipartBody(t *testing.T, image []byte, fields map[string]string) (*bytes.Buffer, string) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for name, value := range fields {
+		mw.WriteField(name, value)
+	}
+	if image != nil {
+		fw, err := mw.CreateFormFile("image", "image.png")
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write(image)
+	}
+	mw.Close()
+
+	return &body, mw.FormDataContentType()
+}
+
+func TestServerPalette(t *testing.T) {
+	img := testImage(t)
+	imageServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/image.png" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(img)
+	}))
+	defer imageServer.Close()
+
+	twoColors := []palettecalculator.Color{{Red: 255, Hex: "ff0000"}, {Blue: 255, Hex: "0000ff"}}
+	upload, uploadType := multipartBody(t, img, map[string]string{"k": "2"})
+	empty, emptyType := multipartBody(t, nil, nil)
+
+	tests := []struct {
+		name         string
+		method       string
+		target       string
+		contentType  string
+		body         string
+		maxImageSize int64
+		wantStatus   int
+		wantColors   []palettecalculator.Color
+		wantWeights  []float64
+		wantError    string
+	}{
+		{
+			name:        "multipart upload",
+			method:      http.MethodPost,
+			target:      "/palette",
+			contentType: uploadType,
+			body:        upload.String(),
+			wantStatus:  http.StatusOK,
+			wantColors:  twoColors,
+			wantWeights: []float64{.75, .25},
+		},
+		{
+			name:        "raw image body",
+			method:      http.MethodPost,
+			target:      "/palette?k=1",
+			contentType: "image/png",
+			body:        string(img),
+			wantStatus:  http.StatusOK,
+			wantColors:  []palettecalculator.Color{{Red: 191, Blue: 64, Hex: "bf0040"}},
+			wantWeights: []float64{1},
+		},
+		{
+			name:        "json url",
+			method:      http.MethodPost,
+			target:      "/palette",
+			contentType: "application/json",
+			body:        `{"url": "` + imageServer.URL + `/image.png", "k": 2}`,
+			wantStatus:  http.StatusOK,
+			wantColors:  twoColors,
+			wantWeights: []float64{.75, .25},
+		},
+		{
+			name:        "form url",
+			method:      http.MethodPost,
+			target:      "/palette",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "k=2&url=" + imageServer.URL + "/image.png",
+			wantStatus:  http.StatusOK,
+			wantColors:  twoColors,
+			wantWeights: []float64{.75, .25},
+		},
+		{
+			name:        "missing image",
+			method:      http.MethodPost,
+			target:      "/palette",
+			contentType: emptyType,
+			body:        empty.String(),
+			wantStatus:  http.StatusBadRequest,
+			wantError:   "expected an image upload or url",
+		},
+		{
+			name:        "invalid k",
+			method:      http.MethodPost,
+			target:      "/palette?k=0",
+			contentType: "image/png",
+			body:        string(img),
+			wantStatus:  http.StatusBadRequest,
+			wantError:   `invalid k "0": expected 1 to 256 colors`,
+		},
+		{
+			name:        "not an image",
+			method:      http.MethodPost,
+			target:      "/palette",
+			contentType: "image/png",
+			body:        "not a png",
+			wantStatus:  http.StatusBadRequest,
+			wantError:   "decoding image: image: unknown format",
+		},
+		{
+			name:         "image too large",
+			method:       http.MethodPost,
+			target:       "/palette",
+			contentType:  "image/png",
+			body:         string(img),
+			maxImageSize: 10,
+			wantStatus:   http.StatusRequestEntityTooLarge,
+			wantError:    "image is larger than 10 bytes",
+		},
+		{
+			name:        "url not found",
+			method:      http.MethodPost,
+			target:      "/palette",
+			contentType: "application/json",
+			body:        `{"url": "` + imageServer.URL + `/missing.png"}`,
+			wantStatus:  http.StatusBadGateway,
+			wantError:   "404 Not Found",
+		},
+		{
+			name:        "url scheme",
+			method:      http.MethodPost,
+			target:      "/palette",
+			contentType: "application/json",
+			body:        `{"url": "file:///etc/passwd"}`,
+			wantStatus:  http.StatusBadRequest,
+			wantError:   `invalid url "file:///etc/passwd": expected http or https`,
+		},
+		{
+			name:       "wrong method",
+			method:     http.MethodGet,
+			target:     "/palette",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantError:  "method GET not allowed, expected POST",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(nil)
+			s.MaxImageSize = tt.maxImageSize
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected: %v\n returned: %v\n", tt.wantStatus, rec.Code)
+			}
+			if tt.wantError != "" {
+				var body errorResponse
+				json.Unmarshal(rec.Body.Bytes(), &body)
+				if !strings.Contains(body.Error, tt.wantError) {
+					t.Errorf("expected: %v\n returned: %v\n", tt.wantError, body.Error)
+				}
+				return
+			}
+
+			var p palettecalculator.Palette
+			if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(p.Colors, tt.wantColors) {
+				t.Errorf("expected: %v\n returned: %v\n", tt.wantColors, p.Colors)
+			}
+			if !reflect.DeepEqual(p.Weights, tt.wantWeights) {
+				t.Errorf("expected: %v\n returned: %v\n", tt.wantWeights, p.Weights)
+			}
+		})
+	}
+}
+
+func TestServerFetchTooLarge(t *testing.T) {
+	imageServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// flush before writing the body so it is sent chunked, without a Content-Length
+		w.(http.Flusher).Flush()
+		w.Write(bytes.Repeat([]byte{0}, 128))
+	}))
+	defer imageServer.Close()
+
+	s := New(nil)
+	s.MaxImageSize = 64
+	req := httptest.NewRequest(http.MethodPost, "/palette", strings.NewReader(`{"url": "`+imageServer.URL+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected: %v\n returned: %v\n", http.StatusRequestEntityTooLarge, rec.Code)
+	}
+	var body errorResponse
+	json.Unmarshal(rec.Body.Bytes(), &body)
+	if expected := "image at " + imageServer.URL + " is larger than 64 bytes"; body.Error != expected {
+		t.Errorf("expected: %v\n returned: %v\n", expected, body.Error)
+	}
+}
+
+// Observer recording what the server reports
+type recordingObserver struct {
+	extractions []string
+	lookups     []bool
+	errors      []string
+}
+
+func (o *recordingObserver) ObserveExtraction(backend string, duration time.Duration) {
+	o.extractions = append(o.extractions, backend)
+}
+
+func (o *recordingObserver) ObserveCacheLookup(hit bool) {
+	o.lookups = append(o.lookups, hit)
+}
+
+func (o *recordingObserver) ObserveError(errorType string) {
+	o.errors = append(o.errors, errorType)
+}
+
+func TestServerCacheAndMetrics(t *testing.T) {
+	img := testImage(t)
+	observer := new(recordingObserver)
+	s := New(nil)
+	s.Cache = cache.NewMemory(10, 0)
+	s.Metrics = observer
+
+	for _, k := range []string{"2", "2", "1", "0"} {
+		req := httptest.NewRequest(http.MethodPost, "/palette?k="+k, bytes.NewReader(img))
+		req.Header.Set("Content-Type", "image/png")
+		s.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	s.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/palette", nil))
+
+	// the second request with k 2 is served from the cache
+	if expected := []string{"local", "local"}; !reflect.DeepEqual(observer.extractions, expected) {
+		t.Errorf("expected: %v\n returned: %v\n", expected, observer.extractions)
+	}
+	if expected := []bool{false, true, false}; !reflect.DeepEqual(observer.lookups, expected) {
+		t.Errorf("expected: %v\n returned: %v\n", expected, observer.lookups)
+	}
+	if expected := []string{"bad_request", "method_not_allowed"}; !reflect.DeepEqual(observer.errors, expected) {
+		t.Errorf("expected: %v\n returned: %v\n", expected, observer.errors)
+	}
+}
+
+func TestServerScheme(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "complimentary by default",
+			target:     "/scheme?color=ff0000",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"scheme":"complimentary","colors":[{"red":255,"green":0,"blue":0,"hex":"ff0000"},{"red":0,"green":255,"blue":255,"hex":"00ffff"}]}` + "\n",
+		},
+		{
+			name:       "css color and type",
+			target:     "/scheme?color=red&type=triadic",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"scheme":"triadic","colors":[{"red":255,"green":0,"blue":0,"hex":"ff0000"},{"red":0,"green":255,"blue":0,"hex":"00ff00"},{"red":0,"green":0,"blue":255,"hex":"0000ff"}]}` + "\n",
+		},
+		{
+			name:       "missing color",
+			target:     "/scheme",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"expected a color"}` + "\n",
+		},
+		{
+			name:       "unknown type",
+			target:     "/scheme?color=red&type=plaid",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"unsupported scheme type: plaid"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			New(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected: %v\n returned: %v\n", tt.wantStatus, rec.Code)
+			}
+			if rec.Body.String() != tt.wantBody {
+				t.Errorf("expected: %v\n returned: %v\n", tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestServerSwatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		wantSize   image.Point
+		wantPixels map[image.Point]color.RGBA
+		wantError  string
+	}{
+		{
+			name:       "default size",
+			target:     "/swatch.png?colors=ff0000,%230000ff",
+			wantStatus: http.StatusOK,
+			wantSize:   image.Pt(600, 120),
+			wantPixels: map[image.Point]color.RGBA{{0, 0}: {R: 255, A: 255}, {599, 119}: {B: 255, A: 255}},
+		},
+		{
+			name:       "grid",
+			target:     "/swatch.png?colors=red,lime,blue,white&width=20&height=20&columns=2",
+			wantStatus: http.StatusOK,
+			wantSize:   image.Pt(20, 20),
+			wantPixels: map[image.Point]color.RGBA{{15, 5}: {G: 255, A: 255}, {5, 15}: {B: 255, A: 255}},
+		},
+		{
+			name:       "missing colors",
+			target:     "/swatch.png",
+			wantStatus: http.StatusBadRequest,
+			wantError:  "expected comma separated colors",
+		},
+		{
+			name:       "too wide",
+			target:     "/swatch.png?colors=red&width=5000",
+			wantStatus: http.StatusBadRequest,
+			wantError:  `invalid width "5000": expected 1 to 4096`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			New(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected: %v\n returned: %v\n", tt.wantStatus, rec.Code)
+			}
+			if tt.wantError != "" {
+				var body errorResponse
+				json.Unmarshal(rec.Body.Bytes(), &body)
+				if body.Error != tt.wantError {
+					t.Errorf("expected: %v\n returned: %v\n", tt.wantError, body.Error)
+				}
+				return
+			}
+
+			img, err := png.Decode(rec.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if img.Bounds().Size() != tt.wantSize {
+				t.Errorf("expected: %v\n returned: %v\n", tt.wantSize, img.Bounds().Size())
+			}
+			for pt, want := range tt.wantPixels {
+				if got := color.RGBAModel.Convert(img.At(pt.X, pt.Y)); got != want {
+					t.Errorf("expected: %v\n returned: %v\n", want, got)
+				}
+			}
+		})
+	}
+}
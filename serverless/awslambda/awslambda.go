@@ -0,0 +1,187 @@
+// Package awslambda adapts palette extraction to AWS Lambda: an S3 trigger extracting the palette of each uploaded
+// image and an HTTP handler serving the REST API of server.Server behind a function URL or API Gateway HTTP API.
+//
+// The event types mirror the JSON Lambda delivers, so start the handlers with the Lambda runtime directly:
+//
+//	h := &awslambda.Handler{Open: openS3Object, OnPalette: savePalette}
+//	lambda.Start(h.HandleS3)
+package awslambda
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	"github.com/evancaplan/palettecalculator/serverless"
+)
+
+// S3 event notification
+type S3Event struct {
+	Records []S3EventRecord `json:"Records"`
+}
+
+type S3EventRecord struct {
+	EventName string   `json:"eventName"`
+	S3        S3Entity `json:"s3"`
+}
+
+type S3Entity struct {
+	Bucket struct {
+		Name string `json:"name"`
+	} `json:"bucket"`
+	Object struct {
+		// URL encoded as in the notification, Handler decodes it
+		Key  string `json:"key"`
+		Size int64  `json:"size"`
+	} `json:"object"`
+}
+
+// Function URL and API Gateway HTTP API request, payload format 2.0
+type HTTPRequest struct {
+	RawPath         string            `json:"rawPath"`
+	RawQueryString  string            `json:"rawQueryString"`
+	Headers         map[string]string `json:"headers"`
+	Body            string            `json:"body"`
+	IsBase64Encoded bool              `json:"isBase64Encoded"`
+	RequestContext  struct {
+		HTTP struct {
+			Method string `json:"method"`
+		} `json:"http"`
+	} `json:"requestContext"`
+}
+
+// Function URL and API Gateway HTTP API response
+type HTTPResponse struct {
+	StatusCode      int               `json:"statusCode"`
+	Headers         map[string]string `json:"headers"`
+	Body            string            `json:"body"`
+	IsBase64Encoded bool              `json:"isBase64Encoded"`
+}
+
+// Lambda handlers of palette extraction
+type Handler struct {
+	Backend serverless.Backend
+	// Opens an uploaded object, e.g. with the AWS SDK's GetObject. Required by HandleS3
+	Open func(ctx context.Context, bucket string, key string) (io.ReadCloser, error)
+	// Called with the palette of each object HandleS3 extracts, e.g. to write it to DynamoDB
+	OnPalette func(ctx context.Context, bucket string, key string, p *palettecalculator.Palette) error
+}
+
+// Extracts the palette of every object in the event and passes it to OnPalette, stopping at the first error so
+// Lambda retries the event
+func (h *Handler) HandleS3(ctx context.Context, event S3Event) error {
+	if h.Open == nil || h.OnPalette == nil {
+		return errors.New("awslambda: Handler.Open and Handler.OnPalette must be set")
+	}
+
+	for _, record := range event.Records {
+		bucket := record.S3.Bucket.Name
+		// keys are form encoded, spaces arrive as +
+		key, err := url.QueryUnescape(record.S3.Object.Key)
+		if err != nil {
+			return fmt.Errorf("invalid key %q: %w", record.S3.Object.Key, err)
+		}
+
+		p, err := h.extract(ctx, bucket, key)
+		if err != nil {
+			return fmt.Errorf("extracting s3://%s/%s: %w", bucket, key, err)
+		}
+		if err := h.OnPalette(ctx, bucket, key, p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) extract(ctx context.Context, bucket string, key string) (*palettecalculator.Palette, error) {
+	r, err := h.Open(ctx, bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return h.Backend.ExtractReader(ctx, r)
+}
+
+// Serves the request with the REST API of server.Server, binary responses such as swatch.png are base64 encoded
+func (h *Handler) HandleHTTP(ctx context.Context, req HTTPRequest) (*HTTPResponse, error) {
+	body := []byte(req.Body)
+	if req.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("decoding request body: %w", err)
+		}
+		body = decoded
+	}
+
+	target := req.RawPath
+	if req.RawQueryString != "" {
+		target += "?" + req.RawQueryString
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.RequestContext.HTTP.Method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range req.Headers {
+		httpReq.Header.Set(name, value)
+	}
+
+	w := &responseWriter{header: make(http.Header), status: http.StatusOK}
+	handler, err := h.Backend.Handler()
+	if err != nil {
+		errorBody, _ := json.Marshal(map[string]string{"error": err.Error()})
+		return &HTTPResponse{
+			StatusCode: http.StatusServiceUnavailable,
+			Headers:    map[string]string{"Content-Type": "application/json"},
+			Body:       string(errorBody) + "\n",
+		}, nil
+	}
+	handler.ServeHTTP(w, httpReq)
+
+	return w.response(), nil
+}
+
+// Buffers a response for HandleHTTP
+type responseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *responseWriter) response() *HTTPResponse {
+	resp := &HTTPResponse{StatusCode: w.status, Headers: make(map[string]string)}
+	for name := range w.header {
+		resp.Headers[name] = w.header.Get(name)
+	}
+
+	contentType := w.header.Get("Content-Type")
+	if strings.HasPrefix(contentType, "application/json") || strings.HasPrefix(contentType, "text/") {
+		resp.Body = w.body.String()
+	} else {
+		resp.Body = base64.StdEncoding.EncodeToString(w.body.Bytes())
+		resp.IsBase64Encoded = true
+	}
+
+	return resp
+}
@@ -0,0 +1,185 @@
+package awslambda
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"reflect"
+	"testing"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	"github.com/evancaplan/palettecalculator/serverless"
+)
+
+func testImage(t *testing.T) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	for x := 0; x < 2; x++ {
+		for y := 0; y < 2; y++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func s3Event(bucket string, keys ...string) S3Event {
+	var event S3Event
+	for _, key := range keys {
+		var record S3EventRecord
+		record.S3.Bucket.Name = bucket
+		record.S3.Object.Key = key
+		event.Records = append(event.Records, record)
+	}
+	return event
+}
+
+func TestHandleS3(t *testing.T) {
+	img := testImage(t)
+	red := &palettecalculator.Palette{Colors: []palettecalculator.Color{{Red: 255, Hex: "ff0000"}}, Weights: []float64{1}}
+
+	tests := []struct {
+		name        string
+		event       S3Event
+		openErr     error
+		expected    map[string]*palettecalculator.Palette
+		expectedErr string
+	}{
+		{
+			name:     "decodes keys",
+			event:    s3Event("uploads", "summer+sale/hero%281%29.png", "logo.png"),
+			expected: map[string]*palettecalculator.Palette{"uploads/summer sale/hero(1).png": red, "uploads/logo.png": red},
+		},
+		{
+			name:        "open error",
+			event:       s3Event("uploads", "missing.png"),
+			openErr:     errors.New("NoSuchKey"),
+			expected:    map[string]*palettecalculator.Palette{},
+			expectedErr: "extracting s3://uploads/missing.png: NoSuchKey",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			returned := make(map[string]*palettecalculator.Palette)
+			h := &Handler{
+				Backend: serverless.Backend{Local: true, K: 1},
+				Open: func(ctx context.Context, bucket string, key string) (io.ReadCloser, error) {
+					if tt.openErr != nil {
+						return nil, tt.openErr
+					}
+					return io.NopCloser(bytes.NewReader(img)), nil
+				},
+				OnPalette: func(ctx context.Context, bucket string, key string, p *palettecalculator.Palette) error {
+					returned[bucket+"/"+key] = p
+					return nil
+				},
+			}
+
+			err := h.HandleS3(context.Background(), tt.event)
+			if (err != nil || tt.expectedErr != "") && (err == nil || err.Error() != tt.expectedErr) {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expectedErr, err)
+			}
+			if !reflect.DeepEqual(returned, tt.expected) {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expected, returned)
+			}
+		})
+	}
+}
+
+func TestHandleS3WrapsErrors(t *testing.T) {
+	h := &Handler{
+		Backend: serverless.Backend{Local: true, K: 1},
+		Open: func(ctx context.Context, bucket string, key string) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader([]byte("not a png"))), nil
+		},
+		OnPalette: func(ctx context.Context, bucket string, key string, p *palettecalculator.Palette) error {
+			return nil
+		},
+	}
+
+	err := h.HandleS3(context.Background(), s3Event("uploads", "notes.txt"))
+	if !errors.Is(err, palettecalculator.ErrDecode) {
+		t.Errorf("expected: %v\n returned: %v\n", palettecalculator.ErrDecode, err)
+	}
+}
+
+func TestHandleHTTP(t *testing.T) {
+	img := testImage(t)
+
+	tests := []struct {
+		name             string
+		request          HTTPRequest
+		expectedStatus   int
+		expectedBody     string
+		expectedBase64   bool
+		expectedPNGBytes bool
+	}{
+		{
+			name: "base64 image upload",
+			request: func() HTTPRequest {
+				req := HTTPRequest{RawPath: "/palette", RawQueryString: "k=1", Headers: map[string]string{"content-type": "image/png"}}
+				req.Body, req.IsBase64Encoded = base64.StdEncoding.EncodeToString(img), true
+				req.RequestContext.HTTP.Method = "POST"
+				return req
+			}(),
+			expectedStatus: 200,
+			expectedBody:   `{"colors":[{"red":255,"green":0,"blue":0,"hex":"ff0000"}],"weights":[1]}` + "\n",
+		},
+		{
+			name: "binary swatch",
+			request: func() HTTPRequest {
+				req := HTTPRequest{RawPath: "/swatch.png", RawQueryString: "colors=red&width=2&height=2"}
+				req.RequestContext.HTTP.Method = "GET"
+				return req
+			}(),
+			expectedStatus:   200,
+			expectedBase64:   true,
+			expectedPNGBytes: true,
+		},
+		{
+			name: "error",
+			request: func() HTTPRequest {
+				req := HTTPRequest{RawPath: "/scheme"}
+				req.RequestContext.HTTP.Method = "GET"
+				return req
+			}(),
+			expectedStatus: 400,
+			expectedBody:   `{"error":"expected a color"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{Backend: serverless.Backend{Local: true}}
+			resp, err := h.HandleHTTP(context.Background(), tt.request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expectedStatus, resp.StatusCode)
+			}
+			if resp.IsBase64Encoded != tt.expectedBase64 {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expectedBase64, resp.IsBase64Encoded)
+			}
+			if tt.expectedPNGBytes {
+				data, _ := base64.StdEncoding.DecodeString(resp.Body)
+				if _, err := png.Decode(bytes.NewReader(data)); err != nil {
+					t.Errorf("expected: a png\n returned: %v\n", err)
+				}
+				return
+			}
+			if resp.Body != tt.expectedBody {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expectedBody, resp.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Package gcf adapts palette extraction to Google Cloud Functions: an HTTP function serving the REST API of
+// server.Server and a Cloud Storage trigger extracting the palette of each uploaded image.
+//
+// Register them with the Functions Framework, for a 2nd gen Storage trigger decode the event's data into a
+// StorageObject first:
+//
+//	var fns = &gcf.Functions{OnPalette: savePalette}
+//
+//	func init() {
+//		functions.HTTP("palette", fns.HTTP)
+//		functions.CloudEvent("onUpload", func(ctx context.Context, e event.Event) error {
+//			var object gcf.StorageObject
+//			if err := e.DataAs(&object); err != nil {
+//				return err
+//			}
+//			return fns.StorageTrigger(ctx, object)
+//		})
+//	}
+package gcf
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	"github.com/evancaplan/palettecalculator/serverless"
+)
+
+// Cloud Storage object of a google.cloud.storage.object.v1.finalized event
+type StorageObject struct {
+	Bucket      string `json:"bucket"`
+	Name        string `json:"name"`
+	ContentType string `json:"contentType"`
+}
+
+// Cloud Functions of palette extraction
+type Functions struct {
+	Backend serverless.Backend
+	// Opens an uploaded object, e.g. with the Cloud Storage client. Required by a local backend, nil lets Vision
+	// read the object by its gs:// URI
+	Open func(ctx context.Context, bucket string, name string) (io.ReadCloser, error)
+	// Called with the palette of each image StorageTrigger extracts, e.g. to save it to Firestore
+	OnPalette func(ctx context.Context, object StorageObject, p *palettecalculator.Palette) error
+}
+
+// HTTP function serving POST /palette, GET /scheme and GET /swatch.png
+func (f *Functions) HTTP(w http.ResponseWriter, r *http.Request) {
+	handler, err := f.Backend.Handler()
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Storage trigger extracting the palette of an uploaded image and passing it to OnPalette. Objects that are not
+// images are skipped so they are not retried
+func (f *Functions) StorageTrigger(ctx context.Context, object StorageObject) error {
+	if object.ContentType != "" && !strings.HasPrefix(object.ContentType, "image/") {
+		return nil
+	}
+	if f.OnPalette == nil {
+		return errors.New("gcf: Functions.OnPalette is not set")
+	}
+
+	p, err := f.extract(ctx, object)
+	if err != nil {
+		return fmt.Errorf("extracting gs://%s/%s: %w", object.Bucket, object.Name, err)
+	}
+
+	return f.OnPalette(ctx, object, p)
+}
+
+func (f *Functions) extract(ctx context.Context, object StorageObject) (*palettecalculator.Palette, error) {
+	if f.Open == nil {
+		if f.Backend.Local {
+			return nil, errors.New("a local backend needs Functions.Open to read objects")
+		}
+		return f.Backend.ExtractURI(ctx, fmt.Sprintf("gs://%s/%s", object.Bucket, object.Name))
+	}
+
+	r, err := f.Open(ctx, object.Bucket, object.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return f.Backend.ExtractReader(ctx, r)
+}
@@ -0,0 +1,137 @@
+package gcf
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	"github.com/evancaplan/palettecalculator/serverless"
+)
+
+func testImage(t *testing.T) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	for x := 0; x < 2; x++ {
+		for y := 0; y < 2; y++ {
+			img.Set(x, y, color.RGBA{B: 255, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestStorageTrigger(t *testing.T) {
+	img := testImage(t)
+	blue := &palettecalculator.Palette{Colors: []palettecalculator.Color{{Blue: 255, Hex: "0000ff"}}, Weights: []float64{1}}
+
+	tests := []struct {
+		name        string
+		functions   *Functions
+		object      StorageObject
+		expected    *palettecalculator.Palette
+		expectedErr string
+	}{
+		{
+			name:     "image",
+			object:   StorageObject{Bucket: "uploads", Name: "hero.png", ContentType: "image/png"},
+			expected: blue,
+		},
+		{
+			name:   "skips other objects",
+			object: StorageObject{Bucket: "uploads", Name: "notes.txt", ContentType: "text/plain"},
+		},
+		{
+			name:        "local backend without open",
+			functions:   &Functions{Backend: serverless.Backend{Local: true}},
+			object:      StorageObject{Bucket: "uploads", Name: "hero.png"},
+			expectedErr: "extracting gs://uploads/hero.png: a local backend needs Functions.Open to read objects",
+		},
+		{
+			name: "vision reads the gs uri",
+			functions: &Functions{Backend: serverless.Backend{NewCalculator: func() (*palettecalculator.PaletteCalculator, error) {
+				return nil, errors.New("no credentials")
+			}}},
+			object:      StorageObject{Bucket: "uploads", Name: "hero.png"},
+			expectedErr: "extracting gs://uploads/hero.png: no credentials",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.functions
+			if f == nil {
+				f = &Functions{
+					Backend: serverless.Backend{Local: true, K: 1},
+					Open: func(ctx context.Context, bucket string, name string) (io.ReadCloser, error) {
+						return io.NopCloser(bytes.NewReader(img)), nil
+					},
+				}
+			}
+			var returned *palettecalculator.Palette
+			f.OnPalette = func(ctx context.Context, object StorageObject, p *palettecalculator.Palette) error {
+				returned = p
+				return nil
+			}
+
+			err := f.StorageTrigger(context.Background(), tt.object)
+			if (err != nil || tt.expectedErr != "") && (err == nil || err.Error() != tt.expectedErr) {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expectedErr, err)
+			}
+			if !reflect.DeepEqual(returned, tt.expected) {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expected, returned)
+			}
+		})
+	}
+}
+
+func TestHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		local          bool
+		newCalculator  func() (*palettecalculator.PaletteCalculator, error)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "serves the rest api",
+			local:          true,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"scheme":"complimentary","colors":[{"red":255,"green":0,"blue":0,"hex":"ff0000"},{"red":0,"green":255,"blue":255,"hex":"00ffff"}]}` + "\n",
+		},
+		{
+			name: "calculator error",
+			newCalculator: func() (*palettecalculator.PaletteCalculator, error) {
+				return nil, errors.New("no credentials")
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"error":"no credentials"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Functions{Backend: serverless.Backend{Local: tt.local, NewCalculator: tt.newCalculator}}
+			rec := httptest.NewRecorder()
+			f.HTTP(rec, httptest.NewRequest(http.MethodGet, "/scheme?color=red", nil))
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expectedStatus, rec.Code)
+			}
+			if rec.Body.String() != tt.expectedBody {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Package serverless holds what the Cloud Functions and Lambda adapters in gcf and awslambda share: an extraction
+// backend that is created on first use and kept for warm invocations.
+package serverless
+
+import (
+	"context"
+	"errors"
+	"image"
+	"io"
+	"net/http"
+	"sync"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	"github.com/evancaplan/palettecalculator/server"
+)
+
+// Extraction backend of a function. The Vision client is created by the first invocation that needs it, so cold
+// starts serving health checks or skipped events don't pay for it, and is reused while the instance is warm
+type Backend struct {
+	// Extract locally with ExtractPalette instead of the Vision API
+	Local bool
+	// Colors extracted locally, zero extracts server.DefaultColors
+	K int
+	// Creates the Vision calculator, nil uses NewPaletteCalculator
+	NewCalculator func() (*palettecalculator.PaletteCalculator, error)
+
+	mu         sync.Mutex
+	calculator *palettecalculator.PaletteCalculator
+	handler    *server.Server
+}
+
+// Returns the Vision calculator, creating it on first use. A failed creation is retried by the next call rather
+// than failing every invocation of the instance. Local backends return nil
+func (b *Backend) Calculator() (*palettecalculator.PaletteCalculator, error) {
+	if b.Local {
+		return nil, nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.calculator != nil {
+		return b.calculator, nil
+	}
+
+	newCalculator := b.NewCalculator
+	if newCalculator == nil {
+		newCalculator = palettecalculator.NewPaletteCalculator
+	}
+	pc, err := newCalculator()
+	if err != nil {
+		return nil, err
+	}
+	b.calculator = pc
+
+	return pc, nil
+}
+
+// Extracts the palette of the image read from r, Vision calls are made with ctx
+func (b *Backend) ExtractReader(ctx context.Context, r io.Reader) (*palettecalculator.Palette, error) {
+	if b.Local {
+		img, _, err := image.Decode(r)
+		if err != nil {
+			return nil, &palettecalculator.DecodeError{Err: err}
+		}
+		return new(palettecalculator.PaletteCalculator).ExtractPalette(img, b.k())
+	}
+
+	pc, err := b.Calculator()
+	if err != nil {
+		return nil, err
+	}
+
+	return pc.WithContext(ctx).CalculatePaletteFromReader(r)
+}
+
+// Extracts the palette of the image at uri, e.g. gs://bucket/object, with the Vision API called with ctx
+func (b *Backend) ExtractURI(ctx context.Context, uri string) (*palettecalculator.Palette, error) {
+	if b.Local {
+		return nil, errors.New("local backend cannot extract from a uri")
+	}
+
+	pc, err := b.Calculator()
+	if err != nil {
+		return nil, err
+	}
+
+	return pc.WithContext(ctx).CalculatePaletteFromURI(uri)
+}
+
+// Returns the REST API of server.Server extracting with this backend, created on first use
+func (b *Backend) Handler() (http.Handler, error) {
+	pc, err := b.Calculator()
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.handler == nil {
+		b.handler = server.New(pc)
+	}
+
+	return b.handler, nil
+}
+
+func (b *Backend) k() int {
+	if b.K > 0 {
+		return b.K
+	}
+	return server.DefaultColors
+}
@@ -0,0 +1,53 @@
+package serverless
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+)
+
+func TestBackendCalculatorIsCreatedOnce(t *testing.T) {
+	calls := 0
+	pc := new(palettecalculator.PaletteCalculator)
+	b := &Backend{NewCalculator: func() (*palettecalculator.PaletteCalculator, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("metadata server timeout")
+		}
+		return pc, nil
+	}}
+
+	// a failed cold start is retried by the next invocation
+	if _, err := b.Calculator(); err == nil || err.Error() != "metadata server timeout" {
+		t.Errorf("expected: %v\n returned: %v\n", "metadata server timeout", err)
+	}
+	for i := 0; i < 2; i++ {
+		returned, err := b.Calculator()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if returned != pc {
+			t.Errorf("expected: %v\n returned: %v\n", pc, returned)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected: %v\n returned: %v\n", 2, calls)
+	}
+}
+
+func TestLocalBackend(t *testing.T) {
+	b := &Backend{Local: true, NewCalculator: func() (*palettecalculator.PaletteCalculator, error) {
+		t.Fatal("local backends do not create a calculator")
+		return nil, nil
+	}}
+
+	if pc, err := b.Calculator(); pc != nil || err != nil {
+		t.Errorf("expected: %v\n returned: %v %v\n", nil, pc, err)
+	}
+	if _, err := b.ExtractURI(context.Background(), "gs://bucket/image.png"); !reflect.DeepEqual(err, errors.New("local backend cannot extract from a uri")) {
+		t.Errorf("expected: %v\n returned: %v\n", "local backend cannot extract from a uri", err)
+	}
+}
@@ -0,0 +1,90 @@
+package palettecalculator
+
+import "math"
+
+// CIEDE2000 distance at which two colors count as entirely different, black to white is 100
+const MaxSimilarityDeltaE = 100
+
+// Calculates how similar two palettes are from 0 (nothing alike) to 1 (the same colors in any order).
+// Colors are paired up so the total CIEDE2000 distance is minimal, colors left without a partner in the
+// larger palette count as entirely different
+func (pc *PaletteCalculator) Similarity(p1 *Palette, p2 *Palette) float64 {
+	n := int(math.Max(float64(len(p1.Colors)), float64(len(p2.Colors))))
+	if n == 0 {
+		return 1
+	}
+
+	costs := make([][]float64, n)
+	for i := range costs {
+		costs[i] = make([]float64, n)
+		for j := range costs[i] {
+			costs[i][j] = 1
+			if i < len(p1.Colors) && j < len(p2.Colors) {
+				costs[i][j] = math.Min(pc.DistanceDeltaE(&p1.Colors[i], &p2.Colors[j], CIEDE2000), MaxSimilarityDeltaE) / MaxSimilarityDeltaE
+			}
+		}
+	}
+
+	total := float64(0)
+	for i, j := range pc.hungarian(costs) {
+		total += costs[i][j]
+	}
+
+	return 1 - total/float64(n)
+}
+
+// Hungarian algorithm for a square cost matrix, returns the column assigned to each row minimizing total cost
+func (pc *PaletteCalculator) hungarian(costs [][]float64) []int {
+	n := len(costs)
+	// potentials and matching are 1-indexed, index 0 is a virtual column used to start each augmenting path
+	u, v := make([]float64, n+1), make([]float64, n+1)
+	match, way := make([]int, n+1), make([]int, n+1)
+
+	for row := 1; row <= n; row++ {
+		match[0] = row
+		column := 0
+		minimum := make([]float64, n+1)
+		used := make([]bool, n+1)
+		for j := range minimum {
+			minimum[j] = math.Inf(1)
+		}
+
+		for match[column] != 0 {
+			used[column] = true
+			i, delta, next := match[column], math.Inf(1), 0
+			for j := 1; j <= n; j++ {
+				if used[j] {
+					continue
+				}
+				if reduced := costs[i-1][j-1] - u[i] - v[j]; reduced < minimum[j] {
+					minimum[j], way[j] = reduced, column
+				}
+				if minimum[j] < delta {
+					delta, next = minimum[j], j
+				}
+			}
+			for j := 0; j <= n; j++ {
+				if used[j] {
+					u[match[j]] += delta
+					v[j] -= delta
+				} else {
+					minimum[j] -= delta
+				}
+			}
+			column = next
+		}
+
+		for column != 0 {
+			previous := way[column]
+			match[column] = match[previous]
+			column = previous
+		}
+	}
+
+	assignment := make([]int, n)
+	for j := 1; j <= n; j++ {
+		assignment[match[j]-1] = j - 1
+	}
+
+	return assignment
+}
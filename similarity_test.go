@@ -0,0 +1,49 @@
+package palettecalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSimilarity(t *testing.T) {
+	teal := Color{Red, Green, Blue, Hex}
+	brown := Color{119, 45, 24, "772d18"}
+	white := Color{255, 255, 255, "ffffff"}
+	black := Color{0, 0, 0, "000000"}
+	photo := &Palette{Colors: []Color{teal, brown}}
+
+	for _, test := range []struct {
+		name               string
+		p1                 *Palette
+		p2                 *Palette
+		expectedSimilarity float64
+	}{
+		{name: "should be identical regardless of order", p1: photo, p2: &Palette{Colors: []Color{brown, teal}}, expectedSimilarity: 1},
+		{name: "should be nearly identical for near-duplicate colors", p1: photo, p2: &Palette{Colors: []Color{{120, 47, 24, "782f18"}, {26, 100, 120, "1a6478"}}}, expectedSimilarity: .992},
+		{name: "should penalize unmatched colors", p1: photo, p2: &Palette{Colors: []Color{brown, teal, white}}, expectedSimilarity: .6667},
+		{name: "should be dissimilar for opposite colors", p1: &Palette{Colors: []Color{white}}, p2: &Palette{Colors: []Color{black}}, expectedSimilarity: 0},
+		{name: "should be dissimilar to empty palette", p1: photo, p2: &Palette{}, expectedSimilarity: 0},
+		{name: "should be identical for empty palettes", p1: &Palette{}, p2: &Palette{}, expectedSimilarity: 1},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedSimilarity := roundFloat(paletteCalculator.Similarity(test.p1, test.p2), 4)
+
+			if test.expectedSimilarity != returnedSimilarity {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedSimilarity, returnedSimilarity)
+			}
+		})
+	}
+}
+
+func TestHungarian(t *testing.T) {
+	paletteCalculator := new(PaletteCalculator)
+	expectedAssignment := []int{1, 0, 2}
+
+	returnedAssignment := paletteCalculator.hungarian([][]float64{{4, 1, 3}, {2, 0, 5}, {3, 2, 2}})
+
+	if !reflect.DeepEqual(expectedAssignment, returnedAssignment) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedAssignment, returnedAssignment)
+	}
+}
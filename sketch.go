@@ -0,0 +1,42 @@
+package palettecalculator
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Sketch Palettes plugin file, the .sketchpalette format
+type sketchPalette struct {
+	CompatibleVersion string        `json:"compatibleVersion"`
+	PluginVersion     string        `json:"pluginVersion"`
+	Colors            []sketchColor `json:"colors"`
+}
+
+// Sketch color, channels in [0,1]
+type sketchColor struct {
+	Name  string  `json:"name"`
+	Red   float64 `json:"red"`
+	Green float64 `json:"green"`
+	Blue  float64 `json:"blue"`
+	Alpha float64 `json:"alpha"`
+}
+
+// Writes the palette as Sketch palette JSON, the .sketchpalette format of the Sketch Palettes plugin. Colors are
+// named by namer, a nil namer names them color-1, color-2, ...
+func (pc *PaletteCalculator) WriteSketchPalette(w io.Writer, p *Palette, namer ColorNamer) error {
+	palette := sketchPalette{CompatibleVersion: "2.0", PluginVersion: "2.22", Colors: []sketchColor{}}
+
+	names := pc.colorNames(p, namer)
+	for i := range p.Colors {
+		c := p.Colors[i].Clamp()
+		palette.Colors = append(palette.Colors, sketchColor{
+			Name:  names[i],
+			Red:   roundFloat(c.Red/255, 4),
+			Green: roundFloat(c.Green/255, 4),
+			Blue:  roundFloat(c.Blue/255, 4),
+			Alpha: 1,
+		})
+	}
+
+	return json.NewEncoder(w).Encode(palette)
+}
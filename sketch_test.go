@@ -0,0 +1,24 @@
+package palettecalculator
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteSketchPalette(t *testing.T) {
+	palette := &Palette{Colors: []Color{{24, 98, 119, "186277"}, {255, 0, 0, "ff0000"}}}
+	expected := `{"compatibleVersion":"2.0","pluginVersion":"2.22","colors":[` +
+		`{"name":"color-1","red":0.0941,"green":0.3843,"blue":0.4667,"alpha":1},` +
+		`{"name":"color-2","red":1,"green":0,"blue":0,"alpha":1}]}` + "\n"
+	paletteCalculator := new(PaletteCalculator)
+	var buf bytes.Buffer
+
+	err := paletteCalculator.WriteSketchPalette(&buf, palette, nil)
+
+	if err != nil {
+		t.Errorf("expected error: %v returned error: %v", nil, err)
+	}
+	if expected != buf.String() {
+		t.Errorf("expected: %v\n returned: %v\n", expected, buf.String())
+	}
+}
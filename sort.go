@@ -0,0 +1,126 @@
+package palettecalculator
+
+import "sort"
+
+// Order a palette's colors are sorted in
+type SortOrder int
+
+const (
+	// OKLCH hue from red through to purple, grays last
+	SortByHue SortOrder = iota
+	// OKLCH lightness from darkest to lightest
+	SortByLightness
+	// OKLCH chroma from grayest to most vivid
+	SortByChroma
+	// Darkest color first, then each next color is the one closest in CIEDE2000 so neighbours blend smoothly
+	SortSmooth
+)
+
+// Returns a copy of the palette with its colors sorted in order
+func (pc *PaletteCalculator) SortPalette(p *Palette, order SortOrder) *Palette {
+	var lchs []*OKLCH
+	for i := range p.Colors {
+		lchs = append(lchs, pc.ConvertOKLABToOKLCH(pc.ConvertRGBToOKLAB(&p.Colors[i])))
+	}
+
+	indices := make([]int, len(p.Colors))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	switch order {
+	case SortByLightness:
+		sort.SliceStable(indices, func(i, j int) bool { return lchs[indices[i]].L < lchs[indices[j]].L })
+	case SortByChroma:
+		sort.SliceStable(indices, func(i, j int) bool { return lchs[indices[i]].C < lchs[indices[j]].C })
+	case SortSmooth:
+		indices = pc.smoothOrder(p.Colors, lchs)
+	default:
+		sort.SliceStable(indices, func(i, j int) bool {
+			a, b := lchs[indices[i]], lchs[indices[j]]
+			if pc.isAchromatic(a) != pc.isAchromatic(b) {
+				return !pc.isAchromatic(a)
+			}
+			if pc.isAchromatic(a) {
+				return a.L < b.L
+			}
+			return a.H < b.H
+		})
+	}
+
+	sorted := &Palette{Name: p.Name}
+	for _, i := range indices {
+		sorted.Colors = append(sorted.Colors, p.Colors[i])
+		if p.Weights != nil {
+			sorted.Weights = append(sorted.Weights, p.weight(i))
+		}
+	}
+
+	return sorted
+}
+
+func (pc *PaletteCalculator) isAchromatic(lch *OKLCH) bool {
+	const epsilon = 1e-4
+	return lch.C < epsilon
+}
+
+// Greedy nearest neighbour path from the darkest color, improved with 2-opt until no reversal shortens it
+func (pc *PaletteCalculator) smoothOrder(colors []Color, lchs []*OKLCH) []int {
+	n := len(colors)
+	if n == 0 {
+		return nil
+	}
+
+	distances := make([][]float64, n)
+	for i := range colors {
+		distances[i] = make([]float64, n)
+		for j := range colors {
+			distances[i][j] = pc.DistanceDeltaE(&colors[i], &colors[j], CIEDE2000)
+		}
+	}
+
+	start := 0
+	for i := range lchs {
+		if lchs[i].L < lchs[start].L {
+			start = i
+		}
+	}
+
+	path := []int{start}
+	visited := make([]bool, n)
+	visited[start] = true
+	for len(path) < n {
+		last, next := path[len(path)-1], -1
+		for i := 0; i < n; i++ {
+			if !visited[i] && (next == -1 || distances[last][i] < distances[last][next]) {
+				next = i
+			}
+		}
+		visited[next] = true
+		path = append(path, next)
+	}
+
+	const epsilon = 1e-9
+	for improved := true; improved; {
+		improved = false
+		for i := 1; i < n-1; i++ {
+			for j := i + 1; j < n; j++ {
+				// reversing path[i..j] swaps edges (i-1, i) and (j, j+1) for (i-1, j) and (i, j+1)
+				before := distances[path[i-1]][path[i]]
+				after := distances[path[i-1]][path[j]]
+				if j < n-1 {
+					before += distances[path[j]][path[j+1]]
+					after += distances[path[i]][path[j+1]]
+				}
+				if after < before-epsilon {
+					for l, r := i, j; l < r; l, r = l+1, r-1 {
+						path[l], path[r] = path[r], path[l]
+					}
+					improved = true
+				}
+			}
+		}
+	}
+
+	return path
+}
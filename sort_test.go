@@ -0,0 +1,51 @@
+package palettecalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortPalette(t *testing.T) {
+	white := Color{255, 255, 255, "ffffff"}
+	black := Color{0, 0, 0, "000000"}
+	gray := Color{128, 128, 128, "808080"}
+	teal := Color{Red, Green, Blue, Hex}
+	lightTeal := Color{40, 120, 150, "287896"}
+	brown := Color{119, 45, 24, "772d18"}
+	red := Color{200, 60, 40, "c83c28"}
+
+	for _, test := range []struct {
+		name           string
+		order          SortOrder
+		expectedColors []Color
+	}{
+		{name: "should sort by hue with grays last", order: SortByHue, expectedColors: []Color{red, brown, teal, lightTeal, black, gray, white}},
+		{name: "should sort by lightness", order: SortByLightness, expectedColors: []Color{black, brown, teal, lightTeal, red, gray, white}},
+		{name: "should sort by chroma", order: SortByChroma, expectedColors: []Color{black, gray, white, teal, lightTeal, brown, red}},
+		{name: "should sort smoothly from darkest color", order: SortSmooth, expectedColors: []Color{black, brown, red, gray, teal, lightTeal, white}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			pc := &PaletteCalculator{}
+			p := &Palette{Name: "photo", Colors: []Color{white, teal, brown, black, red, lightTeal, gray}}
+
+			returnedPalette := pc.SortPalette(p, test.order)
+
+			expectedPalette := &Palette{Name: "photo", Colors: test.expectedColors}
+			if !reflect.DeepEqual(expectedPalette, returnedPalette) {
+				t.Errorf("expected: %v\n returned: %v\n", expectedPalette, returnedPalette)
+			}
+		})
+	}
+}
+
+func TestSortPaletteKeepsWeights(t *testing.T) {
+	pc := &PaletteCalculator{}
+	p := &Palette{Colors: []Color{{255, 255, 255, "ffffff"}, {0, 0, 0, "000000"}}, Weights: []float64{.7, .3}}
+	expectedPalette := &Palette{Colors: []Color{{0, 0, 0, "000000"}, {255, 255, 255, "ffffff"}}, Weights: []float64{.3, .7}}
+
+	returnedPalette := pc.SortPalette(p, SortByLightness)
+
+	if !reflect.DeepEqual(expectedPalette, returnedPalette) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedPalette, returnedPalette)
+	}
+}
@@ -0,0 +1,59 @@
+package palettecalculator
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Stores the color as its "#" prefixed hex, e.g. "#186277"
+func (c Color) Value() (driver.Value, error) {
+	return cssHex(&c), nil
+}
+
+// Scans a hex color, e.g. "#186277", or a color as JSON from a string or []byte column
+func (c *Color) Scan(src interface{}) error {
+	text, err := scanText(src, "Color")
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(strings.TrimSpace(text), "{") {
+		return c.UnmarshalJSON([]byte(text))
+	}
+
+	return c.UnmarshalText([]byte(text))
+}
+
+// Stores the palette as JSON, e.g. for a json or jsonb column
+func (p Palette) Value() (driver.Value, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// Scans a palette stored as JSON from a string or []byte column
+func (p *Palette) Scan(src interface{}) error {
+	text, err := scanText(src, "Palette")
+	if err != nil {
+		return err
+	}
+
+	return p.UnmarshalJSON([]byte(text))
+}
+
+func scanText(src interface{}, into string) (string, error) {
+	switch v := src.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case nil:
+		return "", fmt.Errorf("cannot scan NULL into %s", into)
+	default:
+		return "", fmt.Errorf("cannot scan %T into %s", src, into)
+	}
+}
@@ -0,0 +1,98 @@
+package palettecalculator
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestColorValue(t *testing.T) {
+	c := Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+
+	returned, err := c.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := driver.Value("#186277")
+	if !reflect.DeepEqual(returned, expected) {
+		t.Errorf("expected: %v\n returned: %v\n", expected, returned)
+	}
+}
+
+func TestColorScan(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		src         interface{}
+		expected    Color
+		expectedErr error
+	}{
+		{
+			name:     "should scan hex string",
+			src:      "#186277",
+			expected: Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+		},
+		{
+			name:     "should scan hex bytes",
+			src:      []byte("186277"),
+			expected: Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+		},
+		{
+			name:     "should scan json",
+			src:      []byte(`{"red":24,"green":98,"blue":119,"hex":"186277"}`),
+			expected: Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+		},
+		{
+			name:        "should reject null",
+			src:         nil,
+			expectedErr: errors.New("cannot scan NULL into Color"),
+		},
+		{
+			name:        "should reject other types",
+			src:         int64(42),
+			expectedErr: errors.New("cannot scan int64 into Color"),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var returned Color
+			err := returned.Scan(test.src)
+
+			if !reflect.DeepEqual(err, test.expectedErr) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedErr, err)
+			}
+			if !reflect.DeepEqual(returned, test.expected) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expected, returned)
+			}
+		})
+	}
+}
+
+func TestPaletteValueAndScan(t *testing.T) {
+	p := Palette{
+		Name:    "photo",
+		Colors:  []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {Red: 255, Green: 255, Blue: 255, Hex: "ffffff"}},
+		Weights: []float64{.75, .25},
+	}
+
+	value, err := p.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, src := range []interface{}{value, []byte(value.(string))} {
+		var returned Palette
+		if err := returned.Scan(src); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(returned, p) {
+			t.Errorf("expected: %v\n returned: %v\n", p, returned)
+		}
+	}
+
+	var returned Palette
+	expectedErr := errors.New("cannot scan NULL into Palette")
+	if err := returned.Scan(nil); !reflect.DeepEqual(err, expectedErr) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedErr, err)
+	}
+}
@@ -0,0 +1,42 @@
+package palettecalculator
+
+import "image/color"
+
+// Implements color.Color so a Color can be drawn or encoded with the image packages. The color is opaque, channels
+// are rounded and clamped
+func (c Color) RGBA() (uint32, uint32, uint32, uint32) {
+	return toNRGBA(&c).RGBA()
+}
+
+// Converts any color.Color to a Color, translucent colors are un-premultiplied and their alpha dropped
+func FromColor(c color.Color) *Color {
+	nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+	r, g, b := float64(nrgba.R), float64(nrgba.G), float64(nrgba.B)
+
+	return &Color{Red: r, Green: g, Blue: b, Hex: hexString(r, g, b)}
+}
+
+// Converts the palette to a color.Palette of opaque color.NRGBA, e.g. for image.NewPaletted or gif.Options
+func (p *Palette) ColorPalette() color.Palette {
+	var colors color.Palette
+	for i := range p.Colors {
+		colors = append(colors, toNRGBA(&p.Colors[i]))
+	}
+
+	return colors
+}
+
+// Converts a color.Palette, e.g. palette.WebSafe or a decoded GIF's palette, to a Palette
+func PaletteFromColorPalette(colors color.Palette) *Palette {
+	p := &Palette{}
+	for _, c := range colors {
+		p.Colors = append(p.Colors, *FromColor(c))
+	}
+
+	return p
+}
+
+func toNRGBA(c *Color) color.NRGBA {
+	clamped := c.Clamp()
+	return color.NRGBA{R: uint8(clamped.Red), G: uint8(clamped.Green), B: uint8(clamped.Blue), A: 255}
+}
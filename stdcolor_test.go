@@ -0,0 +1,57 @@
+package palettecalculator
+
+import (
+	"image/color"
+	"image/color/palette"
+	"reflect"
+	"testing"
+)
+
+func TestColorRGBA(t *testing.T) {
+	var c color.Color = Color{24, 98, 119, "186277"}
+	expected := color.RGBA{24, 98, 119, 255}
+
+	returned := color.RGBAModel.Convert(c)
+
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected: %v\n returned: %v\n", expected, returned)
+	}
+}
+
+func TestFromColor(t *testing.T) {
+	tests := []struct {
+		color    color.Color
+		expected *Color
+	}{
+		{color.RGBA{24, 98, 119, 255}, &Color{24, 98, 119, "186277"}},
+		{color.RGBA{12, 49, 59, 128}, &Color{23, 97, 117, "176175"}},
+		{color.Gray{255}, &Color{255, 255, 255, "ffffff"}},
+	}
+
+	for _, test := range tests {
+		returned := FromColor(test.color)
+
+		if !reflect.DeepEqual(test.expected, returned) {
+			t.Errorf("expected: %v\n returned: %v\n", test.expected, returned)
+		}
+	}
+}
+
+func TestPaletteColorPaletteRoundTrip(t *testing.T) {
+	p := &Palette{Colors: []Color{{24, 98, 119, "186277"}, {300, -5, 0, ""}}}
+	expectedColors := color.Palette{color.NRGBA{24, 98, 119, 255}, color.NRGBA{255, 0, 0, 255}}
+	expectedPalette := &Palette{Colors: []Color{{24, 98, 119, "186277"}, {255, 0, 0, "ff0000"}}}
+
+	returnedColors := p.ColorPalette()
+	returnedPalette := PaletteFromColorPalette(returnedColors)
+
+	if !reflect.DeepEqual(expectedColors, returnedColors) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedColors, returnedColors)
+	}
+	if !reflect.DeepEqual(expectedPalette, returnedPalette) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedPalette, returnedPalette)
+	}
+	if len(PaletteFromColorPalette(palette.WebSafe).Colors) != 216 {
+		t.Errorf("expected: %v\n returned: %v\n", 216, len(PaletteFromColorPalette(palette.WebSafe).Colors))
+	}
+}
@@ -0,0 +1,123 @@
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"time"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Top level bucket holding a bucket of palettes per key
+var paletteBucket = []byte("palettes")
+
+// Store in a BoltDB file. Each key is a bucket of palette JSON keyed by big endian unix nanosecond extraction time,
+// so history is read in order with a cursor
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// Opens or creates the store at path, waiting up to a second for another process holding it
+func OpenBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) Save(ctx context.Context, key string, p *palettecalculator.Palette, extractedAt time.Time) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		palettes, err := tx.CreateBucketIfNotExists(paletteBucket)
+		if err != nil {
+			return err
+		}
+		history, err := palettes.CreateBucketIfNotExists([]byte(key))
+		if err != nil {
+			return err
+		}
+		return history.Put(timeKey(extractedAt), data)
+	})
+}
+
+func (s *BoltStore) Latest(ctx context.Context, key string) (*Record, error) {
+	var record *Record
+	err := s.db.View(func(tx *bolt.Tx) error {
+		history := keyBucket(tx, key)
+		if history == nil {
+			return ErrNotFound
+		}
+		k, v := history.Cursor().Last()
+		if k == nil {
+			return ErrNotFound
+		}
+
+		var err error
+		record, err = decodeRecord(key, int64(binary.BigEndian.Uint64(k)), v)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (s *BoltStore) History(ctx context.Context, key string, since time.Time, until time.Time) ([]Record, error) {
+	var history []Record
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := keyBucket(tx, key)
+		if bucket == nil {
+			return nil
+		}
+
+		c := bucket.Cursor()
+		for k, v := c.Seek(timeKey(since)); k != nil; k, v = c.Next() {
+			extractedAt := int64(binary.BigEndian.Uint64(k))
+			if !until.IsZero() && extractedAt >= until.UnixNano() {
+				break
+			}
+			record, err := decodeRecord(key, extractedAt, v)
+			if err != nil {
+				return err
+			}
+			history = append(history, *record)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+func keyBucket(tx *bolt.Tx, key string) *bolt.Bucket {
+	palettes := tx.Bucket(paletteBucket)
+	if palettes == nil {
+		return nil
+	}
+	return palettes.Bucket([]byte(key))
+}
+
+// Sorts by time for times from 1970 to 2262, earlier times including the zero time sort first
+func timeKey(t time.Time) []byte {
+	k := make([]byte, 8)
+	if t.After(time.Unix(0, 0)) {
+		binary.BigEndian.PutUint64(k, uint64(t.UnixNano()))
+	}
+
+	return k
+}
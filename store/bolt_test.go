@@ -0,0 +1,15 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestBoltStore(t *testing.T) {
+	s, err := OpenBoltStore(filepath.Join(t.TempDir(), "palettes.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testStore(t, s)
+}
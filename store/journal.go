@@ -0,0 +1,36 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/evancaplan/palettecalculator/pipeline"
+)
+
+// Journal of a pipeline.Job saving each palette to a Store under its input's Key. An input counts as finished when
+// it has a palette extracted since Since, e.g. the start of this week's run of a catalog. Failed inputs are not
+// saved and are retried by the next run
+type Journal struct {
+	Store Store
+	Since time.Time
+}
+
+func (j *Journal) Finished(ctx context.Context, in pipeline.Input) (bool, error) {
+	record, err := j.Store.Latest(ctx, in.Key())
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return !record.ExtractedAt.Before(j.Since), nil
+}
+
+func (j *Journal) Record(ctx context.Context, r pipeline.Result) error {
+	if r.Err != nil {
+		return nil
+	}
+	return j.Store.Save(ctx, r.Input.Key(), r.Palette, time.Now())
+}
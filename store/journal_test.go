@@ -0,0 +1,47 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	"github.com/evancaplan/palettecalculator/pipeline"
+)
+
+func TestJournal(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+	since := time.Now()
+	p := &palettecalculator.Palette{Colors: []palettecalculator.Color{red}}
+	s.Save(ctx, "last-week", p, since.AddDate(0, 0, -7))
+	j := &Journal{Store: s, Since: since}
+
+	if err := j.Record(ctx, pipeline.Result{Input: pipeline.Input{ID: "this-week"}, Palette: p}); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Record(ctx, pipeline.Result{Input: pipeline.Input{ID: "failed"}, Err: errors.New("decoding image")}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		in       pipeline.Input
+		expected bool
+	}{
+		{pipeline.Input{ID: "this-week"}, true},
+		{pipeline.Input{ID: "last-week"}, false},
+		{pipeline.Input{ID: "failed"}, false},
+		{pipeline.Input{Source: "never-seen.png"}, false},
+	}
+
+	for _, test := range tests {
+		returned, err := j.Finished(ctx, test.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if returned != test.expected {
+			t.Errorf("%s expected: %v\n returned: %v\n", test.in.Key(), test.expected, returned)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+)
+
+// Creates the history table, palettes are stored as JSON by key and unix nanosecond extraction time
+const sqliteSchema = `CREATE TABLE IF NOT EXISTS palette_history (
+	key TEXT NOT NULL,
+	extracted_at INTEGER NOT NULL,
+	palette TEXT NOT NULL,
+	PRIMARY KEY (key, extracted_at)
+)`
+
+// Store in a SQLite database. The database is opened by the caller with the driver of their choice, e.g.
+// modernc.org/sqlite or github.com/mattn/go-sqlite3
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// Creates a store in db, creating its table when it does not exist. Closing the store closes db
+func NewSQLiteStore(ctx context.Context, db *sql.DB) (*SQLiteStore, error) {
+	if _, err := db.ExecContext(ctx, sqliteSchema); err != nil {
+		return nil, fmt.Errorf("creating palette_history: %w", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+func (s *SQLiteStore) Save(ctx context.Context, key string, p *palettecalculator.Palette, extractedAt time.Time) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, "INSERT OR REPLACE INTO palette_history (key, extracted_at, palette) VALUES (?, ?, ?)",
+		key, extractedAt.UnixNano(), string(data))
+	return err
+}
+
+func (s *SQLiteStore) Latest(ctx context.Context, key string) (*Record, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT extracted_at, palette FROM palette_history WHERE key = ? ORDER BY extracted_at DESC LIMIT 1", key)
+	record, err := scanRecord(key, row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (s *SQLiteStore) History(ctx context.Context, key string, since time.Time, until time.Time) ([]Record, error) {
+	start, end := int64(math.MinInt64), int64(math.MaxInt64)
+	if !since.IsZero() {
+		start = since.UnixNano()
+	}
+	if !until.IsZero() {
+		end = until.UnixNano()
+	}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT extracted_at, palette FROM palette_history WHERE key = ? AND extracted_at >= ? AND extracted_at < ? ORDER BY extracted_at",
+		key, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []Record
+	for rows.Next() {
+		record, err := scanRecord(key, rows)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, *record)
+	}
+
+	return history, rows.Err()
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// Scans the extracted_at and palette columns of a row
+func scanRecord(key string, row interface {
+	Scan(dest ...interface{}) error
+}) (*Record, error) {
+	var (
+		extractedAt int64
+		data        string
+	)
+	if err := row.Scan(&extractedAt, &data); err != nil {
+		return nil, err
+	}
+
+	return decodeRecord(key, extractedAt, []byte(data))
+}
+
+func decodeRecord(key string, extractedAt int64, data []byte) (*Record, error) {
+	p := new(palettecalculator.Palette)
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("decoding palette of %s: %w", key, err)
+	}
+
+	return &Record{Key: key, ExtractedAt: time.Unix(0, extractedAt), Palette: p}, nil
+}
@@ -0,0 +1,155 @@
+// Package store saves extracted palettes with their history, keyed by image hash or URL, so a catalog re-extracted
+// on a schedule can be diffed against earlier runs. Store is implemented in memory, on SQLite and on BoltDB.
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+)
+
+// Returned by Latest when a key has no palettes
+var ErrNotFound = errors.New("palette not found")
+
+// CIEDE2000 distance under which Diff counts two colors as the same, about the smallest difference people notice
+const DiffThreshold = 2.3
+
+// Palette extracted for a key at a point in time
+type Record struct {
+	Key         string                     `json:"key"`
+	ExtractedAt time.Time                  `json:"extracted-at"`
+	Palette     *palettecalculator.Palette `json:"palette"`
+}
+
+// History of extracted palettes by key
+type Store interface {
+	// Saves the palette extracted for key at extractedAt, replacing one saved at the same time
+	Save(ctx context.Context, key string, p *palettecalculator.Palette, extractedAt time.Time) error
+	// Returns the most recent palette of key, ErrNotFound when there is none
+	Latest(ctx context.Context, key string) (*Record, error)
+	// Returns the palettes of key extracted from since up to but excluding until, oldest first. A zero since reads
+	// from the oldest and a zero until to the latest
+	History(ctx context.Context, key string, since time.Time, until time.Time) ([]Record, error)
+	Close() error
+}
+
+// Key of an image by its contents, stable across URLs and renames
+func ImageKey(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Changes between two extractions of a palette
+type PaletteDiff struct {
+	// Colors of the newer palette without a color of the older one within DiffThreshold
+	Added []palettecalculator.Color `json:"added"`
+	// Colors of the older palette without a color of the newer one within DiffThreshold
+	Removed []palettecalculator.Color `json:"removed"`
+	// Similarity of the palettes from 0 to 1
+	Similarity float64 `json:"similarity"`
+}
+
+// Calculates what changed from the older palette to the newer one
+func Diff(older *palettecalculator.Palette, newer *palettecalculator.Palette) *PaletteDiff {
+	pc := new(palettecalculator.PaletteCalculator)
+	return &PaletteDiff{
+		Added:      unmatched(pc, newer.Colors, older.Colors),
+		Removed:    unmatched(pc, older.Colors, newer.Colors),
+		Similarity: pc.Similarity(older, newer),
+	}
+}
+
+// Colors of a without a color of b within DiffThreshold
+func unmatched(pc *palettecalculator.PaletteCalculator, a []palettecalculator.Color, b []palettecalculator.Color) []palettecalculator.Color {
+	var colors []palettecalculator.Color
+	for i := range a {
+		matched := false
+		for j := range b {
+			if pc.DistanceDeltaE(&a[i], &b[j], palettecalculator.CIEDE2000) < DiffThreshold {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			colors = append(colors, a[i])
+		}
+	}
+
+	return colors
+}
+
+// Store held in memory, e.g. for tests or a single CLI run
+type MemoryStore struct {
+	mu      sync.RWMutex
+	records map[string][]Record
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[string][]Record)}
+}
+
+func (s *MemoryStore) Save(ctx context.Context, key string, p *palettecalculator.Palette, extractedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records := s.records[key]
+	i := sort.Search(len(records), func(i int) bool { return !records[i].ExtractedAt.Before(extractedAt) })
+	record := Record{Key: key, ExtractedAt: extractedAt, Palette: copyPalette(p)}
+	if i < len(records) && records[i].ExtractedAt.Equal(extractedAt) {
+		records[i] = record
+	} else {
+		records = append(records[:i], append([]Record{record}, records[i:]...)...)
+	}
+	s.records[key] = records
+
+	return nil
+}
+
+func (s *MemoryStore) Latest(ctx context.Context, key string) (*Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records := s.records[key]
+	if len(records) == 0 {
+		return nil, ErrNotFound
+	}
+	record := records[len(records)-1]
+	record.Palette = copyPalette(record.Palette)
+
+	return &record, nil
+}
+
+func (s *MemoryStore) History(ctx context.Context, key string, since time.Time, until time.Time) ([]Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var history []Record
+	for _, record := range s.records[key] {
+		if (!since.IsZero() && record.ExtractedAt.Before(since)) || (!until.IsZero() && !record.ExtractedAt.Before(until)) {
+			continue
+		}
+		record.Palette = copyPalette(record.Palette)
+		history = append(history, record)
+	}
+
+	return history, nil
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+// Copies p so callers can't change a saved palette
+func copyPalette(p *palettecalculator.Palette) *palettecalculator.Palette {
+	c := &palettecalculator.Palette{Name: p.Name}
+	c.Colors = append(c.Colors, p.Colors...)
+	c.Weights = append(c.Weights, p.Weights...)
+
+	return c
+}
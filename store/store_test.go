@@ -0,0 +1,136 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+)
+
+var (
+	red   = palettecalculator.Color{Red: 255, Hex: "ff0000"}
+	green = palettecalculator.Color{Green: 255, Hex: "00ff00"}
+	blue  = palettecalculator.Color{Blue: 255, Hex: "0000ff"}
+	// close enough to red to count as the same color
+	nearRed = palettecalculator.Color{Red: 254, Hex: "fe00"}
+)
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+// Runs the behavior every Store shares against s
+func testStore(t *testing.T, s Store) {
+	ctx := context.Background()
+	defer s.Close()
+
+	week := 7 * 24 * time.Hour
+	first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	p1 := &palettecalculator.Palette{Colors: []palettecalculator.Color{red, green}, Weights: []float64{0.75, 0.25}}
+	p2 := &palettecalculator.Palette{Colors: []palettecalculator.Color{red, blue}, Weights: []float64{0.5, 0.5}}
+	p3 := &palettecalculator.Palette{Name: "replaced", Colors: []palettecalculator.Color{blue}}
+
+	if _, err := s.Latest(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected: %v\n returned: %v\n", ErrNotFound, err)
+	}
+	// saved out of order, the third replaces the palette saved at the same time
+	for _, save := range []struct {
+		key string
+		p   *palettecalculator.Palette
+		at  time.Time
+	}{
+		{"a", p2, first.Add(week)},
+		{"a", p1, first},
+		{"a", p1, first.Add(2 * week)},
+		{"a", p3, first.Add(2 * week)},
+		{"b", p1, first},
+	} {
+		if err := s.Save(ctx, save.key, save.p, save.at); err != nil {
+			t.Fatalf("saving %s at %v: %v", save.key, save.at, err)
+		}
+	}
+
+	latest, err := s.Latest(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !latest.ExtractedAt.Equal(first.Add(2*week)) || !reflect.DeepEqual(latest.Palette, p3) {
+		t.Errorf("expected: %v %v\n returned: %v %v\n", first.Add(2*week), p3, latest.ExtractedAt, latest.Palette)
+	}
+
+	tests := []struct {
+		name     string
+		key      string
+		since    time.Time
+		until    time.Time
+		expected []palettecalculator.Palette
+	}{
+		{"all", "a", time.Time{}, time.Time{}, []palettecalculator.Palette{*p1, *p2, *p3}},
+		{"since", "a", first.Add(week), time.Time{}, []palettecalculator.Palette{*p2, *p3}},
+		{"until excluded", "a", first, first.Add(2 * week), []palettecalculator.Palette{*p1, *p2}},
+		{"other key", "b", time.Time{}, time.Time{}, []palettecalculator.Palette{*p1}},
+		{"missing key", "missing", time.Time{}, time.Time{}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			history, err := s.History(ctx, test.key, test.since, test.until)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var returned []palettecalculator.Palette
+			for i, record := range history {
+				if record.Key != test.key {
+					t.Errorf("expected: %v\n returned: %v\n", test.key, record.Key)
+				}
+				if i > 0 && !record.ExtractedAt.After(history[i-1].ExtractedAt) {
+					t.Errorf("expected history oldest first, returned %v after %v", record.ExtractedAt, history[i-1].ExtractedAt)
+				}
+				returned = append(returned, *record.Palette)
+			}
+			if !reflect.DeepEqual(returned, test.expected) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expected, returned)
+			}
+		})
+	}
+}
+
+func TestImageKey(t *testing.T) {
+	expected := "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	if returned := ImageKey(nil); returned != expected {
+		t.Errorf("expected: %v\n returned: %v\n", expected, returned)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		older    []palettecalculator.Color
+		newer    []palettecalculator.Color
+		expected *PaletteDiff
+	}{
+		{"unchanged", []palettecalculator.Color{red, green}, []palettecalculator.Color{green, red}, &PaletteDiff{Similarity: 1}},
+		{"within threshold", []palettecalculator.Color{red}, []palettecalculator.Color{nearRed}, &PaletteDiff{}},
+		{"replaced", []palettecalculator.Color{red, green}, []palettecalculator.Color{red, blue},
+			&PaletteDiff{Added: []palettecalculator.Color{blue}, Removed: []palettecalculator.Color{green}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			older, newer := &palettecalculator.Palette{Colors: test.older}, &palettecalculator.Palette{Colors: test.newer}
+			returned := Diff(older, newer)
+			if !reflect.DeepEqual(returned.Added, test.expected.Added) || !reflect.DeepEqual(returned.Removed, test.expected.Removed) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expected, returned)
+			}
+			if test.expected.Similarity != 0 && returned.Similarity != test.expected.Similarity {
+				t.Errorf("expected: %v\n returned: %v\n", test.expected.Similarity, returned.Similarity)
+			}
+			if returned.Similarity < 0 || returned.Similarity > 1 {
+				t.Errorf("expected a similarity from 0 to 1, returned %v", returned.Similarity)
+			}
+		})
+	}
+}
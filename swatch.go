@@ -0,0 +1,164 @@
+package palettecalculator
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// How swatches are laid out in a rendered preview
+type SwatchLayout struct {
+	// Swatches per row, zero lays every swatch out in a single strip
+	Columns int
+	// Draw each swatch's hex in a contrasting color in its bottom left corner
+	Labels bool
+}
+
+// 3x5 pixel glyphs of the characters in a hex label, one row of three bits per byte, most significant bit leftmost
+var swatchGlyphs = map[rune][5]uint8{
+	'#': {0b101, 0b111, 0b101, 0b111, 0b101},
+	'0': {0b111, 0b101, 0b101, 0b101, 0b111},
+	'1': {0b010, 0b110, 0b010, 0b010, 0b111},
+	'2': {0b111, 0b001, 0b111, 0b100, 0b111},
+	'3': {0b111, 0b001, 0b111, 0b001, 0b111},
+	'4': {0b101, 0b101, 0b111, 0b001, 0b001},
+	'5': {0b111, 0b100, 0b111, 0b001, 0b111},
+	'6': {0b111, 0b100, 0b111, 0b101, 0b111},
+	'7': {0b111, 0b001, 0b010, 0b010, 0b010},
+	'8': {0b111, 0b101, 0b111, 0b101, 0b111},
+	'9': {0b111, 0b101, 0b111, 0b001, 0b111},
+	'a': {0b010, 0b101, 0b111, 0b101, 0b101},
+	'b': {0b110, 0b101, 0b110, 0b101, 0b110},
+	'c': {0b011, 0b100, 0b100, 0b100, 0b011},
+	'd': {0b110, 0b101, 0b101, 0b101, 0b110},
+	'e': {0b111, 0b100, 0b110, 0b100, 0b111},
+	'f': {0b111, 0b100, 0b110, 0b100, 0b100},
+}
+
+// Draws the palette as a width by height swatch strip or grid and writes it as a PNG, e.g. a preview attached to a
+// notification or pull request
+func (p *Palette) RenderPNG(w io.Writer, width int, height int, layout SwatchLayout) error {
+	img, err := p.renderSwatches(width, height, layout)
+	if err != nil {
+		return err
+	}
+
+	return png.Encode(w, img)
+}
+
+// Writes the palette as a width by height SVG swatch strip or grid. Each swatch is a rect with id swatch-1,
+// swatch-2, ... and class swatch, titled with its hex so it can be styled or scripted downstream
+func (p *Palette) RenderSVG(w io.Writer, width int, height int, layout SwatchLayout) error {
+	if err := p.checkRenderable(width, height); err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", width, height, width, height))
+	if p.Name != "" {
+		sb.WriteString(fmt.Sprintf("  <title>%s</title>\n", html.EscapeString(p.Name)))
+	}
+	for i, cell := range p.swatchCells(width, height, layout) {
+		c := p.Colors[i].Clamp()
+		hex := cssHex(c)
+		sb.WriteString(fmt.Sprintf(`  <rect id="swatch-%d" class="swatch" x="%d" y="%d" width="%d" height="%d" fill="%s"><title>%s</title></rect>`+"\n",
+			i+1, cell.Min.X, cell.Min.Y, cell.Dx(), cell.Dy(), hex, hex))
+
+		if layout.Labels {
+			ink := "#000000"
+			if c.IsDark() {
+				ink = "#ffffff"
+			}
+			size := minInt(cell.Dx()/5, cell.Dy()/4)
+			sb.WriteString(fmt.Sprintf(`  <text class="swatch-label" x="%d" y="%d" font-family="monospace" font-size="%d" fill="%s">%s</text>`+"\n",
+				cell.Min.X+size/2, cell.Max.Y-size/2, size, ink, hex))
+		}
+	}
+	sb.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func (p *Palette) renderSwatches(width int, height int, layout SwatchLayout) (*image.RGBA, error) {
+	if err := p.checkRenderable(width, height); err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for i, cell := range p.swatchCells(width, height, layout) {
+		c := p.Colors[i].Clamp()
+		fill := color.RGBA{R: uint8(c.Red), G: uint8(c.Green), B: uint8(c.Blue), A: 255}
+		draw.Draw(img, cell, &image.Uniform{C: fill}, image.Point{}, draw.Src)
+
+		if layout.Labels {
+			drawSwatchLabel(img, cell, cssHex(c), c.IsDark())
+		}
+	}
+
+	return img, nil
+}
+
+func (p *Palette) checkRenderable(width int, height int) error {
+	if len(p.Colors) == 0 {
+		return errors.New("palette has no colors")
+	}
+	if width <= 0 || height <= 0 {
+		return errors.New("width and height must be positive")
+	}
+
+	return nil
+}
+
+// Bounds of each color's swatch, cells split the remaining pixels evenly so the grid fills width by height
+func (p *Palette) swatchCells(width int, height int, layout SwatchLayout) []image.Rectangle {
+	columns := layout.Columns
+	if columns <= 0 || columns > len(p.Colors) {
+		columns = len(p.Colors)
+	}
+	rows := (len(p.Colors) + columns - 1) / columns
+
+	var cells []image.Rectangle
+	for i := range p.Colors {
+		column, row := i%columns, i/columns
+		cells = append(cells, image.Rect(column*width/columns, row*height/rows, (column+1)*width/columns, (row+1)*height/rows))
+	}
+
+	return cells
+}
+
+// Draws label in the bottom left of cell, white on dark swatches and black on light ones. Glyphs are scaled to the
+// cell and the label is skipped when the cell is too small to hold it
+func drawSwatchLabel(img *image.RGBA, cell image.Rectangle, label string, dark bool) {
+	ink := color.RGBA{A: 255}
+	if dark {
+		ink = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	}
+
+	// each glyph is 3 pixels wide plus a pixel of spacing, with a glyph's width of margin around the label
+	scale := minInt(cell.Dx()/(4*len(label)+5), cell.Dy()/8)
+	if scale < 1 {
+		return
+	}
+	margin := 3 * scale
+	origin := image.Pt(cell.Min.X+margin, cell.Max.Y-margin-5*scale)
+
+	for i, r := range strings.ToLower(label) {
+		glyph := swatchGlyphs[r]
+		for y, bits := range glyph {
+			for x := 0; x < 3; x++ {
+				if bits&(0b100>>x) == 0 {
+					continue
+				}
+				pixel := image.Rect(0, 0, scale, scale).Add(origin.Add(image.Pt((4*i+x)*scale, y*scale)))
+				draw.Draw(img, pixel, &image.Uniform{C: ink}, image.Point{}, draw.Src)
+			}
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package palettecalculator
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"reflect"
+	"testing"
+)
+
+func TestPaletteRenderPNG(t *testing.T) {
+	palette := &Palette{Colors: []Color{{24, 98, 119, "186277"}, {255, 255, 255, "ffffff"}, {119, 45, 24, "772d18"}}}
+	var buf bytes.Buffer
+
+	err := palette.RenderPNG(&buf, 30, 10, SwatchLayout{})
+
+	if err != nil {
+		t.Fatalf("expected error: %v returned error: %v", nil, err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("expected error: %v returned error: %v", nil, err)
+	}
+	expectedBounds := image.Rect(0, 0, 30, 10)
+	if !reflect.DeepEqual(expectedBounds, img.Bounds()) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedBounds, img.Bounds())
+	}
+	for x, expected := range map[int]color.RGBA{0: {24, 98, 119, 255}, 15: {255, 255, 255, 255}, 29: {119, 45, 24, 255}} {
+		returned := color.RGBAModel.Convert(img.At(x, 5))
+		if !reflect.DeepEqual(expected, returned) {
+			t.Errorf("expected pixel %d: %v\n returned: %v\n", x, expected, returned)
+		}
+	}
+}
+
+func TestPaletteRenderSwatches(t *testing.T) {
+	navy := color.RGBA{24, 98, 119, 255}
+	white := color.RGBA{255, 255, 255, 255}
+	tests := []struct {
+		name     string
+		width    int
+		height   int
+		layout   SwatchLayout
+		expected map[image.Point]color.RGBA
+	}{
+		{
+			name: "should lay out grid rows", width: 10, height: 20, layout: SwatchLayout{Columns: 1},
+			expected: map[image.Point]color.RGBA{{5, 5}: navy, {5, 15}: white},
+		},
+		{
+			name: "should draw contrasting label", width: 100, height: 20, layout: SwatchLayout{Columns: 1, Labels: true},
+			expected: map[image.Point]color.RGBA{{3, 2}: white, {4, 2}: navy, {3, 12}: {0, 0, 0, 255}, {50, 15}: white},
+		},
+		{
+			name: "should skip label on small swatch", width: 10, height: 20, layout: SwatchLayout{Columns: 1, Labels: true},
+			expected: map[image.Point]color.RGBA{{3, 6}: navy},
+		},
+	}
+	palette := &Palette{Colors: []Color{{24, 98, 119, "186277"}, {255, 255, 255, "ffffff"}}}
+
+	for _, test := range tests {
+		img, err := palette.renderSwatches(test.width, test.height, test.layout)
+		if err != nil {
+			t.Fatalf("%s expected error: %v returned error: %v", test.name, nil, err)
+		}
+
+		for point, expected := range test.expected {
+			if returned := img.RGBAAt(point.X, point.Y); !reflect.DeepEqual(expected, returned) {
+				t.Errorf("%s expected pixel %v: %v\n returned: %v\n", test.name, point, expected, returned)
+			}
+		}
+	}
+}
+
+func TestPaletteRenderPNGWithInvalidInput(t *testing.T) {
+	tests := []struct {
+		palette     *Palette
+		width       int
+		expectedErr error
+	}{
+		{&Palette{}, 10, errors.New("palette has no colors")},
+		{&Palette{Colors: []Color{{24, 98, 119, "186277"}}}, 0, errors.New("width and height must be positive")},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+
+		err := test.palette.RenderPNG(&buf, test.width, 10, SwatchLayout{})
+
+		if !reflect.DeepEqual(test.expectedErr, err) {
+			t.Errorf("expected error: %v returned error: %v", test.expectedErr, err)
+		}
+	}
+}
+
+func TestPaletteRenderSVG(t *testing.T) {
+	palette := &Palette{Name: "photo & co", Colors: []Color{{24, 98, 119, "186277"}, {255, 255, 255, "ffffff"}}}
+	tests := []struct {
+		layout   SwatchLayout
+		expected string
+	}{
+		{
+			SwatchLayout{},
+			`<svg xmlns="http://www.w3.org/2000/svg" width="200" height="100" viewBox="0 0 200 100">` + "\n" +
+				"  <title>photo &amp; co</title>\n" +
+				`  <rect id="swatch-1" class="swatch" x="0" y="0" width="100" height="100" fill="#186277"><title>#186277</title></rect>` + "\n" +
+				`  <rect id="swatch-2" class="swatch" x="100" y="0" width="100" height="100" fill="#ffffff"><title>#ffffff</title></rect>` + "\n" +
+				"</svg>\n",
+		},
+		{
+			SwatchLayout{Columns: 1, Labels: true},
+			`<svg xmlns="http://www.w3.org/2000/svg" width="200" height="100" viewBox="0 0 200 100">` + "\n" +
+				"  <title>photo &amp; co</title>\n" +
+				`  <rect id="swatch-1" class="swatch" x="0" y="0" width="200" height="50" fill="#186277"><title>#186277</title></rect>` + "\n" +
+				`  <text class="swatch-label" x="6" y="44" font-family="monospace" font-size="12" fill="#ffffff">#186277</text>` + "\n" +
+				`  <rect id="swatch-2" class="swatch" x="0" y="50" width="200" height="50" fill="#ffffff"><title>#ffffff</title></rect>` + "\n" +
+				`  <text class="swatch-label" x="6" y="94" font-family="monospace" font-size="12" fill="#000000">#ffffff</text>` + "\n" +
+				"</svg>\n",
+		},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+
+		err := palette.RenderSVG(&buf, 200, 100, test.layout)
+
+		if err != nil {
+			t.Errorf("expected error: %v returned error: %v", nil, err)
+		}
+		if test.expected != buf.String() {
+			t.Errorf("expected: %v\n returned: %v\n", test.expected, buf.String())
+		}
+	}
+}
+
+func TestPaletteRenderSVGWithNoColors(t *testing.T) {
+	expectedErr := errors.New("palette has no colors")
+	var buf bytes.Buffer
+
+	err := new(Palette).RenderSVG(&buf, 200, 100, SwatchLayout{})
+
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Errorf("expected error: %v returned error: %v", expectedErr, err)
+	}
+}
@@ -0,0 +1,505 @@
+package palettecalculator
+
+// Material Design 2014 color palette, named hue-shade, e.g. "deep-purple-500" or "red-a200"
+var MaterialColors = []NamedColor{
+	{"red-50", Color{255, 235, 238, "ffebee"}},
+	{"red-100", Color{255, 205, 210, "ffcdd2"}},
+	{"red-200", Color{239, 154, 154, "ef9a9a"}},
+	{"red-300", Color{229, 115, 115, "e57373"}},
+	{"red-400", Color{239, 83, 80, "ef5350"}},
+	{"red-500", Color{244, 67, 54, "f44336"}},
+	{"red-600", Color{229, 57, 53, "e53935"}},
+	{"red-700", Color{211, 47, 47, "d32f2f"}},
+	{"red-800", Color{198, 40, 40, "c62828"}},
+	{"red-900", Color{183, 28, 28, "b71c1c"}},
+	{"red-a100", Color{255, 138, 128, "ff8a80"}},
+	{"red-a200", Color{255, 82, 82, "ff5252"}},
+	{"red-a400", Color{255, 23, 68, "ff1744"}},
+	{"red-a700", Color{213, 0, 0, "d50000"}},
+	{"pink-50", Color{252, 228, 236, "fce4ec"}},
+	{"pink-100", Color{248, 187, 208, "f8bbd0"}},
+	{"pink-200", Color{244, 143, 177, "f48fb1"}},
+	{"pink-300", Color{240, 98, 146, "f06292"}},
+	{"pink-400", Color{236, 64, 122, "ec407a"}},
+	{"pink-500", Color{233, 30, 99, "e91e63"}},
+	{"pink-600", Color{216, 27, 96, "d81b60"}},
+	{"pink-700", Color{194, 24, 91, "c2185b"}},
+	{"pink-800", Color{173, 20, 87, "ad1457"}},
+	{"pink-900", Color{136, 14, 79, "880e4f"}},
+	{"pink-a100", Color{255, 128, 171, "ff80ab"}},
+	{"pink-a200", Color{255, 64, 129, "ff4081"}},
+	{"pink-a400", Color{245, 0, 87, "f50057"}},
+	{"pink-a700", Color{197, 17, 98, "c51162"}},
+	{"purple-50", Color{243, 229, 245, "f3e5f5"}},
+	{"purple-100", Color{225, 190, 231, "e1bee7"}},
+	{"purple-200", Color{206, 147, 216, "ce93d8"}},
+	{"purple-300", Color{186, 104, 200, "ba68c8"}},
+	{"purple-400", Color{171, 71, 188, "ab47bc"}},
+	{"purple-500", Color{156, 39, 176, "9c27b0"}},
+	{"purple-600", Color{142, 36, 170, "8e24aa"}},
+	{"purple-700", Color{123, 31, 162, "7b1fa2"}},
+	{"purple-800", Color{106, 27, 154, "6a1b9a"}},
+	{"purple-900", Color{74, 20, 140, "4a148c"}},
+	{"purple-a100", Color{234, 128, 252, "ea80fc"}},
+	{"purple-a200", Color{224, 64, 251, "e040fb"}},
+	{"purple-a400", Color{213, 0, 249, "d500f9"}},
+	{"purple-a700", Color{170, 0, 255, "aa00ff"}},
+	{"deep-purple-50", Color{237, 231, 246, "ede7f6"}},
+	{"deep-purple-100", Color{209, 196, 233, "d1c4e9"}},
+	{"deep-purple-200", Color{179, 157, 219, "b39ddb"}},
+	{"deep-purple-300", Color{149, 117, 205, "9575cd"}},
+	{"deep-purple-400", Color{126, 87, 194, "7e57c2"}},
+	{"deep-purple-500", Color{103, 58, 183, "673ab7"}},
+	{"deep-purple-600", Color{94, 53, 177, "5e35b1"}},
+	{"deep-purple-700", Color{81, 45, 168, "512da8"}},
+	{"deep-purple-800", Color{69, 39, 160, "4527a0"}},
+	{"deep-purple-900", Color{49, 27, 146, "311b92"}},
+	{"deep-purple-a100", Color{179, 136, 255, "b388ff"}},
+	{"deep-purple-a200", Color{124, 77, 255, "7c4dff"}},
+	{"deep-purple-a400", Color{101, 31, 255, "651fff"}},
+	{"deep-purple-a700", Color{98, 0, 234, "6200ea"}},
+	{"indigo-50", Color{232, 234, 246, "e8eaf6"}},
+	{"indigo-100", Color{197, 202, 233, "c5cae9"}},
+	{"indigo-200", Color{159, 168, 218, "9fa8da"}},
+	{"indigo-300", Color{121, 134, 203, "7986cb"}},
+	{"indigo-400", Color{92, 107, 192, "5c6bc0"}},
+	{"indigo-500", Color{63, 81, 181, "3f51b5"}},
+	{"indigo-600", Color{57, 73, 171, "3949ab"}},
+	{"indigo-700", Color{48, 63, 159, "303f9f"}},
+	{"indigo-800", Color{40, 53, 147, "283593"}},
+	{"indigo-900", Color{26, 35, 126, "1a237e"}},
+	{"indigo-a100", Color{140, 158, 255, "8c9eff"}},
+	{"indigo-a200", Color{83, 109, 254, "536dfe"}},
+	{"indigo-a400", Color{61, 90, 254, "3d5afe"}},
+	{"indigo-a700", Color{48, 79, 254, "304ffe"}},
+	{"blue-50", Color{227, 242, 253, "e3f2fd"}},
+	{"blue-100", Color{187, 222, 251, "bbdefb"}},
+	{"blue-200", Color{144, 202, 249, "90caf9"}},
+	{"blue-300", Color{100, 181, 246, "64b5f6"}},
+	{"blue-400", Color{66, 165, 245, "42a5f5"}},
+	{"blue-500", Color{33, 150, 243, "2196f3"}},
+	{"blue-600", Color{30, 136, 229, "1e88e5"}},
+	{"blue-700", Color{25, 118, 210, "1976d2"}},
+	{"blue-800", Color{21, 101, 192, "1565c0"}},
+	{"blue-900", Color{13, 71, 161, "0d47a1"}},
+	{"blue-a100", Color{130, 177, 255, "82b1ff"}},
+	{"blue-a200", Color{68, 138, 255, "448aff"}},
+	{"blue-a400", Color{41, 121, 255, "2979ff"}},
+	{"blue-a700", Color{41, 98, 255, "2962ff"}},
+	{"light-blue-50", Color{225, 245, 254, "e1f5fe"}},
+	{"light-blue-100", Color{179, 229, 252, "b3e5fc"}},
+	{"light-blue-200", Color{129, 212, 250, "81d4fa"}},
+	{"light-blue-300", Color{79, 195, 247, "4fc3f7"}},
+	{"light-blue-400", Color{41, 182, 246, "29b6f6"}},
+	{"light-blue-500", Color{3, 169, 244, "03a9f4"}},
+	{"light-blue-600", Color{3, 155, 229, "039be5"}},
+	{"light-blue-700", Color{2, 136, 209, "0288d1"}},
+	{"light-blue-800", Color{2, 119, 189, "0277bd"}},
+	{"light-blue-900", Color{1, 87, 155, "01579b"}},
+	{"light-blue-a100", Color{128, 216, 255, "80d8ff"}},
+	{"light-blue-a200", Color{64, 196, 255, "40c4ff"}},
+	{"light-blue-a400", Color{0, 176, 255, "00b0ff"}},
+	{"light-blue-a700", Color{0, 145, 234, "0091ea"}},
+	{"cyan-50", Color{224, 247, 250, "e0f7fa"}},
+	{"cyan-100", Color{178, 235, 242, "b2ebf2"}},
+	{"cyan-200", Color{128, 222, 234, "80deea"}},
+	{"cyan-300", Color{77, 208, 225, "4dd0e1"}},
+	{"cyan-400", Color{38, 198, 218, "26c6da"}},
+	{"cyan-500", Color{0, 188, 212, "00bcd4"}},
+	{"cyan-600", Color{0, 172, 193, "00acc1"}},
+	{"cyan-700", Color{0, 151, 167, "0097a7"}},
+	{"cyan-800", Color{0, 131, 143, "00838f"}},
+	{"cyan-900", Color{0, 96, 100, "006064"}},
+	{"cyan-a100", Color{132, 255, 255, "84ffff"}},
+	{"cyan-a200", Color{24, 255, 255, "18ffff"}},
+	{"cyan-a400", Color{0, 229, 255, "00e5ff"}},
+	{"cyan-a700", Color{0, 184, 212, "00b8d4"}},
+	{"teal-50", Color{224, 242, 241, "e0f2f1"}},
+	{"teal-100", Color{178, 223, 219, "b2dfdb"}},
+	{"teal-200", Color{128, 203, 196, "80cbc4"}},
+	{"teal-300", Color{77, 182, 172, "4db6ac"}},
+	{"teal-400", Color{38, 166, 154, "26a69a"}},
+	{"teal-500", Color{0, 150, 136, "009688"}},
+	{"teal-600", Color{0, 137, 123, "00897b"}},
+	{"teal-700", Color{0, 121, 107, "00796b"}},
+	{"teal-800", Color{0, 105, 92, "00695c"}},
+	{"teal-900", Color{0, 77, 64, "004d40"}},
+	{"teal-a100", Color{167, 255, 235, "a7ffeb"}},
+	{"teal-a200", Color{100, 255, 218, "64ffda"}},
+	{"teal-a400", Color{29, 233, 182, "1de9b6"}},
+	{"teal-a700", Color{0, 191, 165, "00bfa5"}},
+	{"green-50", Color{232, 245, 233, "e8f5e9"}},
+	{"green-100", Color{200, 230, 201, "c8e6c9"}},
+	{"green-200", Color{165, 214, 167, "a5d6a7"}},
+	{"green-300", Color{129, 199, 132, "81c784"}},
+	{"green-400", Color{102, 187, 106, "66bb6a"}},
+	{"green-500", Color{76, 175, 80, "4caf50"}},
+	{"green-600", Color{67, 160, 71, "43a047"}},
+	{"green-700", Color{56, 142, 60, "388e3c"}},
+	{"green-800", Color{46, 125, 50, "2e7d32"}},
+	{"green-900", Color{27, 94, 32, "1b5e20"}},
+	{"green-a100", Color{185, 246, 202, "b9f6ca"}},
+	{"green-a200", Color{105, 240, 174, "69f0ae"}},
+	{"green-a400", Color{0, 230, 118, "00e676"}},
+	{"green-a700", Color{0, 200, 83, "00c853"}},
+	{"light-green-50", Color{241, 248, 233, "f1f8e9"}},
+	{"light-green-100", Color{220, 237, 200, "dcedc8"}},
+	{"light-green-200", Color{197, 225, 165, "c5e1a5"}},
+	{"light-green-300", Color{174, 213, 129, "aed581"}},
+	{"light-green-400", Color{156, 204, 101, "9ccc65"}},
+	{"light-green-500", Color{139, 195, 74, "8bc34a"}},
+	{"light-green-600", Color{124, 179, 66, "7cb342"}},
+	{"light-green-700", Color{104, 159, 56, "689f38"}},
+	{"light-green-800", Color{85, 139, 47, "558b2f"}},
+	{"light-green-900", Color{51, 105, 30, "33691e"}},
+	{"light-green-a100", Color{204, 255, 144, "ccff90"}},
+	{"light-green-a200", Color{178, 255, 89, "b2ff59"}},
+	{"light-green-a400", Color{118, 255, 3, "76ff03"}},
+	{"light-green-a700", Color{100, 221, 23, "64dd17"}},
+	{"lime-50", Color{249, 251, 231, "f9fbe7"}},
+	{"lime-100", Color{240, 244, 195, "f0f4c3"}},
+	{"lime-200", Color{230, 238, 156, "e6ee9c"}},
+	{"lime-300", Color{220, 231, 117, "dce775"}},
+	{"lime-400", Color{212, 225, 87, "d4e157"}},
+	{"lime-500", Color{205, 220, 57, "cddc39"}},
+	{"lime-600", Color{192, 202, 51, "c0ca33"}},
+	{"lime-700", Color{175, 180, 43, "afb42b"}},
+	{"lime-800", Color{158, 157, 36, "9e9d24"}},
+	{"lime-900", Color{130, 119, 23, "827717"}},
+	{"lime-a100", Color{244, 255, 129, "f4ff81"}},
+	{"lime-a200", Color{238, 255, 65, "eeff41"}},
+	{"lime-a400", Color{198, 255, 0, "c6ff00"}},
+	{"lime-a700", Color{174, 234, 0, "aeea00"}},
+	{"yellow-50", Color{255, 253, 231, "fffde7"}},
+	{"yellow-100", Color{255, 249, 196, "fff9c4"}},
+	{"yellow-200", Color{255, 245, 157, "fff59d"}},
+	{"yellow-300", Color{255, 241, 118, "fff176"}},
+	{"yellow-400", Color{255, 238, 88, "ffee58"}},
+	{"yellow-500", Color{255, 235, 59, "ffeb3b"}},
+	{"yellow-600", Color{253, 216, 53, "fdd835"}},
+	{"yellow-700", Color{251, 192, 45, "fbc02d"}},
+	{"yellow-800", Color{249, 168, 37, "f9a825"}},
+	{"yellow-900", Color{245, 127, 23, "f57f17"}},
+	{"yellow-a100", Color{255, 255, 141, "ffff8d"}},
+	{"yellow-a200", Color{255, 255, 0, "ffff00"}},
+	{"yellow-a400", Color{255, 234, 0, "ffea00"}},
+	{"yellow-a700", Color{255, 214, 0, "ffd600"}},
+	{"amber-50", Color{255, 248, 225, "fff8e1"}},
+	{"amber-100", Color{255, 236, 179, "ffecb3"}},
+	{"amber-200", Color{255, 224, 130, "ffe082"}},
+	{"amber-300", Color{255, 213, 79, "ffd54f"}},
+	{"amber-400", Color{255, 202, 40, "ffca28"}},
+	{"amber-500", Color{255, 193, 7, "ffc107"}},
+	{"amber-600", Color{255, 179, 0, "ffb300"}},
+	{"amber-700", Color{255, 160, 0, "ffa000"}},
+	{"amber-800", Color{255, 143, 0, "ff8f00"}},
+	{"amber-900", Color{255, 111, 0, "ff6f00"}},
+	{"amber-a100", Color{255, 229, 127, "ffe57f"}},
+	{"amber-a200", Color{255, 215, 64, "ffd740"}},
+	{"amber-a400", Color{255, 196, 0, "ffc400"}},
+	{"amber-a700", Color{255, 171, 0, "ffab00"}},
+	{"orange-50", Color{255, 243, 224, "fff3e0"}},
+	{"orange-100", Color{255, 224, 178, "ffe0b2"}},
+	{"orange-200", Color{255, 204, 128, "ffcc80"}},
+	{"orange-300", Color{255, 183, 77, "ffb74d"}},
+	{"orange-400", Color{255, 167, 38, "ffa726"}},
+	{"orange-500", Color{255, 152, 0, "ff9800"}},
+	{"orange-600", Color{251, 140, 0, "fb8c00"}},
+	{"orange-700", Color{245, 124, 0, "f57c00"}},
+	{"orange-800", Color{239, 108, 0, "ef6c00"}},
+	{"orange-900", Color{230, 81, 0, "e65100"}},
+	{"orange-a100", Color{255, 209, 128, "ffd180"}},
+	{"orange-a200", Color{255, 171, 64, "ffab40"}},
+	{"orange-a400", Color{255, 145, 0, "ff9100"}},
+	{"orange-a700", Color{255, 109, 0, "ff6d00"}},
+	{"deep-orange-50", Color{251, 233, 231, "fbe9e7"}},
+	{"deep-orange-100", Color{255, 204, 188, "ffccbc"}},
+	{"deep-orange-200", Color{255, 171, 145, "ffab91"}},
+	{"deep-orange-300", Color{255, 138, 101, "ff8a65"}},
+	{"deep-orange-400", Color{255, 112, 67, "ff7043"}},
+	{"deep-orange-500", Color{255, 87, 34, "ff5722"}},
+	{"deep-orange-600", Color{244, 81, 30, "f4511e"}},
+	{"deep-orange-700", Color{230, 74, 25, "e64a19"}},
+	{"deep-orange-800", Color{216, 67, 21, "d84315"}},
+	{"deep-orange-900", Color{191, 54, 12, "bf360c"}},
+	{"deep-orange-a100", Color{255, 158, 128, "ff9e80"}},
+	{"deep-orange-a200", Color{255, 110, 64, "ff6e40"}},
+	{"deep-orange-a400", Color{255, 61, 0, "ff3d00"}},
+	{"deep-orange-a700", Color{221, 44, 0, "dd2c00"}},
+	{"brown-50", Color{239, 235, 233, "efebe9"}},
+	{"brown-100", Color{215, 204, 200, "d7ccc8"}},
+	{"brown-200", Color{188, 170, 164, "bcaaa4"}},
+	{"brown-300", Color{161, 136, 127, "a1887f"}},
+	{"brown-400", Color{141, 110, 99, "8d6e63"}},
+	{"brown-500", Color{121, 85, 72, "795548"}},
+	{"brown-600", Color{109, 76, 65, "6d4c41"}},
+	{"brown-700", Color{93, 64, 55, "5d4037"}},
+	{"brown-800", Color{78, 52, 46, "4e342e"}},
+	{"brown-900", Color{62, 39, 35, "3e2723"}},
+	{"grey-50", Color{250, 250, 250, "fafafa"}},
+	{"grey-100", Color{245, 245, 245, "f5f5f5"}},
+	{"grey-200", Color{238, 238, 238, "eeeeee"}},
+	{"grey-300", Color{224, 224, 224, "e0e0e0"}},
+	{"grey-400", Color{189, 189, 189, "bdbdbd"}},
+	{"grey-500", Color{158, 158, 158, "9e9e9e"}},
+	{"grey-600", Color{117, 117, 117, "757575"}},
+	{"grey-700", Color{97, 97, 97, "616161"}},
+	{"grey-800", Color{66, 66, 66, "424242"}},
+	{"grey-900", Color{33, 33, 33, "212121"}},
+	{"blue-grey-50", Color{236, 239, 241, "eceff1"}},
+	{"blue-grey-100", Color{207, 216, 220, "cfd8dc"}},
+	{"blue-grey-200", Color{176, 190, 197, "b0bec5"}},
+	{"blue-grey-300", Color{144, 164, 174, "90a4ae"}},
+	{"blue-grey-400", Color{120, 144, 156, "78909c"}},
+	{"blue-grey-500", Color{96, 125, 139, "607d8b"}},
+	{"blue-grey-600", Color{84, 110, 122, "546e7a"}},
+	{"blue-grey-700", Color{69, 90, 100, "455a64"}},
+	{"blue-grey-800", Color{55, 71, 79, "37474f"}},
+	{"blue-grey-900", Color{38, 50, 56, "263238"}},
+}
+
+// Tailwind CSS v3 default color palette, named hue-shade, e.g. "slate-950"
+var TailwindColors = []NamedColor{
+	{"slate-50", Color{248, 250, 252, "f8fafc"}},
+	{"slate-100", Color{241, 245, 249, "f1f5f9"}},
+	{"slate-200", Color{226, 232, 240, "e2e8f0"}},
+	{"slate-300", Color{203, 213, 225, "cbd5e1"}},
+	{"slate-400", Color{148, 163, 184, "94a3b8"}},
+	{"slate-500", Color{100, 116, 139, "64748b"}},
+	{"slate-600", Color{71, 85, 105, "475569"}},
+	{"slate-700", Color{51, 65, 85, "334155"}},
+	{"slate-800", Color{30, 41, 59, "1e293b"}},
+	{"slate-900", Color{15, 23, 42, "0f172a"}},
+	{"slate-950", Color{2, 6, 23, "020617"}},
+	{"gray-50", Color{249, 250, 251, "f9fafb"}},
+	{"gray-100", Color{243, 244, 246, "f3f4f6"}},
+	{"gray-200", Color{229, 231, 235, "e5e7eb"}},
+	{"gray-300", Color{209, 213, 219, "d1d5db"}},
+	{"gray-400", Color{156, 163, 175, "9ca3af"}},
+	{"gray-500", Color{107, 114, 128, "6b7280"}},
+	{"gray-600", Color{75, 85, 99, "4b5563"}},
+	{"gray-700", Color{55, 65, 81, "374151"}},
+	{"gray-800", Color{31, 41, 55, "1f2937"}},
+	{"gray-900", Color{17, 24, 39, "111827"}},
+	{"gray-950", Color{3, 7, 18, "030712"}},
+	{"zinc-50", Color{250, 250, 250, "fafafa"}},
+	{"zinc-100", Color{244, 244, 245, "f4f4f5"}},
+	{"zinc-200", Color{228, 228, 231, "e4e4e7"}},
+	{"zinc-300", Color{212, 212, 216, "d4d4d8"}},
+	{"zinc-400", Color{161, 161, 170, "a1a1aa"}},
+	{"zinc-500", Color{113, 113, 122, "71717a"}},
+	{"zinc-600", Color{82, 82, 91, "52525b"}},
+	{"zinc-700", Color{63, 63, 70, "3f3f46"}},
+	{"zinc-800", Color{39, 39, 42, "27272a"}},
+	{"zinc-900", Color{24, 24, 27, "18181b"}},
+	{"zinc-950", Color{9, 9, 11, "09090b"}},
+	{"neutral-50", Color{250, 250, 250, "fafafa"}},
+	{"neutral-100", Color{245, 245, 245, "f5f5f5"}},
+	{"neutral-200", Color{229, 229, 229, "e5e5e5"}},
+	{"neutral-300", Color{212, 212, 212, "d4d4d4"}},
+	{"neutral-400", Color{163, 163, 163, "a3a3a3"}},
+	{"neutral-500", Color{115, 115, 115, "737373"}},
+	{"neutral-600", Color{82, 82, 82, "525252"}},
+	{"neutral-700", Color{64, 64, 64, "404040"}},
+	{"neutral-800", Color{38, 38, 38, "262626"}},
+	{"neutral-900", Color{23, 23, 23, "171717"}},
+	{"neutral-950", Color{10, 10, 10, "0a0a0a"}},
+	{"stone-50", Color{250, 250, 249, "fafaf9"}},
+	{"stone-100", Color{245, 245, 244, "f5f5f4"}},
+	{"stone-200", Color{231, 229, 228, "e7e5e4"}},
+	{"stone-300", Color{214, 211, 209, "d6d3d1"}},
+	{"stone-400", Color{168, 162, 158, "a8a29e"}},
+	{"stone-500", Color{120, 113, 108, "78716c"}},
+	{"stone-600", Color{87, 83, 78, "57534e"}},
+	{"stone-700", Color{68, 64, 60, "44403c"}},
+	{"stone-800", Color{41, 37, 36, "292524"}},
+	{"stone-900", Color{28, 25, 23, "1c1917"}},
+	{"stone-950", Color{12, 10, 9, "0c0a09"}},
+	{"red-50", Color{254, 242, 242, "fef2f2"}},
+	{"red-100", Color{254, 226, 226, "fee2e2"}},
+	{"red-200", Color{254, 202, 202, "fecaca"}},
+	{"red-300", Color{252, 165, 165, "fca5a5"}},
+	{"red-400", Color{248, 113, 113, "f87171"}},
+	{"red-500", Color{239, 68, 68, "ef4444"}},
+	{"red-600", Color{220, 38, 38, "dc2626"}},
+	{"red-700", Color{185, 28, 28, "b91c1c"}},
+	{"red-800", Color{153, 27, 27, "991b1b"}},
+	{"red-900", Color{127, 29, 29, "7f1d1d"}},
+	{"red-950", Color{69, 10, 10, "450a0a"}},
+	{"orange-50", Color{255, 247, 237, "fff7ed"}},
+	{"orange-100", Color{255, 237, 213, "ffedd5"}},
+	{"orange-200", Color{254, 215, 170, "fed7aa"}},
+	{"orange-300", Color{253, 186, 116, "fdba74"}},
+	{"orange-400", Color{251, 146, 60, "fb923c"}},
+	{"orange-500", Color{249, 115, 22, "f97316"}},
+	{"orange-600", Color{234, 88, 12, "ea580c"}},
+	{"orange-700", Color{194, 65, 12, "c2410c"}},
+	{"orange-800", Color{154, 52, 18, "9a3412"}},
+	{"orange-900", Color{124, 45, 18, "7c2d12"}},
+	{"orange-950", Color{67, 20, 7, "431407"}},
+	{"amber-50", Color{255, 251, 235, "fffbeb"}},
+	{"amber-100", Color{254, 243, 199, "fef3c7"}},
+	{"amber-200", Color{253, 230, 138, "fde68a"}},
+	{"amber-300", Color{252, 211, 77, "fcd34d"}},
+	{"amber-400", Color{251, 191, 36, "fbbf24"}},
+	{"amber-500", Color{245, 158, 11, "f59e0b"}},
+	{"amber-600", Color{217, 119, 6, "d97706"}},
+	{"amber-700", Color{180, 83, 9, "b45309"}},
+	{"amber-800", Color{146, 64, 14, "92400e"}},
+	{"amber-900", Color{120, 53, 15, "78350f"}},
+	{"amber-950", Color{69, 26, 3, "451a03"}},
+	{"yellow-50", Color{254, 252, 232, "fefce8"}},
+	{"yellow-100", Color{254, 249, 195, "fef9c3"}},
+	{"yellow-200", Color{254, 240, 138, "fef08a"}},
+	{"yellow-300", Color{253, 224, 71, "fde047"}},
+	{"yellow-400", Color{250, 204, 21, "facc15"}},
+	{"yellow-500", Color{234, 179, 8, "eab308"}},
+	{"yellow-600", Color{202, 138, 4, "ca8a04"}},
+	{"yellow-700", Color{161, 98, 7, "a16207"}},
+	{"yellow-800", Color{133, 77, 14, "854d0e"}},
+	{"yellow-900", Color{113, 63, 18, "713f12"}},
+	{"yellow-950", Color{66, 32, 6, "422006"}},
+	{"lime-50", Color{247, 254, 231, "f7fee7"}},
+	{"lime-100", Color{236, 252, 203, "ecfccb"}},
+	{"lime-200", Color{217, 249, 157, "d9f99d"}},
+	{"lime-300", Color{190, 242, 100, "bef264"}},
+	{"lime-400", Color{163, 230, 53, "a3e635"}},
+	{"lime-500", Color{132, 204, 22, "84cc16"}},
+	{"lime-600", Color{101, 163, 13, "65a30d"}},
+	{"lime-700", Color{77, 124, 15, "4d7c0f"}},
+	{"lime-800", Color{63, 98, 18, "3f6212"}},
+	{"lime-900", Color{54, 83, 20, "365314"}},
+	{"lime-950", Color{26, 46, 5, "1a2e05"}},
+	{"green-50", Color{240, 253, 244, "f0fdf4"}},
+	{"green-100", Color{220, 252, 231, "dcfce7"}},
+	{"green-200", Color{187, 247, 208, "bbf7d0"}},
+	{"green-300", Color{134, 239, 172, "86efac"}},
+	{"green-400", Color{74, 222, 128, "4ade80"}},
+	{"green-500", Color{34, 197, 94, "22c55e"}},
+	{"green-600", Color{22, 163, 74, "16a34a"}},
+	{"green-700", Color{21, 128, 61, "15803d"}},
+	{"green-800", Color{22, 101, 52, "166534"}},
+	{"green-900", Color{20, 83, 45, "14532d"}},
+	{"green-950", Color{5, 46, 22, "052e16"}},
+	{"emerald-50", Color{236, 253, 245, "ecfdf5"}},
+	{"emerald-100", Color{209, 250, 229, "d1fae5"}},
+	{"emerald-200", Color{167, 243, 208, "a7f3d0"}},
+	{"emerald-300", Color{110, 231, 183, "6ee7b7"}},
+	{"emerald-400", Color{52, 211, 153, "34d399"}},
+	{"emerald-500", Color{16, 185, 129, "10b981"}},
+	{"emerald-600", Color{5, 150, 105, "059669"}},
+	{"emerald-700", Color{4, 120, 87, "047857"}},
+	{"emerald-800", Color{6, 95, 70, "065f46"}},
+	{"emerald-900", Color{6, 78, 59, "064e3b"}},
+	{"emerald-950", Color{2, 44, 34, "022c22"}},
+	{"teal-50", Color{240, 253, 250, "f0fdfa"}},
+	{"teal-100", Color{204, 251, 241, "ccfbf1"}},
+	{"teal-200", Color{153, 246, 228, "99f6e4"}},
+	{"teal-300", Color{94, 234, 212, "5eead4"}},
+	{"teal-400", Color{45, 212, 191, "2dd4bf"}},
+	{"teal-500", Color{20, 184, 166, "14b8a6"}},
+	{"teal-600", Color{13, 148, 136, "0d9488"}},
+	{"teal-700", Color{15, 118, 110, "0f766e"}},
+	{"teal-800", Color{17, 94, 89, "115e59"}},
+	{"teal-900", Color{19, 78, 74, "134e4a"}},
+	{"teal-950", Color{4, 47, 46, "042f2e"}},
+	{"cyan-50", Color{236, 254, 255, "ecfeff"}},
+	{"cyan-100", Color{207, 250, 254, "cffafe"}},
+	{"cyan-200", Color{165, 243, 252, "a5f3fc"}},
+	{"cyan-300", Color{103, 232, 249, "67e8f9"}},
+	{"cyan-400", Color{34, 211, 238, "22d3ee"}},
+	{"cyan-500", Color{6, 182, 212, "06b6d4"}},
+	{"cyan-600", Color{8, 145, 178, "0891b2"}},
+	{"cyan-700", Color{14, 116, 144, "0e7490"}},
+	{"cyan-800", Color{21, 94, 117, "155e75"}},
+	{"cyan-900", Color{22, 78, 99, "164e63"}},
+	{"cyan-950", Color{8, 51, 68, "083344"}},
+	{"sky-50", Color{240, 249, 255, "f0f9ff"}},
+	{"sky-100", Color{224, 242, 254, "e0f2fe"}},
+	{"sky-200", Color{186, 230, 253, "bae6fd"}},
+	{"sky-300", Color{125, 211, 252, "7dd3fc"}},
+	{"sky-400", Color{56, 189, 248, "38bdf8"}},
+	{"sky-500", Color{14, 165, 233, "0ea5e9"}},
+	{"sky-600", Color{2, 132, 199, "0284c7"}},
+	{"sky-700", Color{3, 105, 161, "0369a1"}},
+	{"sky-800", Color{7, 89, 133, "075985"}},
+	{"sky-900", Color{12, 74, 110, "0c4a6e"}},
+	{"sky-950", Color{8, 47, 73, "082f49"}},
+	{"blue-50", Color{239, 246, 255, "eff6ff"}},
+	{"blue-100", Color{219, 234, 254, "dbeafe"}},
+	{"blue-200", Color{191, 219, 254, "bfdbfe"}},
+	{"blue-300", Color{147, 197, 253, "93c5fd"}},
+	{"blue-400", Color{96, 165, 250, "60a5fa"}},
+	{"blue-500", Color{59, 130, 246, "3b82f6"}},
+	{"blue-600", Color{37, 99, 235, "2563eb"}},
+	{"blue-700", Color{29, 78, 216, "1d4ed8"}},
+	{"blue-800", Color{30, 64, 175, "1e40af"}},
+	{"blue-900", Color{30, 58, 138, "1e3a8a"}},
+	{"blue-950", Color{23, 37, 84, "172554"}},
+	{"indigo-50", Color{238, 242, 255, "eef2ff"}},
+	{"indigo-100", Color{224, 231, 255, "e0e7ff"}},
+	{"indigo-200", Color{199, 210, 254, "c7d2fe"}},
+	{"indigo-300", Color{165, 180, 252, "a5b4fc"}},
+	{"indigo-400", Color{129, 140, 248, "818cf8"}},
+	{"indigo-500", Color{99, 102, 241, "6366f1"}},
+	{"indigo-600", Color{79, 70, 229, "4f46e5"}},
+	{"indigo-700", Color{67, 56, 202, "4338ca"}},
+	{"indigo-800", Color{55, 48, 163, "3730a3"}},
+	{"indigo-900", Color{49, 46, 129, "312e81"}},
+	{"indigo-950", Color{30, 27, 75, "1e1b4b"}},
+	{"violet-50", Color{245, 243, 255, "f5f3ff"}},
+	{"violet-100", Color{237, 233, 254, "ede9fe"}},
+	{"violet-200", Color{221, 214, 254, "ddd6fe"}},
+	{"violet-300", Color{196, 181, 253, "c4b5fd"}},
+	{"violet-400", Color{167, 139, 250, "a78bfa"}},
+	{"violet-500", Color{139, 92, 246, "8b5cf6"}},
+	{"violet-600", Color{124, 58, 237, "7c3aed"}},
+	{"violet-700", Color{109, 40, 217, "6d28d9"}},
+	{"violet-800", Color{91, 33, 182, "5b21b6"}},
+	{"violet-900", Color{76, 29, 149, "4c1d95"}},
+	{"violet-950", Color{46, 16, 101, "2e1065"}},
+	{"purple-50", Color{250, 245, 255, "faf5ff"}},
+	{"purple-100", Color{243, 232, 255, "f3e8ff"}},
+	{"purple-200", Color{233, 213, 255, "e9d5ff"}},
+	{"purple-300", Color{216, 180, 254, "d8b4fe"}},
+	{"purple-400", Color{192, 132, 252, "c084fc"}},
+	{"purple-500", Color{168, 85, 247, "a855f7"}},
+	{"purple-600", Color{147, 51, 234, "9333ea"}},
+	{"purple-700", Color{126, 34, 206, "7e22ce"}},
+	{"purple-800", Color{107, 33, 168, "6b21a8"}},
+	{"purple-900", Color{88, 28, 135, "581c87"}},
+	{"purple-950", Color{59, 7, 100, "3b0764"}},
+	{"fuchsia-50", Color{253, 244, 255, "fdf4ff"}},
+	{"fuchsia-100", Color{250, 232, 255, "fae8ff"}},
+	{"fuchsia-200", Color{245, 208, 254, "f5d0fe"}},
+	{"fuchsia-300", Color{240, 171, 252, "f0abfc"}},
+	{"fuchsia-400", Color{232, 121, 249, "e879f9"}},
+	{"fuchsia-500", Color{217, 70, 239, "d946ef"}},
+	{"fuchsia-600", Color{192, 38, 211, "c026d3"}},
+	{"fuchsia-700", Color{162, 28, 175, "a21caf"}},
+	{"fuchsia-800", Color{134, 25, 143, "86198f"}},
+	{"fuchsia-900", Color{112, 26, 117, "701a75"}},
+	{"fuchsia-950", Color{74, 4, 78, "4a044e"}},
+	{"pink-50", Color{253, 242, 248, "fdf2f8"}},
+	{"pink-100", Color{252, 231, 243, "fce7f3"}},
+	{"pink-200", Color{251, 207, 232, "fbcfe8"}},
+	{"pink-300", Color{249, 168, 212, "f9a8d4"}},
+	{"pink-400", Color{244, 114, 182, "f472b6"}},
+	{"pink-500", Color{236, 72, 153, "ec4899"}},
+	{"pink-600", Color{219, 39, 119, "db2777"}},
+	{"pink-700", Color{190, 24, 93, "be185d"}},
+	{"pink-800", Color{157, 23, 77, "9d174d"}},
+	{"pink-900", Color{131, 24, 67, "831843"}},
+	{"pink-950", Color{80, 7, 36, "500724"}},
+	{"rose-50", Color{255, 241, 242, "fff1f2"}},
+	{"rose-100", Color{255, 228, 230, "ffe4e6"}},
+	{"rose-200", Color{254, 205, 211, "fecdd3"}},
+	{"rose-300", Color{253, 164, 175, "fda4af"}},
+	{"rose-400", Color{251, 113, 133, "fb7185"}},
+	{"rose-500", Color{244, 63, 94, "f43f5e"}},
+	{"rose-600", Color{225, 29, 72, "e11d48"}},
+	{"rose-700", Color{190, 18, 60, "be123c"}},
+	{"rose-800", Color{159, 18, 57, "9f1239"}},
+	{"rose-900", Color{136, 19, 55, "881337"}},
+	{"rose-950", Color{76, 5, 25, "4c0519"}},
+}
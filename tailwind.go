@@ -0,0 +1,61 @@
+package palettecalculator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Shades of a Tailwind CSS color scale
+var TailwindShades = []int{50, 100, 200, 300, 400, 500, 600, 700, 800, 900, 950}
+
+// CIELAB lightness of each Tailwind shade
+var tailwindLightness = map[int]float64{50: 97, 100: 94, 200: 86, 300: 77, 400: 66, 500: 55, 600: 45, 700: 37, 800: 29, 900: 23, 950: 14}
+
+// Fraction of the dominant color's chroma kept by each Tailwind shade, light and dark ends are muted
+var tailwindChroma = map[int]float64{50: .3, 100: .45, 200: .65, 300: .85, 400: .95, 500: 1, 600: 1, 700: .9, 800: .8, 900: .7, 950: .6}
+
+// Calculates the Tailwind style 50-950 shade scale of the dominant color. Returns map of shade to Color{}
+func (pc *PaletteCalculator) CalculateTailwindShades(dc *Color) map[int]Color {
+	shades := make(map[int]Color)
+
+	lch := pc.ConvertLABToLCH(pc.ConvertRGBToLAB(dc))
+	for _, shade := range TailwindShades {
+		shades[shade] = *pc.ConvertLCHToRGB(&LCH{L: tailwindLightness[shade], C: lch.C * tailwindChroma[shade], H: lch.H})
+	}
+
+	return shades
+}
+
+// Formats a shade scale as a Tailwind config fragment for theme.extend.colors
+func (pc *PaletteCalculator) GenerateTailwindConfig(name string, shades map[int]Color) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("'%s': {\n", name))
+	for _, shade := range TailwindShades {
+		c, ok := shades[shade]
+		if !ok {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("  %d: '%s',\n", shade, cssHex(&c)))
+	}
+	sb.WriteString("},\n")
+
+	return sb.String()
+}
+
+// Formats the palette as a Tailwind config fragment for theme.extend.colors with a generated shade scale per color.
+// A single color palette is keyed by name, larger palettes by name-1, name-2, ...
+func (p *Palette) ToTailwindConfig(name string) string {
+	pc := new(PaletteCalculator)
+
+	var sb strings.Builder
+	for i := range p.Colors {
+		key := name
+		if len(p.Colors) > 1 {
+			key = fmt.Sprintf("%s-%d", name, i+1)
+		}
+		sb.WriteString(pc.GenerateTailwindConfig(key, pc.CalculateTailwindShades(&p.Colors[i])))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,78 @@
+package palettecalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCalculateTailwindShades(t *testing.T) {
+	dominantColor := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+	expectedShades := map[int]Color{
+		50:  {234, 249, 255, "eaf9ff"},
+		100: {216, 242, 253, "d8f2fd"},
+		200: {182, 221, 237, "b6dded"},
+		300: {146, 198, 218, "92c6da"},
+		400: {109, 169, 191, "6da9bf"},
+		500: {75, 140, 162, "4b8ca2"},
+		600: {47, 115, 136, "2f7388"},
+		700: {33, 94, 113, "215e71"},
+		800: {20, 74, 90, "144a5a"},
+		900: {14, 60, 73, "0e3c49"},
+		950: {0, 40, 51, "002833"},
+	}
+	paletteCalculator := new(PaletteCalculator)
+
+	returnedShades := paletteCalculator.CalculateTailwindShades(dominantColor)
+
+	if !reflect.DeepEqual(expectedShades, returnedShades) {
+		t.Errorf("expected: %v\n returned %v\n", expectedShades, returnedShades)
+	}
+
+}
+
+func TestGenerateTailwindConfig(t *testing.T) {
+	shades := map[int]Color{500: {75, 140, 162, "4b8ca2"}, 50: {234, 249, 255, "eaf9ff"}}
+	expectedConfig := "'brand': {\n  50: '#eaf9ff',\n  500: '#4b8ca2',\n},\n"
+	paletteCalculator := new(PaletteCalculator)
+
+	returnedConfig := paletteCalculator.GenerateTailwindConfig("brand", shades)
+
+	if expectedConfig != returnedConfig {
+		t.Errorf("expected: %s\n returned %s\n", expectedConfig, returnedConfig)
+	}
+
+}
+
+func TestGenerateTailwindConfigPadsHex(t *testing.T) {
+	shades := map[int]Color{900: {14, 60, 73, "0e3c49"}, 950: {0, 40, 51, "002833"}}
+	expectedConfig := "'brand': {\n  900: '#0e3c49',\n  950: '#002833',\n},\n"
+	paletteCalculator := new(PaletteCalculator)
+
+	returnedConfig := paletteCalculator.GenerateTailwindConfig("brand", shades)
+
+	if expectedConfig != returnedConfig {
+		t.Errorf("expected: %s\n returned %s\n", expectedConfig, returnedConfig)
+	}
+}
+
+func TestPaletteToTailwindConfig(t *testing.T) {
+	scale := "  50: '#eaf9ff',\n  100: '#d8f2fd',\n  200: '#b6dded',\n  300: '#92c6da',\n  400: '#6da9bf',\n  500: '#4b8ca2',\n" +
+		"  600: '#2f7388',\n  700: '#215e71',\n  800: '#144a5a',\n  900: '#0e3c49',\n  950: '#002833',\n},\n"
+	dominantColor := Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+	tests := []struct {
+		palette  *Palette
+		expected string
+	}{
+		{&Palette{Colors: []Color{dominantColor}}, "'brand': {\n" + scale},
+		{&Palette{Colors: []Color{dominantColor, dominantColor}}, "'brand-1': {\n" + scale + "'brand-2': {\n" + scale},
+		{&Palette{}, ""},
+	}
+
+	for _, test := range tests {
+		returnedConfig := test.palette.ToTailwindConfig("brand")
+
+		if test.expected != returnedConfig {
+			t.Errorf("expected: %s\n returned %s\n", test.expected, returnedConfig)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package palettecalculator
+
+import "math"
+
+// Hues (in degrees) that colors are pulled toward when shifted warmer or cooler
+const WarmHue = float64(30)
+const CoolHue = float64(210)
+
+// Saturation below which a color is considered achromatic
+const NeutralSaturation = .1
+
+// Representation of a color's perceived temperature
+type Temperature string
+
+const (
+	Warm    Temperature = "warm"
+	Cool    Temperature = "cool"
+	Neutral Temperature = "neutral"
+)
+
+// Classifies a color as warm, cool or neutral based on its hue and saturation
+func (pc *PaletteCalculator) ClassifyTemperature(c *Color) Temperature {
+	hsl := pc.ConvertRGBToHSL(c)
+
+	if hsl.Saturation < NeutralSaturation || hsl.Luminosity <= .05 || hsl.Luminosity >= .95 {
+		return Neutral
+	}
+	if hsl.Hue < 90 || hsl.Hue >= 330 {
+		return Warm
+	}
+	return Cool
+}
+
+// Classifies every color of a palette. Returns array of Temperature in the same order
+func (pc *PaletteCalculator) ClassifyTemperatures(colors []Color) []Temperature {
+	var temperatures []Temperature
+	for i := range colors {
+		temperatures = append(temperatures, pc.ClassifyTemperature(&colors[i]))
+	}
+
+	return temperatures
+}
+
+// Shifts each color's hue up to amount degrees toward warm, preserving saturation and luminosity
+func (pc *PaletteCalculator) ShiftWarmer(colors []Color, amount float64) []Color {
+	return pc.shiftTemperature(colors, WarmHue, amount)
+}
+
+// Shifts each color's hue up to amount degrees toward cool, preserving saturation and luminosity
+func (pc *PaletteCalculator) ShiftCooler(colors []Color, amount float64) []Color {
+	return pc.shiftTemperature(colors, CoolHue, amount)
+}
+
+func (pc *PaletteCalculator) shiftTemperature(colors []Color, target float64, amount float64) []Color {
+	var shifted []Color
+	for i := range colors {
+		hsl := pc.ConvertRGBToHSL(&colors[i])
+
+		// achromatic colors have no meaningful hue to shift
+		if hsl.Saturation == 0 {
+			shifted = append(shifted, colors[i])
+			continue
+		}
+
+		// rotate along the shortest arc toward target without overshooting it
+		distance := math.Mod(target-hsl.Hue+540, 360) - 180
+		off := math.Copysign(math.Min(math.Abs(distance), amount), distance)
+		shifted = append(shifted, *pc.ConvertHSLToRGB(pc.transformHue(hsl, math.Mod(off+360, 360))))
+	}
+
+	return shifted
+}
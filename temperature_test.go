@@ -0,0 +1,62 @@
+package palettecalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClassifyTemperatures(t *testing.T) {
+	colors := []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {119, 45, 24, "772d18"}, {128, 128, 128, "808080"}}
+	expectedTemperatures := []Temperature{Cool, Warm, Neutral}
+	paletteCalculator := new(PaletteCalculator)
+
+	returnedTemperatures := paletteCalculator.ClassifyTemperatures(colors)
+
+	if !reflect.DeepEqual(expectedTemperatures, returnedTemperatures) {
+		t.Errorf("expected: %v\n returned %v\n", expectedTemperatures, returnedTemperatures)
+	}
+
+}
+
+func TestShiftWarmer(t *testing.T) {
+	colors := []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {119, 45, 24, "772d18"}, {128, 128, 128, "808080"}}
+	expectedRGB := []Color{{24, 119, 109, "18776d"}, {119, 71, 24, "774718"}, {128, 128, 128, "808080"}}
+	paletteCalculator := new(PaletteCalculator)
+
+	returnedRGB := paletteCalculator.ShiftWarmer(colors, 20)
+
+	if !reflect.DeepEqual(expectedRGB, returnedRGB) {
+		t.Errorf("expected: %v\n returned %v\n", expectedRGB, returnedRGB)
+	}
+
+}
+
+func TestShiftCooler(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		amount      float64
+		expectedRGB []Color
+	}{
+		{
+			name:        "should shift hues toward cool by amount",
+			amount:      20,
+			expectedRGB: []Color{{24, 72, 119, "184877"}, {119, 24, 34, "771822"}, {128, 128, 128, "808080"}},
+		},
+		{
+			name:        "should not shift hues past the cool hue",
+			amount:      500,
+			expectedRGB: []Color{{24, 72, 119, "184877"}, {24, 72, 119, "184877"}, {128, 128, 128, "808080"}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			colors := []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {119, 45, 24, "772d18"}, {128, 128, 128, "808080"}}
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedRGB := paletteCalculator.ShiftCooler(colors, test.amount)
+
+			if !reflect.DeepEqual(test.expectedRGB, returnedRGB) {
+				t.Errorf("expected: %v\n returned %v\n", test.expectedRGB, returnedRGB)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package palettecalculator
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// Functions for html/template rendering palettes. Each takes a Color, a *Color or a CSS color string:
+//
+//	hex           "#186277"
+//	rgba          rgba(24, 98, 119, 0.5) given the color and an alpha in [0,1]
+//	contrastText  white or black, whichever has more contrast on the color
+//	lighten       the color moved an amount of the way towards white, see Color.Lighten
+//	cssVar        a custom property declaration, e.g. {{cssVar "brand" .}} is --brand: #186277
+func (pc *PaletteCalculator) TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"hex":          pc.templateHex,
+		"rgba":         pc.templateRGBA,
+		"contrastText": pc.templateContrastText,
+		"lighten":      pc.templateLighten,
+		"cssVar":       pc.templateCSSVar,
+	}
+}
+
+func (pc *PaletteCalculator) templateHex(v interface{}) (string, error) {
+	c, err := templateColor(v)
+	if err != nil {
+		return "", err
+	}
+
+	return cssHex(c), nil
+}
+
+func (pc *PaletteCalculator) templateRGBA(v interface{}, alpha float64) (template.CSS, error) {
+	c, err := templateColor(v)
+	if err != nil {
+		return "", err
+	}
+	c = c.Clamp()
+
+	return template.CSS(fmt.Sprintf("rgba(%v, %v, %v, %s)", c.Red, c.Green, c.Blue, formatNumber(clampUnit(alpha)))), nil
+}
+
+func (pc *PaletteCalculator) templateContrastText(v interface{}) (Color, error) {
+	c, err := templateColor(v)
+	if err != nil {
+		return Color{}, err
+	}
+
+	white := Color{Red: RGBMax, Green: RGBMax, Blue: RGBMax, Hex: pc.generateHex(RGBMax, RGBMax, RGBMax)}
+	black := Color{Red: 0, Green: 0, Blue: 0, Hex: pc.generateHex(0, 0, 0)}
+	if pc.ContrastRatio(&black, c) > pc.ContrastRatio(&white, c) {
+		return black, nil
+	}
+	return white, nil
+}
+
+func (pc *PaletteCalculator) templateLighten(v interface{}, amount float64) (Color, error) {
+	c, err := templateColor(v)
+	if err != nil {
+		return Color{}, err
+	}
+
+	return *c.Lighten(amount), nil
+}
+
+// Declares name as a custom property, anything but letters, digits, - and _ in name becomes -
+func (pc *PaletteCalculator) templateCSSVar(name string, v interface{}) (template.CSS, error) {
+	c, err := templateColor(v)
+	if err != nil {
+		return "", err
+	}
+
+	safe := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '-'
+	}, strings.TrimPrefix(name, "--"))
+	if safe == "" {
+		return "", fmt.Errorf("invalid css variable name %q", name)
+	}
+
+	return template.CSS(fmt.Sprintf("--%s: %s", safe, cssHex(c))), nil
+}
+
+// Color given to a template function as a Color, a *Color or a CSS color string
+func templateColor(v interface{}) (*Color, error) {
+	switch c := v.(type) {
+	case Color:
+		return &c, nil
+	case *Color:
+		if c == nil {
+			return nil, errors.New("expected a color, got nil")
+		}
+		return c, nil
+	case string:
+		return ParseCSS(c)
+	default:
+		return nil, fmt.Errorf("expected a color, got %T", v)
+	}
+}
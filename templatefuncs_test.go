@@ -0,0 +1,85 @@
+package palettecalculator
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		template    string
+		data        interface{}
+		expected    string
+		expectedErr string
+	}{
+		{
+			name:     "should format hex",
+			template: `{{hex .}}`,
+			data:     Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+			expected: "#186277",
+		},
+		{
+			name:     "should accept color pointers and css strings",
+			template: `{{hex .}} {{hex "teal"}}`,
+			data:     &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+			expected: "#186277 #008080",
+		},
+		{
+			name:     "should format rgba",
+			template: `<div style="background: {{rgba . 0.5}}"></div>`,
+			data:     Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+			expected: `<div style="background: rgba(24, 98, 119, 0.5)"></div>`,
+		},
+		{
+			name:     "should choose white text on dark colors",
+			template: `{{contrastText . | hex}}`,
+			data:     Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+			expected: "#ffffff",
+		},
+		{
+			name:     "should choose black text on light colors",
+			template: `{{contrastText "#fafafa" | hex}}`,
+			expected: "#000000",
+		},
+		{
+			name:     "should lighten",
+			template: `{{lighten "#000" 1 | hex}}`,
+			expected: "#ffffff",
+		},
+		{
+			name:     "should declare css variables",
+			template: `<div style="{{cssVar "brand color" .}}"></div>`,
+			data:     Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+			expected: `<div style="--brand-color: #186277"></div>`,
+		},
+		{
+			name:        "should reject values that are not colors",
+			template:    `{{hex .}}`,
+			data:        42,
+			expectedErr: "expected a color, got int",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+			tmpl := template.Must(template.New(test.name).Funcs(paletteCalculator.TemplateFuncs()).Parse(test.template))
+
+			var sb strings.Builder
+			err := tmpl.Execute(&sb, test.data)
+
+			if test.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.expectedErr) {
+					t.Errorf("expected: %v\n returned: %v\n", test.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sb.String() != test.expected {
+				t.Errorf("expected: %v\n returned: %v\n", test.expected, sb.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package palettecalculator
+
+// Marshals the color as its "#" prefixed hex, e.g. "#186277", so colors can be JSON map keys and plain text values
+func (c Color) MarshalText() ([]byte, error) {
+	return []byte(cssHex(&c)), nil
+}
+
+// Unmarshals a hex color like ParseHex, e.g. "#186277", "186277" or "#fff"
+func (c *Color) UnmarshalText(text []byte) error {
+	parsed, err := ParseHex(string(text))
+	if err != nil {
+		return err
+	}
+	*c = *parsed
+
+	return nil
+}
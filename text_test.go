@@ -0,0 +1,103 @@
+package palettecalculator
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestColorMarshalText(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		color    Color
+		expected string
+	}{
+		{
+			name:     "should marshal hex with prefix",
+			color:    Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+			expected: "#186277",
+		},
+		{
+			name:     "should zero pad channels",
+			color:    Color{Red: 10, Green: 10, Blue: 10},
+			expected: "#0a0a0a",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			returned, err := test.color.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(returned) != test.expected {
+				t.Errorf("expected: %v\n returned: %v\n", test.expected, string(returned))
+			}
+		})
+	}
+}
+
+func TestColorUnmarshalText(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		text        string
+		expected    Color
+		expectedErr error
+	}{
+		{
+			name:     "should unmarshal hex with prefix",
+			text:     "#186277",
+			expected: Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+		},
+		{
+			name:     "should unmarshal hex without prefix",
+			text:     "186277",
+			expected: Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+		},
+		{
+			name:     "should unmarshal short hex",
+			text:     "#fff",
+			expected: Color{Red: 255, Green: 255, Blue: 255, Hex: "ffffff"},
+		},
+		{
+			name:        "should reject color names",
+			text:        "black",
+			expectedErr: &ColorError{Syntax: "hex", Input: "black", Err: errors.New("expected 3, 4, 6 or 8 hex digits")},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var returned Color
+			err := returned.UnmarshalText([]byte(test.text))
+
+			if !reflect.DeepEqual(err, test.expectedErr) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expectedErr, err)
+			}
+			if !reflect.DeepEqual(returned, test.expected) {
+				t.Errorf("expected: %v\n returned: %v\n", test.expected, returned)
+			}
+		})
+	}
+}
+
+func TestColorMapKeys(t *testing.T) {
+	usage := map[Color]float64{
+		{Red: Red, Green: Green, Blue: Blue, Hex: Hex}:   .75,
+		{Red: 255, Green: 255, Blue: 255, Hex: "ffffff"}: .25,
+	}
+
+	data, err := json.Marshal(usage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedJSON := `{"#186277":0.75,"#ffffff":0.25}`
+	if string(data) != expectedJSON {
+		t.Errorf("expected: %v\n returned: %v\n", expectedJSON, string(data))
+	}
+
+	var returned map[Color]float64
+	if err := json.Unmarshal(data, &returned); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(returned, usage) {
+		t.Errorf("expected: %v\n returned: %v\n", usage, returned)
+	}
+}
@@ -0,0 +1,69 @@
+package palettecalculator
+
+import "math"
+
+// Minimum WCAG contrast ratios enforced between theme tokens
+const TextContrast = 4.5
+const UIContrast = 3.0
+
+// Hue and chroma (LCH) of the error token, a Material style red
+const errorHue = float64(30)
+const errorChroma = float64(65)
+
+// Chroma kept by the neutral tokens so they stay tinted toward the dominant color
+const neutralChroma = float64(4)
+
+// Semantic color tokens for a single theme mode
+type ThemeTokens struct {
+	Primary    Color `json:"primary"`
+	OnPrimary  Color `json:"on-primary"`
+	Surface    Color `json:"surface"`
+	Background Color `json:"background"`
+	Border     Color `json:"border"`
+	Error      Color `json:"error"`
+}
+
+// Light and dark theme generated from a single color
+type Theme struct {
+	Light ThemeTokens `json:"light"`
+	Dark  ThemeTokens `json:"dark"`
+}
+
+// Generates light and dark semantic tokens from dominant color. Primary/on-primary and error/surface meet
+// TextContrast, border/surface meets UIContrast
+func (pc *PaletteCalculator) GenerateTheme(dc *Color) *Theme {
+	lch := pc.ConvertLABToLCH(pc.ConvertRGBToLAB(dc))
+	neutral := &LCH{C: math.Min(lch.C, neutralChroma), H: lch.H}
+	errorLCH := &LCH{C: errorChroma, H: errorHue}
+
+	light := ThemeTokens{
+		OnPrimary:  *pc.ConvertLCHToRGB(&LCH{L: 100, C: lch.C, H: lch.H}),
+		Surface:    *pc.ConvertLCHToRGB(&LCH{L: 98, C: neutral.C, H: neutral.H}),
+		Background: *pc.ConvertLCHToRGB(&LCH{L: 99, C: neutral.C, H: neutral.H}),
+	}
+	light.Primary = pc.toneWithContrast(lch, 40, -1, light.OnPrimary, TextContrast)
+	light.Border = pc.toneWithContrast(neutral, 60, -1, light.Surface, UIContrast)
+	light.Error = pc.toneWithContrast(errorLCH, 40, -1, light.Surface, TextContrast)
+
+	dark := ThemeTokens{
+		Surface:    *pc.ConvertLCHToRGB(&LCH{L: 10, C: neutral.C, H: neutral.H}),
+		Background: *pc.ConvertLCHToRGB(&LCH{L: 6, C: neutral.C, H: neutral.H}),
+	}
+	dark.Primary = pc.toneWithContrast(lch, 80, 1, dark.Surface, UIContrast)
+	dark.OnPrimary = pc.toneWithContrast(lch, 20, -1, dark.Primary, TextContrast)
+	dark.Border = pc.toneWithContrast(neutral, 50, 1, dark.Surface, UIContrast)
+	dark.Error = pc.toneWithContrast(errorLCH, 80, 1, dark.Surface, TextContrast)
+
+	return &Theme{Light: light, Dark: dark}
+}
+
+// Steps lightness from tone in direction until the color reaches ratio against other
+func (pc *PaletteCalculator) toneWithContrast(lch *LCH, tone float64, direction float64, other Color, ratio float64) Color {
+	c := *pc.ConvertLCHToRGB(&LCH{L: tone, C: lch.C, H: lch.H})
+	for tone >= 0 && tone <= 100 && pc.ContrastRatio(&c, &other) < ratio {
+		tone += direction
+		c = *pc.ConvertLCHToRGB(&LCH{L: math.Max(0, math.Min(100, tone)), C: lch.C, H: lch.H})
+	}
+
+	return c
+}
@@ -0,0 +1,55 @@
+package palettecalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenerateTheme(t *testing.T) {
+	dominantColor := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+	expectedLight := ThemeTokens{
+		Primary:    Color{30, 102, 123, "1e667b"},
+		OnPrimary:  Color{255, 255, 255, "ffffff"},
+		Surface:    Color{241, 251, 255, "f1fbff"},
+		Background: Color{248, 253, 255, "f8fdff"},
+		Border:     Color{137, 146, 150, "899296"},
+		Error:      Color{180, 37, 45, "b4252d"},
+	}
+	paletteCalculator := new(PaletteCalculator)
+
+	returnedTheme := paletteCalculator.GenerateTheme(dominantColor)
+
+	if !reflect.DeepEqual(expectedLight, returnedTheme.Light) {
+		t.Errorf("expected: %v\n returned %v\n", expectedLight, returnedTheme.Light)
+	}
+
+}
+
+func TestGenerateThemeContrast(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		dominantColor *Color
+	}{
+		{name: "dark dominant color", dominantColor: &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}},
+		{name: "light dominant color", dominantColor: &Color{Red: 250, Green: 240, Blue: 10, Hex: "faf00a"}},
+		{name: "gray dominant color", dominantColor: &Color{Red: 128, Green: 128, Blue: 128, Hex: "808080"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedTheme := paletteCalculator.GenerateTheme(test.dominantColor)
+
+			for _, tokens := range []ThemeTokens{returnedTheme.Light, returnedTheme.Dark} {
+				if ratio := paletteCalculator.ContrastRatio(&tokens.Primary, &tokens.OnPrimary); ratio < TextContrast {
+					t.Errorf("primary/on-primary contrast %f below %f", ratio, TextContrast)
+				}
+				if ratio := paletteCalculator.ContrastRatio(&tokens.Error, &tokens.Surface); ratio < TextContrast {
+					t.Errorf("error/surface contrast %f below %f", ratio, TextContrast)
+				}
+				if ratio := paletteCalculator.ContrastRatio(&tokens.Border, &tokens.Surface); ratio < UIContrast {
+					t.Errorf("border/surface contrast %f below %f", ratio, UIContrast)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package palettecalculator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// JSON design token format
+type TokenFormat int
+
+const (
+	// Tokens Studio for Figma, formerly Figma Tokens: {"value": ..., "type": "color"}
+	TokensStudio TokenFormat = iota
+	// W3C Design Tokens Community Group draft: {"$value": ..., "$type": "color"}
+	W3CDesignTokens
+)
+
+// Writes the palette as design tokens grouped under the palette name, or "color" for unnamed palettes, keeping
+// palette order. Tokens are named by namer, a nil namer names them color-1, color-2, ...
+func (pc *PaletteCalculator) WriteDesignTokens(w io.Writer, p *Palette, namer ColorNamer, format TokenFormat) error {
+	valueKey, typeKey := "value", "type"
+	switch format {
+	case TokensStudio:
+	case W3CDesignTokens:
+		valueKey, typeKey = "$value", "$type"
+	default:
+		return fmt.Errorf("unsupported token format: %d", format)
+	}
+
+	group := p.Name
+	if group == "" {
+		group = "color"
+	}
+
+	var tokens []string
+	names := pc.colorNames(p, namer)
+	for i := range p.Colors {
+		tokens = append(tokens, fmt.Sprintf("    %s: {%s: %s, %s: \"color\"}",
+			jsonString(names[i]), jsonString(valueKey), jsonString(cssHex(&p.Colors[i])), jsonString(typeKey)))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("{\n  %s: {\n", jsonString(group)))
+	if len(tokens) > 0 {
+		sb.WriteString(strings.Join(tokens, ",\n") + "\n")
+	}
+	sb.WriteString("  }\n}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func jsonString(s string) string {
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
@@ -0,0 +1,62 @@
+package palettecalculator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWriteDesignTokens(t *testing.T) {
+	palette := &Palette{Name: "brand", Colors: []Color{{24, 98, 119, "186277"}, {14, 60, 73, "0e3c49"}}}
+	tests := []struct {
+		name     string
+		palette  *Palette
+		format   TokenFormat
+		expected string
+	}{
+		{
+			"tokens studio",
+			palette,
+			TokensStudio,
+			"{\n  \"brand\": {\n    \"color-1\": {\"value\": \"#186277\", \"type\": \"color\"},\n    \"color-2\": {\"value\": \"#0e3c49\", \"type\": \"color\"}\n  }\n}\n",
+		},
+		{
+			"w3c design tokens",
+			palette,
+			W3CDesignTokens,
+			"{\n  \"brand\": {\n    \"color-1\": {\"$value\": \"#186277\", \"$type\": \"color\"},\n    \"color-2\": {\"$value\": \"#0e3c49\", \"$type\": \"color\"}\n  }\n}\n",
+		},
+		{"unnamed empty palette", &Palette{}, TokensStudio, "{\n  \"color\": {\n  }\n}\n"},
+	}
+	paletteCalculator := new(PaletteCalculator)
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+
+		err := paletteCalculator.WriteDesignTokens(&buf, test.palette, nil, test.format)
+
+		if err != nil {
+			t.Errorf("%s expected error: %v returned error: %v", test.name, nil, err)
+		}
+		if test.expected != buf.String() {
+			t.Errorf("%s expected: %v\n returned: %v\n", test.name, test.expected, buf.String())
+		}
+		if !json.Valid(buf.Bytes()) {
+			t.Errorf("%s expected valid json\n returned: %v\n", test.name, buf.String())
+		}
+	}
+}
+
+func TestWriteDesignTokensWithUnsupportedFormat(t *testing.T) {
+	expectedErr := errors.New("unsupported token format: 9")
+	paletteCalculator := new(PaletteCalculator)
+	var buf bytes.Buffer
+
+	err := paletteCalculator.WriteDesignTokens(&buf, &Palette{}, nil, TokenFormat(9))
+
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Errorf("expected error: %v returned error: %v", expectedErr, err)
+	}
+}
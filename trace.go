@@ -0,0 +1,46 @@
+package palettecalculator
+
+import "context"
+
+// Starts spans around the steps of a calculation: opening files, reading images for upload, Vision API calls,
+// converting their results and generating schemes. The paletteotel package adapts an OpenTelemetry tracer
+type Tracer interface {
+	// Starts the span name as a child of the span in ctx, returning a context carrying the new span
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span started by a Tracer
+type Span interface {
+	// Ends the span, recording err as its failure when it is not nil
+	End(err error)
+}
+
+type noopSpan struct{}
+
+func (noopSpan) End(err error) {}
+
+// Starts the span name under pc's context, returning a copy of pc whose context carries the span so the steps it
+// calls are children of it
+func (pc *PaletteCalculator) startSpan(name string) (*PaletteCalculator, Span) {
+	if pc.Tracer == nil {
+		return pc, noopSpan{}
+	}
+
+	ctx := pc.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, span := pc.Tracer.Start(ctx, name)
+	traced := *pc
+	traced.Context = ctx
+
+	return &traced, span
+}
+
+// Copy of pc calling the Vision API and starting spans with ctx, e.g. the context of an incoming request
+func (pc *PaletteCalculator) WithContext(ctx context.Context) *PaletteCalculator {
+	c := *pc
+	c.Context = ctx
+
+	return &c
+}
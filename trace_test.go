@@ -0,0 +1,61 @@
+package palettecalculator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type mockSpanKey struct{}
+
+// Tracer recording each span as "parent > name" and the error it ended with
+type MockTracer struct {
+	spans []string
+	errs  []error
+}
+
+func (m *MockTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	parent, _ := ctx.Value(mockSpanKey{}).(string)
+	m.spans = append(m.spans, parent+" > "+name)
+	i := len(m.errs)
+	m.errs = append(m.errs, nil)
+
+	return context.WithValue(ctx, mockSpanKey{}, name), &mockSpan{end: func(err error) { m.errs[i] = err }}
+}
+
+type mockSpan struct {
+	end func(err error)
+}
+
+func (m *mockSpan) End(err error) {
+	m.end(err)
+}
+
+func TestCalculateSchemeSpans(t *testing.T) {
+	tracer := new(MockTracer)
+	pc := &PaletteCalculator{Tracer: tracer}
+	pc = pc.WithContext(context.WithValue(context.Background(), mockSpanKey{}, "request"))
+
+	pc.CalculateScheme(&Color{Red: 255}, Triadic)
+	pc.CalculateScheme(&Color{Red: 255}, "unknown")
+
+	expectedSpans := []string{"request > palettecalculator.CalculateScheme", "request > palettecalculator.CalculateScheme"}
+	if !reflect.DeepEqual(expectedSpans, tracer.spans) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedSpans, tracer.spans)
+	}
+	expectedErrs := []error{nil, errors.New("unsupported scheme type: unknown")}
+	if !reflect.DeepEqual(expectedErrs, tracer.errs) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedErrs, tracer.errs)
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	pc := new(PaletteCalculator)
+	ctx := context.WithValue(context.Background(), mockSpanKey{}, "request")
+
+	returned := pc.WithContext(ctx)
+	if returned.Context != ctx || pc.Context != nil {
+		t.Errorf("expected: %v\n returned: %v\n", ctx, returned.Context)
+	}
+}
@@ -0,0 +1,21 @@
+package palettecalculator
+
+import (
+	"math"
+)
+
+// Adjustment applied to each color a scheme generates. Shifts are relative, so a LuminosityShift of .1 makes
+// colors 10% lighter and a SaturationShift of -.2 makes them 20% less saturated. The zero value changes nothing
+type Transform struct {
+	HueOffset       float64
+	SaturationShift float64
+	LuminosityShift float64
+}
+
+func (t Transform) apply(pc *PaletteCalculator, hsl *HSL) *HSL {
+	return &HSL{
+		Hue:        math.Mod(hsl.Hue+t.HueOffset+360, 360),
+		Saturation: pc.round(math.Max(0, math.Min(1, hsl.Saturation*(1+t.SaturationShift))), 2),
+		Luminosity: pc.round(math.Max(0, math.Min(1, hsl.Luminosity*(1+t.LuminosityShift))), 2),
+	}
+}
@@ -0,0 +1,46 @@
+package palettecalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCalculateColorSchemeWithTransforms(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		transforms  []SchemeOption
+		expectedRGB []Color
+	}{
+		{
+			name:        "should leave scheme unchanged with zero transform",
+			transforms:  []SchemeOption{Transform{}},
+			expectedRGB: []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {119, 24, 96, "771860"}, {96, 119, 24, "607718"}},
+		},
+		{
+			name:        "should make generated colors lighter",
+			transforms:  []SchemeOption{Transform{LuminosityShift: .1}},
+			expectedRGB: []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {131, 27, 106, "831b6a"}, {106, 131, 27, "6a831b"}},
+		},
+		{
+			name:        "should apply transforms in order",
+			transforms:  []SchemeOption{Transform{SaturationShift: -.5}, Transform{HueOffset: -10}},
+			expectedRGB: []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {95, 48, 92, "5f305c"}, {92, 95, 48, "5c5f30"}},
+		},
+		{
+			name:        "should clamp luminosity",
+			transforms:  []SchemeOption{Transform{LuminosityShift: 5}},
+			expectedRGB: []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {255, 255, 255, "ffffff"}, {255, 255, 255, "ffffff"}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dominantColor := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedRGB := paletteCalculator.CalculateTriadicColorScheme(dominantColor, test.transforms...)
+
+			if !reflect.DeepEqual(test.expectedRGB, returnedRGB) {
+				t.Errorf("expected: %v\n returned %v\n", test.expectedRGB, returnedRGB)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package palettecalculator
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log/slog"
+	"math"
+)
+
+// JPEG quality of downscaled uploads, high enough that the colors detected don't shift
+const uploadJPEGQuality = 90
+
+// Reader of the image to upload, downscaled to fit within MaxUploadDimension when its longest side is larger. Images
+// that fit, or can't be decoded locally, e.g. WebP, are uploaded as they are
+func (pc *PaletteCalculator) downscaleForUpload(r io.Reader) (io.Reader, error) {
+	if pc.MaxUploadDimension <= 0 {
+		return r, nil
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || maxInt(config.Width, config.Height) <= pc.MaxUploadDimension {
+		return bytes.NewReader(data), nil
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, &DecodeError{Err: err}
+	}
+
+	scaled := pc.downscale(img, pc.MaxUploadDimension)
+	var buf bytes.Buffer
+	if scaled.Opaque() {
+		err = jpeg.Encode(&buf, scaled, &jpeg.Options{Quality: uploadJPEGQuality})
+	} else {
+		// jpeg has no alpha, keep transparent pixels out of the colors detected
+		err = png.Encode(&buf, scaled)
+	}
+	if err != nil {
+		return nil, err
+	}
+	pc.log(slog.LevelDebug, "downscaled image for upload", "width", config.Width, "height", config.Height,
+		"scaled_width", scaled.Bounds().Dx(), "scaled_height", scaled.Bounds().Dy(), "size", len(data), "scaled_size", buf.Len())
+
+	return &buf, nil
+}
+
+// Box filters img so its longest side is max pixels, keeping its aspect ratio
+func (pc *PaletteCalculator) downscale(img image.Image, max int) *image.RGBA {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	scale := math.Max(1, float64(maxInt(w, h))/float64(max))
+	sw, sh := maxInt(1, int(math.Round(float64(w)/scale))), maxInt(1, int(math.Round(float64(h)/scale)))
+
+	sums := make([]uint64, sw*sh*4)
+	counts := make([]uint64, sw*sh)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			i := minInt(sw-1, x*sw/w) + minInt(sh-1, y*sh/h)*sw
+			r, g, b, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			sums[i*4] += uint64(r)
+			sums[i*4+1] += uint64(g)
+			sums[i*4+2] += uint64(b)
+			sums[i*4+3] += uint64(a)
+			counts[i]++
+		}
+	}
+
+	// sums are premultiplied, as image.RGBA is
+	scaled := image.NewRGBA(image.Rect(0, 0, sw, sh))
+	for i := range counts {
+		if counts[i] == 0 {
+			continue
+		}
+		scaled.SetRGBA(i%sw, i/sw, color.RGBA{
+			R: uint8(sums[i*4] / counts[i] >> 8),
+			G: uint8(sums[i*4+1] / counts[i] >> 8),
+			B: uint8(sums[i*4+2] / counts[i] >> 8),
+			A: uint8(sums[i*4+3] / counts[i] >> 8),
+		})
+	}
+
+	return scaled
+}
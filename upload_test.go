@@ -0,0 +1,121 @@
+package palettecalculator
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestDownscaleForUpload(t *testing.T) {
+	encode := func(img image.Image) []byte {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+	opaque := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	transparent := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for x := 0; x < 40; x++ {
+		for y := 0; y < 20; y++ {
+			opaque.Set(x, y, color.RGBA{R: Red, G: Green, B: Blue, A: 255})
+			if x < 20 {
+				transparent.Set(x, y, color.RGBA{R: Red, G: Green, B: Blue, A: 255})
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		name              string
+		max               int
+		data              []byte
+		expectedUnchanged bool
+		expectedFormat    string
+		expectedSize      image.Point
+	}{
+		{
+			name:              "should upload as is when disabled",
+			data:              encode(opaque),
+			expectedUnchanged: true,
+		},
+		{
+			name:              "should upload images that fit as is",
+			max:               40,
+			data:              encode(opaque),
+			expectedUnchanged: true,
+		},
+		{
+			name:           "should downscale opaque images to jpeg",
+			max:            10,
+			data:           encode(opaque),
+			expectedFormat: "jpeg",
+			expectedSize:   image.Pt(10, 5),
+		},
+		{
+			name:           "should downscale transparent images to png",
+			max:            10,
+			data:           encode(transparent),
+			expectedFormat: "png",
+			expectedSize:   image.Pt(10, 5),
+		},
+		{
+			name:              "should upload images it can't decode as is",
+			max:               10,
+			data:              []byte("RIFF....WEBP"),
+			expectedUnchanged: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := &PaletteCalculator{MaxUploadDimension: test.max}
+
+			r, err := paletteCalculator.downscaleForUpload(bytes.NewReader(test.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			returned, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.expectedUnchanged {
+				if !bytes.Equal(returned, test.data) {
+					t.Errorf("expected: %v\n returned: %v\n", len(test.data), len(returned))
+				}
+				return
+			}
+			config, format, err := image.DecodeConfig(bytes.NewReader(returned))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if format != test.expectedFormat || image.Pt(config.Width, config.Height) != test.expectedSize {
+				t.Errorf("expected: %v %v\n returned: %v %v\n", test.expectedFormat, test.expectedSize, format, image.Pt(config.Width, config.Height))
+			}
+		})
+	}
+}
+
+func TestDownscale(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 2; y++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+			if x%2 == 1 {
+				img.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+	paletteCalculator := new(PaletteCalculator)
+
+	returned := paletteCalculator.downscale(img, 2)
+
+	expected := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	expected.SetRGBA(0, 0, color.RGBA{R: 127, B: 127, A: 255})
+	expected.SetRGBA(1, 0, color.RGBA{R: 127, B: 127, A: 255})
+	if !reflect.DeepEqual(returned, expected) {
+		t.Errorf("expected: %v\n returned: %v\n", expected.Pix, returned.Pix)
+	}
+}
@@ -0,0 +1,41 @@
+package palettecalculator
+
+// Saturation and luminosity ranges a palette is remapped into while keeping its hues
+type PaletteVariant struct {
+	MinSaturation float64
+	MaxSaturation float64
+	MinLuminosity float64
+	MaxLuminosity float64
+}
+
+// Soft, light variant
+var Pastel = PaletteVariant{MinSaturation: .45, MaxSaturation: .75, MinLuminosity: .78, MaxLuminosity: .9}
+
+// Fully saturated, mid luminosity variant
+var Neon = PaletteVariant{MinSaturation: .9, MaxSaturation: 1, MinLuminosity: .5, MaxLuminosity: .6}
+
+// Muted, dark variant
+var Earthy = PaletteVariant{MinSaturation: .2, MaxSaturation: .45, MinLuminosity: .25, MaxLuminosity: .45}
+
+// Remaps saturation and luminosity of each color into the variant's ranges. Hues and relative ordering are preserved
+func (pc *PaletteCalculator) ApplyPaletteVariant(colors []Color, v PaletteVariant) []Color {
+	var variantColors []Color
+	for i := range colors {
+		hsl := pc.ConvertRGBToHSL(&colors[i])
+
+		// achromatic colors keep their lack of saturation
+		saturation := float64(0)
+		if hsl.Saturation > 0 {
+			saturation = v.MinSaturation + hsl.Saturation*(v.MaxSaturation-v.MinSaturation)
+		}
+
+		remappedHSL := &HSL{
+			Hue:        hsl.Hue,
+			Saturation: saturation,
+			Luminosity: v.MinLuminosity + hsl.Luminosity*(v.MaxLuminosity-v.MinLuminosity),
+		}
+		variantColors = append(variantColors, *pc.ConvertHSLToRGB(remappedHSL))
+	}
+
+	return variantColors
+}
@@ -0,0 +1,41 @@
+package palettecalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyPaletteVariant(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		variant     PaletteVariant
+		expectedRGB []Color
+	}{
+		{
+			name:        "should return pastel variant",
+			variant:     Pastel,
+			expectedRGB: []Color{{177, 225, 238, "b1e1ee"}, {238, 177, 223, "eeb1df"}, {223, 238, 177, "dfeeb1"}, {214, 214, 214, "d6d6d6"}},
+		},
+		{
+			name:        "should return neon variant",
+			variant:     Neon,
+			expectedRGB: []Color{{18, 201, 251, "12c9fb"}, {251, 18, 195, "fb12c3"}, {195, 251, 18, "c3fb12"}, {140, 140, 140, "8c8c8c"}},
+		},
+		{
+			name:        "should return earthy variant",
+			variant:     Earthy,
+			expectedRGB: []Color{{49, 94, 107, "315e6b"}, {107, 49, 93, "6b315d"}, {93, 107, 49, "5d6b31"}, {89, 89, 89, "595959"}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			colors := []Color{{Red: Red, Green: Green, Blue: Blue, Hex: Hex}, {119, 24, 96, "771860"}, {96, 119, 24, "607718"}, {128, 128, 128, "808080"}}
+			paletteCalculator := new(PaletteCalculator)
+
+			returnedRGB := paletteCalculator.ApplyPaletteVariant(colors, test.variant)
+
+			if !reflect.DeepEqual(test.expectedRGB, returnedRGB) {
+				t.Errorf("expected: %v\n returned %v\n", test.expectedRGB, returnedRGB)
+			}
+		})
+	}
+}
@@ -0,0 +1,349 @@
+//go:build !js
+
+package palettecalculator
+
+import (
+	"bytes"
+	vision "cloud.google.com/go/vision/apiv1"
+	"context"
+	gax2 "github.com/googleapis/gax-go/v2"
+	pb "google.golang.org/genproto/googleapis/cloud/vision/v1"
+	col "google.golang.org/genproto/googleapis/type/color"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"image"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"time"
+)
+
+// Third party wrapper of the vision.NewImageAnnotatorClient method being used by DI
+type Calculator interface {
+	DetectImageProperties(ctx context.Context, img *pb.Image, ictx *pb.ImageContext, opts ...gax2.CallOption) (*pb.ImageProperties, error)
+}
+
+// Third Party wrapper interface for vision.NewImageFromReader
+type Reader interface {
+	NewImageFromReader(r io.Reader) (*pb.Image, error)
+	NewImageFromURI(uri string) *pb.Image
+}
+
+type VisionReader struct{}
+
+// Failure of a Vision API call, matching ErrVisionQuota with errors.Is when the quota or rate limit was exceeded
+type VisionError struct {
+	Err error
+}
+
+func (e *VisionError) Error() string {
+	return "vision api: " + e.Err.Error()
+}
+
+func (e *VisionError) Unwrap() error {
+	return e.Err
+}
+
+func (e *VisionError) Is(target error) bool {
+	return target == ErrVisionQuota && status.Code(e.Err) == codes.ResourceExhausted
+}
+
+func (vr *VisionReader) NewImageFromReader(r io.Reader) (*pb.Image, error) {
+	image, err := vision.NewImageFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return image, nil
+
+}
+
+func (vr *VisionReader) NewImageFromURI(uri string) *pb.Image {
+	image := vision.NewImageFromURI(uri)
+
+	return image
+}
+
+func NewPaletteCalculator() (*PaletteCalculator, error) {
+	ctx := context.Background()
+	client, err := vision.NewImageAnnotatorClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaletteCalculator{Calculator: client, Reader: new(VisionReader), Opener: new(FileOpener), Context: ctx}, nil
+
+}
+
+// Calculates predominant color in image given file path to image. Indexed PNG and GIF files are read
+// locally without the Vision API
+func (pc *PaletteCalculator) CalculatePredominantColorFromFile(file string) (c *Color, err error) {
+	pc, span := pc.startSpan("palettecalculator.CalculatePredominantColorFromFile")
+	defer func() { span.End(err) }()
+
+	// Open file
+	f, err := pc.openFile(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return pc.CalculatePredominantColorFromReader(f)
+}
+
+// Calculates predominant color in image read from r, e.g. an upload or stdin. Indexed PNG and GIF images are
+// read locally without the Vision API
+func (pc *PaletteCalculator) CalculatePredominantColorFromReader(r io.Reader) (c *Color, err error) {
+	pc, span := pc.startSpan("palettecalculator.CalculatePredominantColorFromReader")
+	defer func() { span.End(err) }()
+
+	// indexed images carry an exact palette, read it locally instead of calling Vision
+	indexed, r := pc.decodeIndexed(r)
+	if indexed != nil {
+		if p := pc.PaletteFromIndexedImage(indexed); len(p.Colors) > 0 {
+			pc.log(slog.LevelDebug, "reading predominant color of indexed image", "backend", "indexed", "colors", len(p.Colors))
+			return &p.Colors[0], nil
+		}
+	}
+	pc.log(slog.LevelDebug, "calculating predominant color", "backend", "vision")
+
+	// calculate properties of generated image with
+	properties, err := pc.detectImageProperties(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return pc.predominantColor(properties)
+}
+
+// Sends the image read from r to the Vision API
+func (pc *PaletteCalculator) detectImageProperties(r io.Reader) (*pb.ImageProperties, error) {
+	// generate image from reader
+	image, err := pc.newImageFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return pc.callVision(image)
+}
+
+// Opens file, traced as palettecalculator.OpenFile
+func (pc *PaletteCalculator) openFile(file string) (*os.File, error) {
+	_, span := pc.startSpan("palettecalculator.OpenFile")
+	f, err := pc.Opener.Open(file)
+	if err != nil {
+		err = &FileError{File: file, Err: err}
+	}
+	span.End(err)
+	if err == nil && pc.Logger != nil {
+		if info, statErr := f.Stat(); statErr == nil {
+			pc.log(slog.LevelDebug, "opened image file", "file", file, "size", info.Size())
+		}
+	}
+
+	return f, err
+}
+
+// Reads the image to upload to the Vision API, downscaled when larger than MaxUploadDimension, traced as
+// palettecalculator.Upload
+func (pc *PaletteCalculator) newImageFromReader(r io.Reader) (*pb.Image, error) {
+	_, span := pc.startSpan("palettecalculator.Upload")
+	r, err := pc.downscaleForUpload(r)
+	if err != nil {
+		span.End(err)
+		return nil, err
+	}
+	image, err := pc.Reader.NewImageFromReader(r)
+	span.End(err)
+	if err == nil {
+		pc.log(slog.LevelDebug, "read image for upload", "size", len(image.GetContent()))
+	}
+
+	return image, err
+}
+
+// Calls the Vision API with pc's context, traced as palettecalculator.DetectImageProperties
+func (pc *PaletteCalculator) callVision(image *pb.Image) (*pb.ImageProperties, error) {
+	traced, span := pc.startSpan("palettecalculator.DetectImageProperties")
+	start := time.Now()
+	properties, err := pc.Calculator.DetectImageProperties(traced.Context, image, nil)
+	if err != nil {
+		err = &VisionError{Err: err}
+	}
+	span.End(err)
+	if err != nil {
+		pc.log(slog.LevelDebug, "vision request failed", "duration", time.Since(start), "error", err)
+	} else {
+		pc.log(slog.LevelDebug, "vision request finished", "duration", time.Since(start), "colors", len(properties.GetDominantColors().GetColors()))
+	}
+
+	return properties, err
+}
+
+func (pc *PaletteCalculator) CalculatePredominantColorFromURI(uri string) (c *Color, err error) {
+	pc, span := pc.startSpan("palettecalculator.CalculatePredominantColorFromURI")
+	defer func() { span.End(err) }()
+
+	// generate image from file
+	image := pc.Reader.NewImageFromURI(uri)
+
+	// calculate properties of generated image with
+	properties, err := pc.callVision(image)
+	if err != nil {
+		return nil, err
+	}
+
+	return pc.predominantColor(properties)
+}
+
+// Most dominant of the image properties' colors
+func (pc *PaletteCalculator) predominantColor(properties *pb.ImageProperties) (dc *Color, err error) {
+	_, span := pc.startSpan("palettecalculator.Convert")
+	defer func() { span.End(err) }()
+
+	dc = new(Color)
+
+	// iterate through resulting colors, get most dominant and add to dc's attributes
+	var c *col.Color
+	max := float32(0)
+	for _, quantized := range properties.GetDominantColors().GetColors() {
+		color := quantized.Color
+		score := quantized.Score
+		if score > max {
+			max = score
+			c = color
+		}
+	}
+	if c == nil {
+		return nil, ErrNoDominantColors
+	}
+
+	dc.Red = float64(c.GetRed())
+	dc.Green = float64(c.GetGreen())
+	dc.Blue = float64(c.GetBlue())
+	dc.Hex = pc.generateHex(dc.Red, dc.Green, dc.Blue)
+	return dc, nil
+}
+
+// Calculates the palette of the image read from r with the Vision API, colors ordered by score and weighted by
+// pixel fraction. Indexed PNG and GIF images are read locally with PaletteFromIndexedImage
+func (pc *PaletteCalculator) CalculatePaletteFromReader(r io.Reader) (p *Palette, err error) {
+	pc, span := pc.startSpan("palettecalculator.CalculatePaletteFromReader")
+	defer func() { span.End(err) }()
+	start := time.Now()
+
+	indexed, r := pc.decodeIndexed(r)
+	if indexed != nil {
+		p = pc.PaletteFromIndexedImage(indexed)
+		pc.logPalette(p, "indexed", start)
+		return p, nil
+	}
+
+	properties, err := pc.detectImageProperties(r)
+	if err != nil {
+		return nil, err
+	}
+	p, err = pc.visionPalette(properties)
+	if err != nil {
+		return nil, err
+	}
+	pc.logPalette(p, "vision", start)
+
+	return p, nil
+}
+
+// Calculates the palette of the image at uri with the Vision API, colors ordered by score and weighted by pixel
+// fraction
+func (pc *PaletteCalculator) CalculatePaletteFromURI(uri string) (p *Palette, err error) {
+	pc, span := pc.startSpan("palettecalculator.CalculatePaletteFromURI")
+	defer func() { span.End(err) }()
+	start := time.Now()
+
+	properties, err := pc.callVision(pc.Reader.NewImageFromURI(uri))
+	if err != nil {
+		return nil, err
+	}
+	p, err = pc.visionPalette(properties)
+	if err != nil {
+		return nil, err
+	}
+	pc.logPalette(p, "vision", start, "uri", uri)
+
+	return p, nil
+}
+
+func (pc *PaletteCalculator) visionPalette(properties *pb.ImageProperties) (p *Palette, err error) {
+	_, span := pc.startSpan("palettecalculator.Convert")
+	defer func() { span.End(err) }()
+
+	infos := append([]*pb.ColorInfo(nil), properties.GetDominantColors().GetColors()...)
+	if len(infos) == 0 {
+		return nil, ErrNoDominantColors
+	}
+	sort.SliceStable(infos, func(i, j int) bool { return infos[i].GetScore() > infos[j].GetScore() })
+
+	p = &Palette{}
+	for _, info := range infos {
+		c := info.GetColor()
+		r, g, b := float64(c.GetRed()), float64(c.GetGreen()), float64(c.GetBlue())
+		p.Colors = append(p.Colors, Color{Red: r, Green: g, Blue: b, Hex: hexString(r, g, b)})
+		p.Weights = append(p.Weights, float64(info.GetPixelFraction()))
+	}
+
+	return p, nil
+}
+
+// Calculates the palette of the image read from r, or at uri when r is nil, for Extraction.Run
+func (pc *PaletteCalculator) visionExtract(r io.Reader, uri string) (*Palette, error) {
+	if r == nil {
+		return pc.CalculatePaletteFromURI(uri)
+	}
+	return pc.CalculatePaletteFromReader(r)
+}
+
+// Calculates predominant color in image given file path to image, along with its placeholder hashes.
+// The file is read once and the same bytes are decoded locally and sent to the Vision API
+func (pc *PaletteCalculator) CalculatePredominantColorAndPlaceholdersFromFile(file string) (c *Color, placeholders *Placeholders, err error) {
+	pc, span := pc.startSpan("palettecalculator.CalculatePredominantColorAndPlaceholdersFromFile")
+	defer func() { span.End(err) }()
+
+	f, err := pc.openFile(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if _, err := buf.ReadFrom(f); err != nil {
+		return nil, nil, err
+	}
+	data := buf.Bytes()
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, &DecodeError{Err: err}
+	}
+	placeholders, err = pc.EncodePlaceholders(img)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	visionImage, err := pc.newImageFromReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	properties, err := pc.callVision(visionImage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c, err = pc.predominantColor(properties)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c, placeholders, nil
+}
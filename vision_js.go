@@ -0,0 +1,19 @@
+//go:build js
+
+package palettecalculator
+
+import (
+	"errors"
+	"io"
+)
+
+// The Vision API client does not build for the browser, so under GOOS=js these stand in for its wrappers and
+// PaletteCalculator is used as new(PaletteCalculator) for the color math alone
+type Calculator interface{}
+
+type Reader interface{}
+
+// Extraction.Run under GOOS=js, where only local extraction is available
+func (pc *PaletteCalculator) visionExtract(r io.Reader, uri string) (*Palette, error) {
+	return nil, errors.New("the vision api is not available under GOOS=js, use Local")
+}
@@ -0,0 +1,378 @@
+//go:build !js
+
+package palettecalculator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/googleapis/gax-go/v2"
+	pb "google.golang.org/genproto/googleapis/cloud/vision/v1"
+	"google.golang.org/genproto/googleapis/type/color"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCalculatePredominantColorFromFile(t *testing.T) {
+	for _, test := range []struct {
+		name                  string
+		filePath              string
+		data                  []*pb.ColorInfo
+		visionData            []byte
+		expectedDominantColor *Color
+		calculatorErr         error
+		openerErr             error
+		readerErr             error
+		expectedErr           error
+	}{
+		{
+			name:                  "should return dominant color with no error",
+			filePath:              "test/file.path",
+			data:                  []*pb.ColorInfo{&pb.ColorInfo{Color: &color.Color{Red: Red, Green: Green, Blue: Blue}, Score: .01}},
+			visionData:            []byte{},
+			expectedDominantColor: &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+			calculatorErr:         nil,
+			openerErr:             nil,
+			readerErr:             nil,
+			expectedErr:           nil,
+		},
+		{
+			name:                  "error occurs when file is opened",
+			filePath:              "test/file.path",
+			data:                  nil,
+			visionData:            nil,
+			expectedDominantColor: nil,
+			calculatorErr:         nil,
+			openerErr:             errors.New("os error has occurRed. file not found"),
+			readerErr:             nil,
+			expectedErr:           &FileError{File: "test/file.path", Err: errors.New("os error has occurRed. file not found")},
+		},
+		{
+			name:                  "error occurs when file is read as image",
+			filePath:              "test/file.path",
+			data:                  nil,
+			visionData:            nil,
+			expectedDominantColor: nil,
+			calculatorErr:         nil,
+			openerErr:             nil,
+			readerErr:             errors.New("unable to read from file"),
+			expectedErr:           errors.New("unable to read from file"),
+		}, {
+			name:                  "error occurs when image properties are calculated",
+			filePath:              "test/file.path",
+			data:                  nil,
+			visionData:            nil,
+			expectedDominantColor: nil,
+			calculatorErr:         errors.New("unable to calculate image properties"),
+			openerErr:             nil,
+			readerErr:             nil,
+			expectedErr:           &VisionError{Err: errors.New("unable to calculate image properties")},
+		},
+	} {
+		t.Run(fmt.Sprintf("%s", test.name), func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			paletteCalculator.Calculator = &MockCalculator{data: test.data, err: test.calculatorErr}
+			file, _ := os.Create(filepath.Join(t.TempDir(), "image"))
+			defer file.Close()
+			paletteCalculator.Opener = &MockFileOpener{data: file, err: test.openerErr}
+			paletteCalculator.Reader = &MockVisionReader{data: test.visionData, err: test.readerErr}
+
+			returnedDominantColor, err := paletteCalculator.CalculatePredominantColorFromFile(test.filePath)
+
+			if !reflect.DeepEqual(test.expectedDominantColor, returnedDominantColor) {
+				t.Errorf("expected: %+v\n returned: %+v\n ", test.expectedDominantColor, returnedDominantColor)
+			}
+
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf("expected error: %s returned error: %s", test.expectedErr.Error(), err.Error())
+			}
+		})
+	}
+}
+
+func TestCalculatePredominantColorFromURI(t *testing.T) {
+	for _, test := range []struct {
+		name                  string
+		uri                   string
+		data                  []*pb.ColorInfo
+		visionData            []byte
+		expectedDominantColor *Color
+		calculatorErr         error
+		expectedErr           error
+	}{
+		{
+			name:                  "should return dominant color with no error",
+			uri:                   "test.uri",
+			data:                  []*pb.ColorInfo{&pb.ColorInfo{Color: &color.Color{Red: Red, Green: Green, Blue: Blue}, Score: .01}},
+			visionData:            []byte{},
+			expectedDominantColor: &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex},
+			calculatorErr:         nil,
+			expectedErr:           nil,
+		}, {
+			name:                  "error occurs when vision finds no dominant colors",
+			uri:                   "test.uri",
+			data:                  nil,
+			visionData:            []byte{},
+			expectedDominantColor: nil,
+			calculatorErr:         nil,
+			expectedErr:           ErrNoDominantColors,
+		}, {
+			name:                  "error occurs when image properties are calculated",
+			uri:                   "test.uri",
+			data:                  nil,
+			visionData:            nil,
+			expectedDominantColor: nil,
+			calculatorErr:         errors.New("unable to calculate image properties"),
+			expectedErr:           &VisionError{Err: errors.New("unable to calculate image properties")},
+		},
+	} {
+		t.Run(fmt.Sprintf("%s", test.name), func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+
+			paletteCalculator.Calculator = &MockCalculator{data: test.data, err: test.calculatorErr}
+			paletteCalculator.Reader = &MockVisionReader{data: test.visionData}
+
+			returnedDominantColor, err := paletteCalculator.CalculatePredominantColorFromURI(test.uri)
+
+			if !reflect.DeepEqual(test.expectedDominantColor, returnedDominantColor) {
+				t.Errorf("expected: %+v\n returned: %+v\n ", test.expectedDominantColor, returnedDominantColor)
+			}
+
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf("expected error: %s returned error: %s", test.expectedErr.Error(), err.Error())
+			}
+		})
+	}
+}
+
+func TestVisionErrorIs(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		err       error
+		wantQuota bool
+	}{
+		{name: "quota exceeded", err: status.Error(codes.ResourceExhausted, "quota exceeded"), wantQuota: true},
+		{name: "other status", err: status.Error(codes.InvalidArgument, "bad image"), wantQuota: false},
+		{name: "not a status", err: errors.New("connection reset"), wantQuota: false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			paletteCalculator := new(PaletteCalculator)
+			paletteCalculator.Calculator = &MockCalculator{err: test.err}
+			paletteCalculator.Reader = &MockVisionReader{}
+
+			_, err := paletteCalculator.CalculatePaletteFromURI("gs://bucket/photo.jpg")
+
+			var visionErr *VisionError
+			if !errors.As(err, &visionErr) || visionErr.Err != test.err {
+				t.Errorf("expected: %v\n returned: %v\n", test.err, err)
+			}
+			if quota := errors.Is(err, ErrVisionQuota); quota != test.wantQuota {
+				t.Errorf("expected: %v\n returned: %v\n", test.wantQuota, quota)
+			}
+		})
+	}
+}
+
+func TestExtractionVision(t *testing.T) {
+	paletteCalculator := new(PaletteCalculator)
+	paletteCalculator.Calculator = &MockCalculator{data: visionTestColors}
+	paletteCalculator.Reader = &MockVisionReader{}
+
+	for _, extraction := range []*Extraction{
+		paletteCalculator.Extract().FromURI("gs://bucket/photo.jpg").TopColors(1),
+		paletteCalculator.Extract().FromReader(bytes.NewReader([]byte("jpeg"))).TopColors(1),
+	} {
+		result, err := extraction.Run(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := &Palette{Colors: []Color{{Red, Green, Blue, Hex}}, Weights: []float64{.25}}
+		if !reflect.DeepEqual(result.Palette, expected) || result.Backend != "vision" {
+			t.Errorf("expected: %v\n returned: %v %v\n", expected, result.Palette, result.Backend)
+		}
+	}
+}
+
+type MockCalculator struct {
+	data []*pb.ColorInfo
+	err  error
+}
+
+func (m *MockCalculator) DetectImageProperties(ctx context.Context, img *pb.Image, ictx *pb.ImageContext, opts ...gax.CallOption) (*pb.ImageProperties, error) {
+	return &pb.ImageProperties{DominantColors: &pb.DominantColorsAnnotation{Colors: m.data}}, m.err
+}
+
+type MockVisionReader struct {
+	data []byte
+	err  error
+}
+
+func (m *MockVisionReader) NewImageFromReader(r io.Reader) (*pb.Image, error) {
+	return &pb.Image{Content: m.data}, m.err
+}
+
+func (m *MockVisionReader) NewImageFromURI(uri string) *pb.Image {
+	return &pb.Image{Content: m.data}
+}
+
+func TestCalculatePaletteFromReader(t *testing.T) {
+	for _, test := range []struct {
+		name            string
+		data            []*pb.ColorInfo
+		calculatorErr   error
+		expectedPalette *Palette
+		expectedErr     error
+	}{
+		{
+			name:            "should order colors by score",
+			data:            visionTestColors,
+			expectedPalette: &Palette{Colors: []Color{{Red, Green, Blue, Hex}, {255, 255, 255, "ffffff"}}, Weights: []float64{.25, .5}},
+		},
+		{
+			name:          "error occurs when image properties are calculated",
+			calculatorErr: errors.New("unable to calculate image properties"),
+			expectedErr:   &VisionError{Err: errors.New("unable to calculate image properties")},
+		},
+		{
+			name:        "error occurs when vision finds no dominant colors",
+			data:        nil,
+			expectedErr: ErrNoDominantColors,
+		},
+	} {
+		paletteCalculator := new(PaletteCalculator)
+		paletteCalculator.Calculator = &MockCalculator{data: test.data, err: test.calculatorErr}
+		paletteCalculator.Reader = &MockVisionReader{}
+
+		returnedPalette, err := paletteCalculator.CalculatePaletteFromReader(bytes.NewReader([]byte("jpeg")))
+
+		if !reflect.DeepEqual(test.expectedErr, err) {
+			t.Errorf("%s expected error: %v returned error: %v", test.name, test.expectedErr, err)
+		}
+		if !reflect.DeepEqual(test.expectedPalette, returnedPalette) {
+			t.Errorf("%s expected: %v\n returned: %v\n", test.name, test.expectedPalette, returnedPalette)
+		}
+	}
+}
+
+func TestCalculatePaletteFromReaderSpans(t *testing.T) {
+	tracer := new(MockTracer)
+	paletteCalculator := &PaletteCalculator{Tracer: tracer}
+	paletteCalculator.Calculator = &MockCalculator{data: visionTestColors}
+	paletteCalculator.Reader = &MockVisionReader{}
+
+	paletteCalculator.CalculatePaletteFromReader(bytes.NewReader([]byte("jpeg")))
+
+	expectedSpans := []string{
+		" > palettecalculator.CalculatePaletteFromReader",
+		"palettecalculator.CalculatePaletteFromReader > palettecalculator.Upload",
+		"palettecalculator.CalculatePaletteFromReader > palettecalculator.DetectImageProperties",
+		"palettecalculator.CalculatePaletteFromReader > palettecalculator.Convert",
+	}
+	if !reflect.DeepEqual(expectedSpans, tracer.spans) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedSpans, tracer.spans)
+	}
+}
+
+func TestCalculatePaletteFromReaderLogs(t *testing.T) {
+	var buf bytes.Buffer
+	paletteCalculator := new(PaletteCalculator).WithLogger(testLogger(&buf))
+	paletteCalculator.Calculator = &MockCalculator{data: visionTestColors}
+	paletteCalculator.Reader = &MockVisionReader{data: []byte("jpeg")}
+
+	paletteCalculator.CalculatePaletteFromReader(bytes.NewReader([]byte("jpeg")))
+
+	expected := "level=DEBUG msg=\"read image for upload\" size=4\n" +
+		"level=DEBUG msg=\"vision request finished\" colors=2\n" +
+		"level=INFO msg=\"extracted palette\" backend=vision colors=2\n"
+	if buf.String() != expected {
+		t.Errorf("expected: %v\n returned: %v\n", expected, buf.String())
+	}
+}
+
+func TestCalculatePaletteFromURI(t *testing.T) {
+	paletteCalculator := new(PaletteCalculator)
+	paletteCalculator.Calculator = &MockCalculator{data: visionTestColors}
+	paletteCalculator.Reader = &MockVisionReader{}
+	expectedPalette := &Palette{Colors: []Color{{Red, Green, Blue, Hex}, {255, 255, 255, "ffffff"}}, Weights: []float64{.25, .5}}
+
+	returnedPalette, err := paletteCalculator.CalculatePaletteFromURI("test.uri")
+
+	if err != nil {
+		t.Errorf("expected error: %v returned error: %v", nil, err)
+	}
+	if !reflect.DeepEqual(expectedPalette, returnedPalette) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedPalette, returnedPalette)
+	}
+}
+
+func TestCalculatePredominantColorAndPlaceholdersFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "image.png")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(f, gradientImage()); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	paletteCalculator := new(PaletteCalculator)
+	paletteCalculator.Calculator = &MockCalculator{data: []*pb.ColorInfo{{Color: &color.Color{Red: Red, Green: Green, Blue: Blue}, Score: .01}}}
+	paletteCalculator.Opener = &MockFileOpener{data: file}
+	paletteCalculator.Reader = &MockVisionReader{data: []byte{}}
+	expectedDominantColor := &Color{Red: Red, Green: Green, Blue: Blue, Hex: Hex}
+	expectedBlurHash := "L$HVCX2Y$5Sghpazjtf7gcfQfQfQ"
+
+	returnedDominantColor, returnedPlaceholders, err := paletteCalculator.CalculatePredominantColorAndPlaceholdersFromFile(path)
+
+	if !reflect.DeepEqual(expectedDominantColor, returnedDominantColor) {
+		t.Errorf("expected: %+v\n returned: %+v\n ", expectedDominantColor, returnedDominantColor)
+	}
+
+	if returnedPlaceholders == nil || expectedBlurHash != returnedPlaceholders.BlurHash || len(returnedPlaceholders.ThumbHash) == 0 {
+		t.Errorf("expected blurhash: %v\n returned: %+v\n ", expectedBlurHash, returnedPlaceholders)
+	}
+
+	if err != nil {
+		t.Errorf("expected error: <nil> returned error: %v", err)
+	}
+}
+
+func TestCalculatePredominantColorFromFileWithIndexedImage(t *testing.T) {
+	var pngData bytes.Buffer
+	png.Encode(&pngData, indexedTestImage())
+	path := filepath.Join(t.TempDir(), "image.png")
+	os.WriteFile(path, pngData.Bytes(), 0644)
+	file, _ := os.Open(path)
+	paletteCalculator := new(PaletteCalculator)
+	paletteCalculator.Opener = &MockFileOpener{data: file}
+	paletteCalculator.Calculator = &MockCalculator{err: errors.New("vision should not be called")}
+	expectedColor := &Color{24, 98, 119, "186277"}
+
+	returnedColor, err := paletteCalculator.CalculatePredominantColorFromFile(path)
+
+	if err != nil {
+		t.Errorf("expected error: %v returned error: %v", nil, err)
+	}
+	if !reflect.DeepEqual(expectedColor, returnedColor) {
+		t.Errorf("expected: %v\n returned: %v\n", expectedColor, returnedColor)
+	}
+}
+
+var visionTestColors = []*pb.ColorInfo{
+	{Color: &color.Color{Red: 255, Green: 255, Blue: 255}, Score: .2, PixelFraction: .5},
+	{Color: &color.Color{Red: Red, Green: Green, Blue: Blue}, Score: .7, PixelFraction: .25},
+}
@@ -0,0 +1,177 @@
+// Package watch extracts the palettes of images as they arrive in directories, e.g. an ingestion folder, passing
+// each result to a callback and saving its palette to a store.
+//
+// Files are extracted once they have gone Settle without changing, so an image still being copied in is not read
+// half written. An image written again later is extracted again.
+package watch
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/evancaplan/palettecalculator/pipeline"
+	"github.com/evancaplan/palettecalculator/store"
+	"github.com/fsnotify/fsnotify"
+)
+
+// How long a file must go unchanged before it is extracted when a Watcher does not set Settle
+const DefaultSettle = 500 * time.Millisecond
+
+// Extensions of the files extracted when a Watcher does not set Extensions
+var DefaultExtensions = []string{".png", ".jpg", ".jpeg", ".gif"}
+
+// Watches directories for new images and extracts their palettes
+type Watcher struct {
+	// Directories watched, not including their subdirectories
+	Dirs []string
+	// Extracts the images, nil extracts them locally one at a time
+	Pipeline *pipeline.Pipeline
+	// Called with the result of each image, nil calls nothing. A failed save to Store is reported as the result's
+	// error
+	OnResult func(pipeline.Result)
+	// Saves each palette keyed by the image's path, nil saves nothing
+	Store store.Store
+	// How long a file must go unchanged before it is extracted, zero uses DefaultSettle
+	Settle time.Duration
+	// Extensions extracted, compared without case, nil uses DefaultExtensions
+	Extensions []string
+	// Extract the images already in Dirs when Run starts, not just the ones that arrive after
+	Existing bool
+}
+
+// Watches until ctx is done, returning nil, or watching fails, returning its error. Extractions still running are
+// cancelled and Run returns once they have stopped
+func (w *Watcher) Run(ctx context.Context) error {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer fw.Close()
+	for _, dir := range w.Dirs {
+		if err := fw.Add(dir); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	inputs := make(chan pipeline.Input)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.handle(ctx, w.pipeline().Run(ctx, inputs))
+	}()
+	defer func() {
+		close(inputs)
+		<-done
+	}()
+
+	// changed files by when they last changed, then settled files waiting for the pipeline
+	changed := make(map[string]time.Time)
+	var queue []string
+	if w.Existing {
+		for _, dir := range w.Dirs {
+			matches, err := filepath.Glob(filepath.Join(dir, "*"))
+			if err != nil {
+				return err
+			}
+			for _, path := range matches {
+				if w.isImage(path) {
+					queue = append(queue, path)
+				}
+			}
+		}
+	}
+
+	tick := time.NewTicker(w.settle() / 2)
+	defer tick.Stop()
+	for {
+		// the pipeline is only offered an input when one is waiting
+		var (
+			send chan<- pipeline.Input
+			next pipeline.Input
+		)
+		if len(queue) > 0 {
+			send, next = inputs, pipeline.Input{ID: queue[0], Source: queue[0]}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-fw.Errors:
+			if !ok {
+				return nil
+			}
+			return err
+		case event, ok := <-fw.Events:
+			if !ok {
+				return nil
+			}
+			switch {
+			case event.Has(fsnotify.Create) || event.Has(fsnotify.Write):
+				if w.isImage(event.Name) {
+					changed[event.Name] = time.Now()
+				}
+			case event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename):
+				delete(changed, event.Name)
+			}
+		case now := <-tick.C:
+			for path, at := range changed {
+				if now.Sub(at) >= w.settle() {
+					queue = append(queue, path)
+					delete(changed, path)
+				}
+			}
+		case send <- next:
+			queue = queue[1:]
+		}
+	}
+}
+
+// Saves and reports results until results is closed
+func (w *Watcher) handle(ctx context.Context, results <-chan pipeline.Result) {
+	for result := range results {
+		if result.Err == nil && w.Store != nil {
+			if err := w.Store.Save(ctx, result.Input.Key(), result.Palette, time.Now()); err != nil {
+				result.Err = err
+			}
+		}
+		if w.OnResult != nil {
+			w.OnResult(result)
+		}
+	}
+}
+
+func (w *Watcher) isImage(path string) bool {
+	extensions := w.Extensions
+	if extensions == nil {
+		extensions = DefaultExtensions
+	}
+	ext := filepath.Ext(path)
+	for _, e := range extensions {
+		if strings.EqualFold(e, ext) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (w *Watcher) pipeline() *pipeline.Pipeline {
+	if w.Pipeline != nil {
+		return w.Pipeline
+	}
+	p := new(pipeline.Pipeline)
+	p.Backend.Local = true
+
+	return p
+}
+
+func (w *Watcher) settle() time.Duration {
+	if w.Settle > 0 {
+		return w.Settle
+	}
+	return DefaultSettle
+}
@@ -0,0 +1,93 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/evancaplan/palettecalculator/pipeline"
+	"github.com/evancaplan/palettecalculator/store"
+)
+
+func encodeImage(t *testing.T, c color.RGBA) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	for x := 0; x < 2; x++ {
+		for y := 0; y < 2; y++ {
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing.png")
+	if err := os.WriteFile(existing, encodeImage(t, color.RGBA{B: 255, A: 255}), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := store.NewMemoryStore()
+	results := make(chan pipeline.Result, 10)
+	w := &Watcher{
+		Dirs:     []string{dir},
+		OnResult: func(r pipeline.Result) { results <- r },
+		Store:    s,
+		Settle:   20 * time.Millisecond,
+		Existing: true,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() { errs <- w.Run(ctx) }()
+
+	// images arrive along with files that are not images
+	arrived := filepath.Join(dir, "arrived.PNG")
+	time.Sleep(50 * time.Millisecond)
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not an image"), 0o644)
+	os.WriteFile(arrived, encodeImage(t, color.RGBA{R: 255, A: 255}), 0o644)
+
+	expected := map[string]string{existing: "0000ff", arrived: "ff0000"}
+	for range expected {
+		select {
+		case r := <-results:
+			hex, ok := expected[r.Input.Source]
+			if !ok || r.Err != nil || r.Palette.Colors[0].Hex != hex {
+				t.Errorf("expected: %v\n returned: %v %v\n", hex, r.Input.Source, r.Err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected a result for each image")
+		}
+	}
+
+	cancel()
+	if err := <-errs; err != nil {
+		t.Errorf("expected: %v\n returned: %v\n", nil, err)
+	}
+	select {
+	case r := <-results:
+		t.Errorf("expected no other results, returned %v", r.Input.Source)
+	default:
+	}
+	for path := range expected {
+		if _, err := s.Latest(context.Background(), path); err != nil {
+			t.Errorf("expected: %v\n returned: %v\n", nil, err)
+		}
+	}
+}
+
+func TestWatcherMissingDir(t *testing.T) {
+	w := &Watcher{Dirs: []string{filepath.Join(t.TempDir(), "missing")}}
+	if err := w.Run(context.Background()); err == nil {
+		t.Errorf("expected an error watching a missing directory")
+	}
+}
@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/evancaplan/palettecalculator/serverless/awslambda"
+	"github.com/evancaplan/palettecalculator/serverless/gcf"
+)
+
+// Message of a Cloud Storage notification delivered by Pub/Sub, given the Pub/Sub message's id, data, attributes and
+// delivery attempt. Returns nil for events other than OBJECT_FINALIZE and for objects that are not images, ack
+// those without handling them. The image URI is gs://bucket/object, read by the Vision backend or Worker.Open
+func DecodeGCSNotification(id string, data []byte, attributes map[string]string, attempt int) (*Message, error) {
+	if eventType := attributes["eventType"]; eventType != "" && eventType != "OBJECT_FINALIZE" {
+		return nil, nil
+	}
+
+	// the object is in the attributes, and in data unless the notification's payload format is NONE
+	object := gcf.StorageObject{Bucket: attributes["bucketId"], Name: attributes["objectId"]}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &object); err != nil {
+			return nil, fmt.Errorf("decoding gcs notification %s: %w", id, err)
+		}
+	}
+	if object.Bucket == "" || object.Name == "" {
+		return nil, fmt.Errorf("gcs notification %s has no bucket or object", id)
+	}
+	if object.ContentType != "" && !strings.HasPrefix(object.ContentType, "image/") {
+		return nil, nil
+	}
+
+	return &Message{
+		ID:         id,
+		ImageURI:   fmt.Sprintf("gs://%s/%s", object.Bucket, object.Name),
+		Attempt:    attempt,
+		Attributes: attributes,
+	}, nil
+}
+
+// Messages of an S3 event notification delivered by SQS, given the SQS message's id, body and receive count. Returns
+// one message per created object, none for the s3:TestEvent sent when notifications are configured or for other
+// events. The image URI is s3://bucket/key with the key decoded, read by Worker.Open. S3 sends one record per event,
+// should a body hold more its messages share the SQS message and so its handle
+func DecodeS3Notification(id string, body []byte, attempt int) ([]*Message, error) {
+	var event awslambda.S3Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("decoding s3 notification %s: %w", id, err)
+	}
+
+	var messages []*Message
+	for i, record := range event.Records {
+		if !strings.HasPrefix(record.EventName, "ObjectCreated:") {
+			continue
+		}
+		bucket := record.S3.Bucket.Name
+		// keys are form encoded, spaces arrive as +
+		key, err := url.QueryUnescape(record.S3.Object.Key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q in s3 notification %s: %w", record.S3.Object.Key, id, err)
+		}
+
+		messageID := id
+		if len(event.Records) > 1 {
+			messageID = fmt.Sprintf("%s/%d", id, i)
+		}
+		messages = append(messages, &Message{
+			ID:         messageID,
+			ImageURI:   fmt.Sprintf("s3://%s/%s", bucket, key),
+			Attempt:    attempt,
+			Attributes: map[string]string{"bucket": bucket, "key": key},
+		})
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,101 @@
+package worker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeGCSNotification(t *testing.T) {
+	finalize := map[string]string{"eventType": "OBJECT_FINALIZE", "bucketId": "uploads", "objectId": "hero.png"}
+
+	tests := []struct {
+		name        string
+		data        string
+		attributes  map[string]string
+		expected    *Message
+		expectedErr string
+	}{
+		{
+			name:       "json payload",
+			data:       `{"bucket": "uploads", "name": "hero.png", "contentType": "image/png"}`,
+			attributes: finalize,
+			expected:   &Message{ID: "1", ImageURI: "gs://uploads/hero.png", Attempt: 2, Attributes: finalize},
+		},
+		{
+			name:       "no payload",
+			attributes: finalize,
+			expected:   &Message{ID: "1", ImageURI: "gs://uploads/hero.png", Attempt: 2, Attributes: finalize},
+		},
+		{
+			name:       "not an image",
+			data:       `{"bucket": "uploads", "name": "notes.txt", "contentType": "text/plain"}`,
+			attributes: finalize,
+		},
+		{
+			name:       "deleted object",
+			attributes: map[string]string{"eventType": "OBJECT_DELETE", "bucketId": "uploads", "objectId": "hero.png"},
+		},
+		{
+			name:        "no object",
+			attributes:  map[string]string{"eventType": "OBJECT_FINALIZE"},
+			expectedErr: "gcs notification 1 has no bucket or object",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			returned, err := DecodeGCSNotification("1", []byte(tt.data), tt.attributes, 2)
+			if (err != nil || tt.expectedErr != "") && (err == nil || err.Error() != tt.expectedErr) {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expectedErr, err)
+			}
+			if !reflect.DeepEqual(returned, tt.expected) {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expected, returned)
+			}
+		})
+	}
+}
+
+func TestDecodeS3Notification(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		expected    []*Message
+		expectedErr string
+	}{
+		{
+			name: "created object",
+			body: `{"Records": [{"eventName": "ObjectCreated:Put", "s3": {"bucket": {"name": "uploads"}, "object": {"key": "summer+sale/hero%281%29.png"}}}]}`,
+			expected: []*Message{{
+				ID:         "1",
+				ImageURI:   "s3://uploads/summer sale/hero(1).png",
+				Attempt:    3,
+				Attributes: map[string]string{"bucket": "uploads", "key": "summer sale/hero(1).png"},
+			}},
+		},
+		{
+			name: "removed object",
+			body: `{"Records": [{"eventName": "ObjectRemoved:Delete", "s3": {"bucket": {"name": "uploads"}, "object": {"key": "hero.png"}}}]}`,
+		},
+		{
+			name: "test event",
+			body: `{"Service": "Amazon S3", "Event": "s3:TestEvent", "Bucket": "uploads"}`,
+		},
+		{
+			name:        "not json",
+			body:        "hero.png",
+			expectedErr: "decoding s3 notification 1: invalid character 'h' looking for beginning of value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			returned, err := DecodeS3Notification("1", []byte(tt.body), 3)
+			if (err != nil || tt.expectedErr != "") && (err == nil || err.Error() != tt.expectedErr) {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expectedErr, err)
+			}
+			if !reflect.DeepEqual(returned, tt.expected) {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expected, returned)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+// Package worker extracts the palettes of images referenced by queue messages, e.g. from Pub/Sub or SQS, and
+// publishes the results. Queues plug in through Consumer and Publisher.
+//
+// A message is acked once its result is published. Failed extractions and publishes are nacked with an exponential
+// backoff and retried until MaxAttempts, after which a Result carrying the error is published and the message acked
+// so it stops being redelivered. Failures a retry won't fix, such as an image that can't be decoded or a 404, are
+// published and acked on the first attempt.
+//
+// DecodeGCSNotification and DecodeS3Notification turn the bucket notifications Pub/Sub and SQS deliver into messages.
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	"github.com/evancaplan/palettecalculator/serverless"
+)
+
+// Attempts before a message is given up on when a Worker does not set MaxAttempts
+const DefaultMaxAttempts = 5
+
+// Delay before the first retry when a Worker does not set RetryDelay, doubled on each further attempt
+const DefaultRetryDelay = time.Second
+
+// Failure of a message that redelivery won't fix, wrap it in errors returned by Worker.Open to skip the retries,
+// e.g. fmt.Errorf("%w: no such object", worker.ErrPermanent)
+var ErrPermanent = errors.New("permanent failure")
+
+// Longest delay between retries
+const MaxRetryDelay = 5 * time.Minute
+
+// Time allowed to ack or nack a handled message, which is done even after Run's context is cancelled
+const SettleTimeout = 10 * time.Second
+
+// Image reference received from a queue
+type Message struct {
+	// Queue's id of the message
+	ID string
+	// Image to extract: http(s), gs:// with the Vision backend, or any URI Worker.Open understands
+	ImageURI string
+	// Delivery attempt starting at 1, e.g. SQS ApproximateReceiveCount or the Pub/Sub delivery attempt. Zero when the
+	// queue does not count deliveries, leaving retries to its dead letter policy
+	Attempt int
+	// Queue attributes of the message, passed through to its Result
+	Attributes map[string]string
+	// Consumer's handle of the message, e.g. an SQS receipt handle
+	Handle interface{}
+}
+
+// Source of messages with acknowledgement
+type Consumer interface {
+	// Returns the next message, blocking until one arrives or ctx is done
+	Receive(ctx context.Context) (*Message, error)
+	// Acknowledges a handled message so it is not redelivered
+	Ack(ctx context.Context, m *Message) error
+	// Returns a message to the queue to be redelivered after delay, e.g. an SQS visibility change or Pub/Sub nack
+	Nack(ctx context.Context, m *Message, delay time.Duration) error
+}
+
+// Destination of results
+type Publisher interface {
+	Publish(ctx context.Context, r *Result) error
+}
+
+// Outcome of a message, its palette or the error it was given up with
+type Result struct {
+	MessageID  string                     `json:"message-id"`
+	ImageURI   string                     `json:"image-uri"`
+	Attributes map[string]string          `json:"attributes,omitempty"`
+	Palette    *palettecalculator.Palette `json:"palette,omitempty"`
+	Error      string                     `json:"error,omitempty"`
+}
+
+// Consumes messages, extracts their palettes and publishes the results
+type Worker struct {
+	Consumer  Consumer
+	Publisher Publisher
+	Backend   serverless.Backend
+	// Opens an image URI for a local backend, nil fetches http(s) URIs
+	Open func(ctx context.Context, uri string) (io.ReadCloser, error)
+	// Messages handled at once, zero handles one at a time
+	Concurrency int
+	// Attempts before a message is given up on, zero uses DefaultMaxAttempts
+	MaxAttempts int
+	// Delay before the first retry, zero uses DefaultRetryDelay
+	RetryDelay time.Duration
+}
+
+// Handles messages until ctx is done, returning nil, or Receive fails, returning its error
+func (w *Worker) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	for i := 0; i < maxInt(w.Concurrency, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				m, err := w.Consumer.Receive(ctx)
+				if ctx.Err() != nil {
+					return
+				}
+				if err != nil {
+					once.Do(func() { firstErr = err })
+					cancel()
+					return
+				}
+				// errors are reported to the queue by nacking, only a failed ack or nack is left to redelivery
+				w.Handle(ctx, m)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+// Extracts the palette of m and publishes it, acking m on success and nacking it for a retry on failure
+func (w *Worker) Handle(ctx context.Context, m *Message) error {
+	p, err := w.extract(ctx, m)
+	result := &Result{MessageID: m.ID, ImageURI: m.ImageURI, Attributes: m.Attributes, Palette: p}
+	if err != nil {
+		if !permanent(err) && w.retry(m) {
+			return w.nack(ctx, m)
+		}
+		result.Error = err.Error()
+	}
+
+	if err := w.Publisher.Publish(ctx, result); err != nil {
+		return w.nack(ctx, m)
+	}
+
+	settleCtx, cancel := settleContext(ctx)
+	defer cancel()
+	return w.Consumer.Ack(settleCtx, m)
+}
+
+func (w *Worker) nack(ctx context.Context, m *Message) error {
+	settleCtx, cancel := settleContext(ctx)
+	defer cancel()
+	return w.Consumer.Nack(settleCtx, m, w.retryDelay(m))
+}
+
+// Context for acking or nacking a message, kept from ctx's cancellation so a shutdown mid message still returns it
+// to the queue rather than leaving it to its visibility timeout
+func settleContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), SettleTimeout)
+}
+
+func (w *Worker) extract(ctx context.Context, m *Message) (*palettecalculator.Palette, error) {
+	if m.ImageURI == "" {
+		return nil, fmt.Errorf("%w: message has no image uri", ErrPermanent)
+	}
+	if !w.Backend.Local && w.Open == nil {
+		return w.Backend.ExtractURI(ctx, m.ImageURI)
+	}
+
+	open := w.Open
+	if open == nil {
+		open = fetch
+	}
+	r, err := open(ctx, m.ImageURI)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return w.Backend.ExtractReader(ctx, r)
+}
+
+// Whether err fails every attempt: a bad message, an image that isn't one or has no colors, or a client error
+func permanent(err error) bool {
+	var fetchErr *fetchError
+	if errors.As(err, &fetchErr) {
+		return fetchErr.status >= 400 && fetchErr.status < 500 &&
+			fetchErr.status != http.StatusRequestTimeout && fetchErr.status != http.StatusTooManyRequests
+	}
+
+	return errors.Is(err, ErrPermanent) || errors.Is(err, palettecalculator.ErrDecode) ||
+		errors.Is(err, palettecalculator.ErrNoDominantColors) || errors.Is(err, palettecalculator.ErrInvalidColor)
+}
+
+// Whether a failed message has attempts left
+func (w *Worker) retry(m *Message) bool {
+	maxAttempts := w.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	return m.Attempt < maxAttempts
+}
+
+// Delay before redelivering m, doubling with each attempt up to MaxRetryDelay
+func (w *Worker) retryDelay(m *Message) time.Duration {
+	delay := w.RetryDelay
+	if delay <= 0 {
+		delay = DefaultRetryDelay
+	}
+	for attempt := 1; attempt < m.Attempt && delay < MaxRetryDelay; attempt++ {
+		delay *= 2
+	}
+	if delay > MaxRetryDelay {
+		delay = MaxRetryDelay
+	}
+
+	return delay
+}
+
+func fetch(ctx context.Context, uri string) (io.ReadCloser, error) {
+	if !strings.HasPrefix(uri, "http://") && !strings.HasPrefix(uri, "https://") {
+		return nil, fmt.Errorf("%w: cannot open %q: expected http or https, set Worker.Open for other schemes", ErrPermanent, uri)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, &fetchError{uri: uri, status: resp.StatusCode, statusText: resp.Status}
+	}
+
+	return resp.Body, nil
+}
+
+// Unsuccessful response to fetching an image
+type fetchError struct {
+	uri        string
+	status     int
+	statusText string
+}
+
+func (e *fetchError) Error() string {
+	return fmt.Sprintf("fetching %s: %s", e.uri, e.statusText)
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
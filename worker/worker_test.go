@@ -0,0 +1,283 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	palettecalculator "github.com/evancaplan/palettecalculator"
+	"github.com/evancaplan/palettecalculator/serverless"
+)
+
+// In memory queue recording acks and nacks, Receive fails with errEmpty once it runs out of messages
+type fakeQueue struct {
+	mu       sync.Mutex
+	messages []*Message
+	acked    []string
+	nacked   map[string]time.Duration
+	results  []*Result
+	publish  error
+}
+
+var errEmpty = errors.New("queue is empty")
+
+func (q *fakeQueue) Receive(ctx context.Context) (*Message, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.messages) == 0 {
+		return nil, errEmpty
+	}
+	m := q.messages[0]
+	q.messages = q.messages[1:]
+	return m, nil
+}
+
+func (q *fakeQueue) Ack(ctx context.Context, m *Message) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.acked = append(q.acked, m.ID)
+	return nil
+}
+
+func (q *fakeQueue) Nack(ctx context.Context, m *Message, delay time.Duration) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.nacked == nil {
+		q.nacked = make(map[string]time.Duration)
+	}
+	q.nacked[m.ID] = delay
+	return nil
+}
+
+func (q *fakeQueue) Publish(ctx context.Context, r *Result) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.publish != nil {
+		return q.publish
+	}
+	q.results = append(q.results, r)
+	return nil
+}
+
+func testImage(t *testing.T) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	for x := 0; x < 2; x++ {
+		for y := 0; y < 2; y++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestHandle(t *testing.T) {
+	img := testImage(t)
+	red := &palettecalculator.Palette{Colors: []palettecalculator.Color{{Red: 255, Hex: "ff0000"}}, Weights: []float64{1}}
+
+	tests := []struct {
+		name            string
+		message         *Message
+		publishErr      error
+		expectedAcked   []string
+		expectedNacked  map[string]time.Duration
+		expectedResults []*Result
+	}{
+		{
+			name:            "publishes and acks",
+			message:         &Message{ID: "1", ImageURI: "mem://red.png", Attempt: 1, Attributes: map[string]string{"sku": "42"}},
+			expectedAcked:   []string{"1"},
+			expectedResults: []*Result{{MessageID: "1", ImageURI: "mem://red.png", Attributes: map[string]string{"sku": "42"}, Palette: red}},
+		},
+		{
+			name:           "nacks with backoff",
+			message:        &Message{ID: "2", ImageURI: "mem://missing.png", Attempt: 3},
+			expectedNacked: map[string]time.Duration{"2": 4 * time.Second},
+		},
+		{
+			name:            "gives up after max attempts",
+			message:         &Message{ID: "3", ImageURI: "mem://missing.png", Attempt: 5},
+			expectedAcked:   []string{"3"},
+			expectedResults: []*Result{{MessageID: "3", ImageURI: "mem://missing.png", Error: "no such image"}},
+		},
+		{
+			name:           "retries unknown attempts",
+			message:        &Message{ID: "4", ImageURI: "mem://missing.png"},
+			expectedNacked: map[string]time.Duration{"4": time.Second},
+		},
+		{
+			name:            "acks images that fail to decode",
+			message:         &Message{ID: "6", ImageURI: "mem://notes.txt", Attempt: 1},
+			expectedAcked:   []string{"6"},
+			expectedResults: []*Result{{MessageID: "6", ImageURI: "mem://notes.txt", Error: "unable to decode image: image: unknown format"}},
+		},
+		{
+			name:            "acks messages without an image",
+			message:         &Message{ID: "7", Attempt: 1},
+			expectedAcked:   []string{"7"},
+			expectedResults: []*Result{{MessageID: "7", Error: "permanent failure: message has no image uri"}},
+		},
+		{
+			name:           "nacks when publishing fails",
+			message:        &Message{ID: "5", ImageURI: "mem://red.png", Attempt: 1},
+			publishErr:     errors.New("topic not found"),
+			expectedNacked: map[string]time.Duration{"5": time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &fakeQueue{publish: tt.publishErr}
+			w := &Worker{
+				Consumer:  q,
+				Publisher: q,
+				Backend:   serverless.Backend{Local: true, K: 1},
+				Open: func(ctx context.Context, uri string) (io.ReadCloser, error) {
+					switch uri {
+					case "mem://red.png":
+						return io.NopCloser(bytes.NewReader(img)), nil
+					case "mem://notes.txt":
+						return io.NopCloser(strings.NewReader("not a png")), nil
+					}
+					return nil, errors.New("no such image")
+				},
+			}
+
+			if err := w.Handle(context.Background(), tt.message); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(q.acked, tt.expectedAcked) {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expectedAcked, q.acked)
+			}
+			if !reflect.DeepEqual(q.nacked, tt.expectedNacked) {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expectedNacked, q.nacked)
+			}
+			if !reflect.DeepEqual(q.results, tt.expectedResults) {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expectedResults, q.results)
+			}
+		})
+	}
+}
+
+func TestHandleNacksAfterCancel(t *testing.T) {
+	q := &fakeQueue{}
+	w := &Worker{
+		Consumer:  q,
+		Publisher: q,
+		Backend:   serverless.Backend{Local: true, K: 1},
+		Open: func(ctx context.Context, uri string) (io.ReadCloser, error) {
+			return nil, ctx.Err()
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := w.Handle(ctx, &Message{ID: "1", ImageURI: "mem://red.png", Attempt: 1}); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]time.Duration{"1": time.Second}
+	if !reflect.DeepEqual(q.nacked, expected) {
+		t.Errorf("expected: %v\n returned: %v\n", expected, q.nacked)
+	}
+}
+
+func TestHandleFetchStatus(t *testing.T) {
+	imageServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing.png":
+			http.NotFound(w, r)
+		case "/busy.png":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer imageServer.Close()
+
+	tests := []struct {
+		path           string
+		expectedAcked  []string
+		expectedNacked map[string]time.Duration
+	}{
+		{path: "/missing.png", expectedAcked: []string{"1"}},
+		{path: "/busy.png", expectedNacked: map[string]time.Duration{"1": time.Second}},
+		{path: "/down.png", expectedNacked: map[string]time.Duration{"1": time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			q := &fakeQueue{}
+			w := &Worker{Consumer: q, Publisher: q, Backend: serverless.Backend{Local: true, K: 1}}
+
+			if err := w.Handle(context.Background(), &Message{ID: "1", ImageURI: imageServer.URL + tt.path, Attempt: 1}); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(q.acked, tt.expectedAcked) {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expectedAcked, q.acked)
+			}
+			if !reflect.DeepEqual(q.nacked, tt.expectedNacked) {
+				t.Errorf("expected: %v\n returned: %v\n", tt.expectedNacked, q.nacked)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	w := &Worker{RetryDelay: time.Minute}
+	for attempt, expected := range map[int]time.Duration{0: time.Minute, 1: time.Minute, 2: 2 * time.Minute, 3: 4 * time.Minute, 10: MaxRetryDelay} {
+		if returned := w.retryDelay(&Message{Attempt: attempt}); returned != expected {
+			t.Errorf("expected: %v\n returned: %v\n", expected, returned)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	img := testImage(t)
+	q := &fakeQueue{}
+	for _, id := range []string{"a", "b", "c", "d"} {
+		q.messages = append(q.messages, &Message{ID: id, ImageURI: "mem://red.png", Attempt: 1})
+	}
+	w := &Worker{
+		Consumer:    q,
+		Publisher:   q,
+		Backend:     serverless.Backend{Local: true, K: 1},
+		Concurrency: 3,
+		Open: func(ctx context.Context, uri string) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(img)), nil
+		},
+	}
+
+	if err := w.Run(context.Background()); err != errEmpty {
+		t.Errorf("expected: %v\n returned: %v\n", errEmpty, err)
+	}
+	if len(q.acked) != 4 || len(q.results) != 4 {
+		t.Errorf("expected: %v\n returned: %v acked %v results\n", 4, len(q.acked), len(q.results))
+	}
+
+	// a done context stops the worker without an error
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := w.Run(ctx); err != nil {
+		t.Errorf("expected: %v\n returned: %v\n", nil, err)
+	}
+}